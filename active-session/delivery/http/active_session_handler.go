@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// activeSessionHandler represent the http handler for active session
+type activeSessionHandler struct {
+	aUsecase   domain.ActiveSessionUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewActiveSessionHandler will initialize the active session resources endpoint
+func NewActiveSessionHandler(r *gin.Engine, au domain.ActiveSessionUsecase, v validator, jh jwtHandler) {
+	h := &activeSessionHandler{
+		aUsecase:   au,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/active-sessions", h.jwtHandler.ParseUUIDFromToken, h.StartActiveSession)
+	r.GET("children/uuid/:children_uuid/active-sessions", h.jwtHandler.ParseUUIDFromToken, h.GetActiveSessions)
+	r.POST("children/uuid/:children_uuid/active-sessions/uuid/:session_uuid/pause", h.jwtHandler.ParseUUIDFromToken, h.PauseActiveSession)
+	r.POST("children/uuid/:children_uuid/active-sessions/uuid/:session_uuid/resume", h.jwtHandler.ParseUUIDFromToken, h.ResumeActiveSession)
+	r.POST("children/uuid/:children_uuid/active-sessions/uuid/:session_uuid/switch-side", h.jwtHandler.ParseUUIDFromToken, h.SwitchActiveSessionSide)
+	r.POST("children/uuid/:children_uuid/active-sessions/uuid/:session_uuid/stop", h.jwtHandler.ParseUUIDFromToken, h.StopActiveSession)
+}
+
+// StartActiveSession deliver data to StartActiveSession of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) StartActiveSession(c *gin.Context) {
+	req := new(startActiveSessionRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	as := &domain.ActiveSession{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		SessionType:  domain.String(req.SessionType),
+		StartedAt:    domain.Time(time.Now()),
+	}
+	if req.Side != "" {
+		as.Side = domain.String(req.Side)
+	}
+
+	if req.StartedAt != "" {
+		if t, err := time.Parse(time.RFC3339, req.StartedAt); err != nil {
+			err = errors.Wrap(err, "failed to parse started_at time string")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		} else {
+			as.StartedAt = domain.Time(t)
+		}
+	}
+
+	switch uuid, err := ah.aUsecase.StartActiveSession(c.Request.Context(), c.GetString("uuid"), as); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to start active session")
+		resp["session_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "StartActiveSession return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetActiveSessions deliver data to GetActiveSessions of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) GetActiveSessions(c *gin.Context) {
+	req := new(getActiveSessionsRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	sessions, err := ah.aUsecase.GetActiveSessions(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get active sessions")
+		resp["sessions"] = sessions
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetActiveSessions return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// PauseActiveSession deliver data to PauseActiveSession of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) PauseActiveSession(c *gin.Context) {
+	ah.changeActiveSession(c, ah.aUsecase.PauseActiveSession, "pause")
+}
+
+// ResumeActiveSession deliver data to ResumeActiveSession of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) ResumeActiveSession(c *gin.Context) {
+	ah.changeActiveSession(c, ah.aUsecase.ResumeActiveSession, "resume")
+}
+
+// SwitchActiveSessionSide deliver data to SwitchActiveSessionSide of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) SwitchActiveSessionSide(c *gin.Context) {
+	ah.changeActiveSession(c, ah.aUsecase.SwitchActiveSessionSide, "switch side of")
+}
+
+// changeActiveSession method deliver data to usecase method changing state of active session
+func (ah *activeSessionHandler) changeActiveSession(
+	c *gin.Context,
+	change func(ctx context.Context, parentUUID, childrenUUID, sessionUUID string) (domain.ActiveSession, error),
+	action string,
+) {
+	req := new(changeActiveSessionRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	session, err := change(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.SessionUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to "+action+" active session")
+		resp["session"] = session
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "failed to "+action+" active session with unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// StopActiveSession deliver data to StopActiveSession of domain.ActiveSessionUsecase
+func (ah *activeSessionHandler) StopActiveSession(c *gin.Context) {
+	req := new(stopActiveSessionRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	recordType, recordUUID, err := ah.aUsecase.StopActiveSession(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.SessionUUID, req.SleepType)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to stop active session")
+		resp["record_type"] = recordType
+		resp["record_uuid"] = recordUUID
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "StopActiveSession return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (ah *activeSessionHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := ah.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// startActiveSessionRequest is request for activeSessionHandler.StartActiveSession
+// StartedAt is now if empty, and Side is used only for feeding session (left if empty)
+type startActiveSessionRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	SessionType  string `json:"session_type" validate:"required,oneof=feeding sleep"`
+	StartedAt    string `json:"started_at" validate:"max=30"`
+	Side         string `json:"side" validate:"omitempty,oneof=left right"`
+}
+
+func (r *startActiveSessionRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getActiveSessionsRequest is request for activeSessionHandler.GetActiveSessions
+type getActiveSessionsRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getActiveSessionsRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// changeActiveSessionRequest is request for activeSessionHandler.PauseActiveSession, ResumeActiveSession & SwitchActiveSessionSide
+type changeActiveSessionRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	SessionUUID  string `uri:"session_uuid" validate:"required,uuid=active_session"`
+}
+
+func (r *changeActiveSessionRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// stopActiveSessionRequest is request for activeSessionHandler.StopActiveSession
+// SleepType is required only for sleep session
+type stopActiveSessionRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	SessionUUID  string `uri:"session_uuid" validate:"required,uuid=active_session"`
+	SleepType    string `json:"sleep_type" validate:"omitempty,oneof=nap night"`
+}
+
+func (r *stopActiveSessionRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	if c.Request.ContentLength == 0 {
+		return nil
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// activeSessionRepository is implementation of domain.ActiveSessionRepository using mysql
+type activeSessionRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// ActiveSessionRepository return implementation of domain.ActiveSessionRepository using mysql
+func ActiveSessionRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.ActiveSessionRepository {
+	repo := &activeSessionRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.ActiveSession{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate active session model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.ActiveSessionRepository interface
+func (ar *activeSessionRepository) Store(ctx tx.Context, as *domain.ActiveSession) (err error) {
+	if domain.StringValue(as.UUID) == "" {
+		if as.UUID, err = ar.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = ar.validator.ValidateStruct(as); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ActiveSession")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("active_session").
+		Columns("uuid", "children_uuid", "session_type", "state", "started_at", "segment_started_at", "side", "elapsed_seconds", "left_seconds", "right_seconds").
+		Values(as.UUID, as.ChildrenUUID, as.SessionType, as.State, as.StartedAt, as.SegmentStartedAt, as.Side, as.ElapsedSeconds, as.LeftSeconds, as.RightSeconds).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert active session")
+			_, key := ar.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert active session")
+			fk := ar.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert active session return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert active session return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.ActiveSessionRepository interface
+func (ar *activeSessionRepository) GetByUUID(ctx tx.Context, uuid string) (as domain.ActiveSession, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("active_session").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&as, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select active session")}
+	default:
+		err = errors.Wrap(err, "select active session return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.ActiveSessionRepository interface
+func (ar *activeSessionRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (ass []domain.ActiveSession, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("active_session").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("started_at").ToSql()
+
+	ass = []domain.ActiveSession{}
+	if err = _tx.Select(&ass, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select active sessions return unexpected error")
+	}
+	return
+}
+
+// Update is implement Update method of domain.ActiveSessionRepository interface
+// timer columns (state, segment_started_at, side & seconds) are updated to value in model
+func (ar *activeSessionRepository) Update(ctx tx.Context, as *domain.ActiveSession) (err error) {
+	if domain.StringValue(as.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = ar.validator.ValidateStruct(as); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ActiveSession")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("active_session").
+		Set("state", as.State).
+		Set("segment_started_at", as.SegmentStartedAt).
+		Set("side", as.Side).
+		Set("elapsed_seconds", as.ElapsedSeconds).
+		Set("left_seconds", as.LeftSeconds).
+		Set("right_seconds", as.RightSeconds).
+		Where("uuid = ?", as.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "failed to update active session")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ActiveSessionRepository interface
+func (ar *activeSessionRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("active_session").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete active session return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("active session with that uuid is not exist")}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of active session table
+func (ar *activeSessionRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	as := new(domain.ActiveSession)
+
+	for {
+		uuid := as.GenerateRandomUUID()
+		_, err := ar.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// activeSessionUsecase is used for usecase layer which implement domain.ActiveSessionUsecase interface
+type activeSessionUsecase struct {
+	// activeSessionRepository is repository interface about domain.ActiveSession model
+	activeSessionRepository domain.ActiveSessionRepository
+
+	// feedingRepository is repository interface about domain.Feeding model
+	feedingRepository domain.FeedingRepository
+
+	// sleepRepository is repository interface about domain.Sleep model
+	sleepRepository domain.SleepRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// ActiveSessionUsecase return implementation of domain.ActiveSessionUsecase
+func ActiveSessionUsecase(
+	ar domain.ActiveSessionRepository,
+	fr domain.FeedingRepository,
+	sr domain.SleepRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.ActiveSessionUsecase {
+	return &activeSessionUsecase{
+		activeSessionRepository: ar,
+		feedingRepository:       fr,
+		sleepRepository:         sr,
+		childrenRepository:      cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// StartActiveSession implement StartActiveSession method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) StartActiveSession(
+	ctx context.Context,
+	parentUUID string,
+	as *domain.ActiveSession,
+) (uuid string, err error) {
+	switch domain.StringValue(as.SessionType) {
+	case domain.ActiveSessionFeeding:
+		if as.Side == nil {
+			as.Side = domain.String(domain.BreastSideLeft)
+		}
+	case domain.ActiveSessionSleep:
+		as.Side = nil
+	}
+	as.State = domain.String(domain.ActiveSessionRunning)
+	as.SegmentStartedAt = as.StartedAt
+	as.ElapsedSeconds, as.LeftSeconds, as.RightSeconds = domain.Int64(0), domain.Int64(0), domain.Int64(0)
+
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = au.getOwnChildren(_tx, parentUUID, domain.StringValue(as.ChildrenUUID)); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = au.activeSessionRepository.Store(_tx, as); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "active session Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	case domain.ErrEntryDuplicate:
+		err = errors.New("session of that type is already active for that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ActiveSessionAlreadyExist}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "active session Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(as.UUID)
+	_ = au.txHandler.Commit(_tx)
+	return
+}
+
+// GetActiveSessions implement GetActiveSessions method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) GetActiveSessions(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (sessions []domain.ActiveSession, err error) {
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = au.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if sessions, err = au.activeSessionRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "active session GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+	_ = au.txHandler.Commit(_tx)
+
+	// settle only in response, so every device show same durations at this moment
+	now := time.Now()
+	for i := range sessions {
+		sessions[i].Settle(now)
+	}
+	return
+}
+
+// PauseActiveSession implement PauseActiveSession method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) PauseActiveSession(
+	ctx context.Context,
+	parentUUID, childrenUUID, sessionUUID string,
+) (as domain.ActiveSession, err error) {
+	return au.changeActiveSession(ctx, parentUUID, childrenUUID, sessionUUID, (*domain.ActiveSession).Pause, "that session is not running")
+}
+
+// ResumeActiveSession implement ResumeActiveSession method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) ResumeActiveSession(
+	ctx context.Context,
+	parentUUID, childrenUUID, sessionUUID string,
+) (as domain.ActiveSession, err error) {
+	return au.changeActiveSession(ctx, parentUUID, childrenUUID, sessionUUID, (*domain.ActiveSession).Resume, "that session is not paused")
+}
+
+// SwitchActiveSessionSide implement SwitchActiveSessionSide method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) SwitchActiveSessionSide(
+	ctx context.Context,
+	parentUUID, childrenUUID, sessionUUID string,
+) (as domain.ActiveSession, err error) {
+	return au.changeActiveSession(ctx, parentUUID, childrenUUID, sessionUUID, (*domain.ActiveSession).SwitchSide, "that session is not feeding session")
+}
+
+// changeActiveSession method apply change to session & update it, invalidMsg is error message if change return false
+func (au *activeSessionUsecase) changeActiveSession(
+	ctx context.Context,
+	parentUUID, childrenUUID, sessionUUID string,
+	change func(as *domain.ActiveSession, now time.Time) bool,
+	invalidMsg string,
+) (as domain.ActiveSession, err error) {
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if as, err = au.getChildrenSession(_tx, parentUUID, childrenUUID, sessionUUID); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !change(&as, time.Now()) {
+		err = domain.UsecaseError{UsecaseErr: errors.New(invalidMsg), Status: http.StatusConflict, Code: domain.ActiveSessionInvalidState}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = au.activeSessionRepository.Update(_tx, &as); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "active session Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "active session Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = au.txHandler.Commit(_tx)
+	return
+}
+
+// StopActiveSession implement StopActiveSession method of domain.ActiveSessionUsecase interface
+func (au *activeSessionUsecase) StopActiveSession(
+	ctx context.Context,
+	parentUUID, childrenUUID, sessionUUID, sleepType string,
+) (recordType, recordUUID string, err error) {
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	as, err := au.getChildrenSession(_tx, parentUUID, childrenUUID, sessionUUID)
+	if err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	// delete first, so session stopped on other device at same time is not stored twice
+	switch err = au.activeSessionRepository.Delete(_tx, sessionUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("that session is already stopped")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "active session Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	now := time.Now()
+	switch recordType = domain.StringValue(as.SessionType); recordType {
+	case domain.ActiveSessionFeeding:
+		f := as.ToFeeding(now)
+		err = au.feedingRepository.Store(_tx, &f)
+		recordUUID = domain.StringValue(f.UUID)
+	case domain.ActiveSessionSleep:
+		s := as.ToSleep(now, sleepType)
+		if err = au.checkSleepOverlapped(_tx, s); err != nil {
+			_ = au.txHandler.Rollback(_tx)
+			return
+		}
+		err = au.sleepRepository.Store(_tx, &s)
+		recordUUID = domain.StringValue(s.UUID)
+	}
+
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrapf(err, "%s Store return invalid model", recordType)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrapf(err, "%s Store return unexpected error", recordType)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = au.txHandler.Commit(_tx)
+	return
+}
+
+// checkSleepOverlapped method return error if sleep is overlapped with other sleep of that children
+func (au *activeSessionUsecase) checkSleepOverlapped(_tx tx.Context, s domain.Sleep) (err error) {
+	overlapped, err := au.sleepRepository.GetByChildrenUUIDInRange(_tx, domain.StringValue(s.ChildrenUUID), domain.TimeValue(s.StartedAt), domain.TimeValue(s.EndedAt))
+	if err != nil {
+		err = errors.Wrap(err, "sleep GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	if len(overlapped) != 0 {
+		err = errors.New("sleep session is overlapped with other session of that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.SleepSessionOverlapped}
+	}
+	return
+}
+
+// getChildrenSession method return active session with uuid if that session is of children owned by parent
+func (au *activeSessionUsecase) getChildrenSession(_tx tx.Context, parentUUID, childrenUUID, sessionUUID string) (as domain.ActiveSession, err error) {
+	if _, err = au.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		return
+	}
+
+	switch as, err = au.activeSessionRepository.GetByUUID(_tx, sessionUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("active session with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "active session GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(as.ChildrenUUID) != childrenUUID {
+		err = errors.New("active session with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+	}
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (au *activeSessionUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = au.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
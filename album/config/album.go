@@ -0,0 +1,60 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about album domain
+var App *albumConfig
+
+// init function initialize App global variable
+func init() {
+	App = &albumConfig{}
+}
+
+// albumConfig have config value and implement various interface about album config
+type albumConfig struct {
+	// albumMediaS3Bucket represent aws s3 bucket for album media
+	albumMediaS3Bucket *string
+
+	// downloadLinkDuration represent time valid duration for album media download link
+	downloadLinkDuration *time.Duration
+}
+
+// default const value about albumConfig field
+const (
+	defaultAlbumMediaS3Bucket   = "first-baby-time"
+	defaultDownloadLinkDuration = time.Hour
+)
+
+// AlbumMediaS3Bucket implement AlbumMediaS3Bucket of albumUsecaseConfig
+func (ac *albumConfig) AlbumMediaS3Bucket() string {
+	var key = "album.albumMediaS3Bucket"
+	if ac.albumMediaS3Bucket == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultAlbumMediaS3Bucket)
+		}
+		ac.albumMediaS3Bucket = _string(viper.GetString(key))
+	}
+	return *ac.albumMediaS3Bucket
+}
+
+// DownloadLinkDuration implement DownloadLinkDuration of albumUsecaseConfig
+func (ac *albumConfig) DownloadLinkDuration() time.Duration {
+	var key = "album.downloadLinkDuration"
+	if ac.downloadLinkDuration != nil {
+		return *ac.downloadLinkDuration
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultDownloadLinkDuration.String())
+		d = defaultDownloadLinkDuration
+	}
+
+	ac.downloadLinkDuration = &d
+	return *ac.downloadLinkDuration
+}
+
+func _string(s string) *string { return &s }
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// maxFieldSize is max size of non-file field value in multipart body
+const maxFieldSize = 1024
+
+// albumHandler represent the http handler for album
+type albumHandler struct {
+	aUsecase   domain.AlbumUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewAlbumHandler will initialize the album resources endpoint
+func NewAlbumHandler(r *gin.Engine, au domain.AlbumUsecase, v validator, jh jwtHandler) {
+	h := &albumHandler{
+		aUsecase:   au,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/album", h.jwtHandler.ParseUUIDFromToken, h.UploadAlbumMedias)
+	r.GET("children/uuid/:children_uuid/album", h.jwtHandler.ParseUUIDFromToken, h.GetAlbumMedias)
+	r.DELETE("children/uuid/:children_uuid/album/uuid/:media_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteAlbumMedia)
+}
+
+// UploadAlbumMedias deliver each media in multipart body to UploadAlbumMedia of domain.AlbumUsecase
+// "caption" & "taken_at" fields are applied to the next media part, so they must be sent before the media
+func (ah *albumHandler) UploadAlbumMedias(c *gin.Context) {
+	req := new(uploadAlbumMediasRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		err = errors.Wrap(err, "failed to read multipart body")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	uuids := []string{}
+	am := &domain.AlbumMedia{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			err = errors.Wrap(err, "failed to read next part of multipart body")
+			resp := defaultResp(http.StatusBadRequest, 0, err.Error())
+			resp["media_uuids"] = uuids
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		if part.FileName() == "" {
+			v, _ := ioutil.ReadAll(io.LimitReader(part, maxFieldSize))
+			switch part.FormName() {
+			case "caption":
+				am.Caption = domain.String(string(v))
+			case "taken_at":
+				t, err := time.Parse(time.RFC3339, string(v))
+				if err != nil {
+					err = errors.Wrap(err, "failed to parse taken_at time string")
+					resp := defaultResp(http.StatusBadRequest, 0, err.Error())
+					resp["media_uuids"] = uuids
+					c.JSON(http.StatusBadRequest, resp)
+					return
+				}
+				am.TakenAt = domain.Time(t)
+			}
+			_ = part.Close()
+			continue
+		}
+
+		am.ChildrenUUID = domain.String(req.ChildrenUUID)
+		am.ContentType = domain.String(part.Header.Get("Content-Type"))
+		uuid, err := ah.aUsecase.UploadAlbumMedia(c.Request.Context(), c.GetString("uuid"), am, part)
+		_ = part.Close()
+
+		switch tErr := err.(type) {
+		case nil:
+			uuids = append(uuids, uuid)
+			am = &domain.AlbumMedia{}
+		case domain.UsecaseError:
+			resp := defaultResp(tErr.Status, tErr.Code, tErr.Error())
+			resp["media_uuids"] = uuids
+			c.JSON(tErr.Status, resp)
+			return
+		default:
+			msg := errors.Wrap(err, "UploadAlbumMedia return unexpected error").Error()
+			resp := defaultResp(http.StatusInternalServerError, 0, msg)
+			resp["media_uuids"] = uuids
+			c.JSON(http.StatusInternalServerError, resp)
+			return
+		}
+	}
+
+	if len(uuids) == 0 {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, "there is no media to upload in body"))
+		return
+	}
+
+	resp := defaultResp(http.StatusCreated, 0, "succeed to upload album medias")
+	resp["media_uuids"] = uuids
+	c.JSON(http.StatusCreated, resp)
+	return
+}
+
+// GetAlbumMedias deliver data to GetAlbumMedias of domain.AlbumUsecase
+func (ah *albumHandler) GetAlbumMedias(c *gin.Context) {
+	req := new(getAlbumMediasRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	medias, next, err := ah.aUsecase.GetAlbumMedias(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date, req.Cursor, req.Limit)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get album medias")
+		resp["medias"] = medias
+		resp["next_cursor"] = next
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetAlbumMedias return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteAlbumMedia deliver data to DeleteAlbumMedia of domain.AlbumUsecase
+func (ah *albumHandler) DeleteAlbumMedia(c *gin.Context) {
+	req := new(deleteAlbumMediaRequest)
+	if err := ah.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ah.aUsecase.DeleteAlbumMedia(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.MediaUUID); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete album media"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteAlbumMedia return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (ah *albumHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := ah.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// uploadAlbumMediasRequest is request for albumHandler.UploadAlbumMedias
+// medias are read from multipart body as stream, not bound in this request
+type uploadAlbumMediasRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *uploadAlbumMediasRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// getAlbumMediasRequest is request for albumHandler.GetAlbumMedias
+type getAlbumMediasRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"omitempty,len=10"`
+	Cursor       string `form:"cursor" validate:"omitempty,uuid=album"`
+	Limit        int    `form:"limit" validate:"range=1~100"`
+}
+
+// defaultAlbumMediasLimit is count of medias returned at once if limit is not set
+const defaultAlbumMediasLimit = 30
+
+func (r *getAlbumMediasRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	r.Limit = defaultAlbumMediasLimit
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// deleteAlbumMediaRequest is request for albumHandler.DeleteAlbumMedia
+type deleteAlbumMediaRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	MediaUUID    string `uri:"media_uuid" validate:"required,uuid=album"`
+}
+
+func (r *deleteAlbumMediaRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
@@ -0,0 +1,146 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// exif tag id used for finding date time original
+const (
+	tagDateTime         = 0x0132
+	tagExifIFDPointer   = 0x8769
+	tagDateTimeOriginal = 0x9003
+)
+
+// dateTimeLayout is layout of date time value in exif
+const dateTimeLayout = "2006:01:02 15:04:05"
+
+// ErrNotFound is returned if there is no date time in exif of image
+var ErrNotFound = errors.New("date time is not found in exif")
+
+// DateTimeOriginal function return date time original (or date time) in exif of JPEG image head
+// head must contain APP1 segment of image, which is located at the front of file in general
+// returned time is parsed in loc because exif date time has no time zone
+func DateTimeOriginal(head []byte, loc *time.Location) (t time.Time, err error) {
+	tiff, err := findExifSegment(head)
+	if err != nil {
+		return
+	}
+
+	if len(tiff) < 8 {
+		err = errors.New("tiff header is too short")
+		return
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		err = errors.New("invalid byte order in tiff header")
+		return
+	}
+
+	ifd0 := order.Uint32(tiff[4:8])
+	dateTime, _ := readASCII(tiff, order, ifd0, tagDateTime)
+	if exifIFD, ok := readLong(tiff, order, ifd0, tagExifIFDPointer); ok {
+		if original, ok := readASCII(tiff, order, exifIFD, tagDateTimeOriginal); ok {
+			dateTime = original
+		}
+	}
+
+	if dateTime == "" {
+		err = ErrNotFound
+		return
+	}
+	return time.ParseInLocation(dateTimeLayout, dateTime, loc)
+}
+
+// findExifSegment function return tiff data in APP1 exif segment of JPEG
+func findExifSegment(b []byte) (tiff []byte, err error) {
+	if len(b) < 2 || b[0] != 0xFF || b[1] != 0xD8 {
+		err = errors.New("not a JPEG image")
+		return
+	}
+
+	for i := 2; i+4 <= len(b); {
+		if b[i] != 0xFF {
+			err = errors.New("invalid JPEG segment marker")
+			return
+		}
+		marker := b[i+1]
+		size := int(binary.BigEndian.Uint16(b[i+2 : i+4]))
+		if marker == 0xDA || marker == 0xD9 {
+			break // start of scan or end of image, no more metadata
+		}
+		if size < 2 {
+			err = errors.New("invalid JPEG segment length")
+			return
+		}
+		if i+2+size > len(b) {
+			break
+		}
+
+		seg := b[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return seg[6:], nil
+		}
+		i += 2 + size
+	}
+
+	err = ErrNotFound
+	return
+}
+
+// findEntry function return offset of 12-byte ifd entry with tag in ifd at offset
+func findEntry(tiff []byte, order binary.ByteOrder, offset uint32, tag uint16) (entry []byte, ok bool) {
+	if int(offset)+2 > len(tiff) {
+		return
+	}
+	n := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < n; i++ {
+		start := int(offset) + 2 + i*12
+		if start+12 > len(tiff) {
+			return
+		}
+		if order.Uint16(tiff[start:start+2]) == tag {
+			return tiff[start : start+12], true
+		}
+	}
+	return
+}
+
+// readLong function return LONG value of tag in ifd at offset
+func readLong(tiff []byte, order binary.ByteOrder, offset uint32, tag uint16) (v uint32, ok bool) {
+	entry, ok := findEntry(tiff, order, offset, tag)
+	if !ok {
+		return
+	}
+	return order.Uint32(entry[8:12]), true
+}
+
+// readASCII function return ASCII value of tag in ifd at offset
+func readASCII(tiff []byte, order binary.ByteOrder, offset uint32, tag uint16) (v string, ok bool) {
+	entry, ok := findEntry(tiff, order, offset, tag)
+	if !ok {
+		return
+	}
+
+	count := order.Uint32(entry[4:8])
+	var value []byte
+	if count <= 4 {
+		value = entry[8 : 8+count]
+	} else {
+		start := order.Uint32(entry[8:12])
+		if uint64(start)+uint64(count) > uint64(len(tiff)) {
+			return "", false
+		}
+		value = tiff[start : start+count]
+	}
+	return string(bytes.TrimRight(value, "\x00 ")), true
+}
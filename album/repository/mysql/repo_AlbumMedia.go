@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// albumMediaRepository is implementation of domain.AlbumMediaRepository using mysql
+type albumMediaRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// AlbumMediaRepository return implementation of domain.AlbumMediaRepository using mysql
+func AlbumMediaRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.AlbumMediaRepository {
+	repo := &albumMediaRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.AlbumMedia{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate album media model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.AlbumMediaRepository interface
+func (ar *albumMediaRepository) Store(ctx tx.Context, am *domain.AlbumMedia) (err error) {
+	if domain.StringValue(am.UUID) == "" {
+		if am.UUID, err = ar.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = ar.validator.ValidateStruct(am); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.AlbumMedia")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("album_media").
+		Columns("uuid", "children_uuid", "uploader_uuid", "media_type", "content_type", "media_uri", "caption", "taken_at", "uploaded_at").
+		Values(am.UUID, am.ChildrenUUID, am.UploaderUUID, am.MediaType, am.ContentType, am.MediaUri, am.Caption, am.TakenAt, am.UploadedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert album media")
+			fk := ar.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert album media return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert album media return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.AlbumMediaRepository interface
+func (ar *albumMediaRepository) GetByUUID(ctx tx.Context, uuid string) (am domain.AlbumMedia, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("album_media").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&am, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select album media")}
+	default:
+		err = errors.Wrap(err, "select album media return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDPaged is implement GetByChildrenUUIDPaged method of domain.AlbumMediaRepository interface
+// medias are ordered by taken_at & uuid descending, from & to are not applied if zero
+func (ar *albumMediaRepository) GetByChildrenUUIDPaged(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+	cursor *domain.AlbumMedia,
+	limit uint64,
+) (medias []domain.AlbumMedia, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	builder := squirrel.Select("*").From("album_media").Where("children_uuid = ?", childrenUUID)
+	if !from.IsZero() {
+		builder = builder.Where("taken_at >= ?", from)
+	}
+	if !to.IsZero() {
+		builder = builder.Where("taken_at < ?", to)
+	}
+	if cursor != nil {
+		builder = builder.Where("(taken_at < ? OR (taken_at = ? AND uuid < ?))", cursor.TakenAt, cursor.TakenAt, cursor.UUID)
+	}
+	_sql, args, _ := builder.OrderBy("taken_at DESC", "uuid DESC").Limit(limit).ToSql()
+
+	medias = []domain.AlbumMedia{}
+	if err = _tx.Select(&medias, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select album medias return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.AlbumMediaRepository interface
+func (ar *albumMediaRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("album_media").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete album media return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("album media with that uuid is not exist")}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of album media table
+func (ar *albumMediaRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	am := new(domain.AlbumMedia)
+
+	for {
+		uuid := am.GenerateRandomUUID()
+		_, err := ar.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/album/exif"
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// exifHeadSize is size of photo head buffered for reading exif before streaming photo to s3
+const exifHeadSize = 128 * 1024
+
+// albumUsecase is used for usecase layer which implement domain.AlbumUsecase interface
+type albumUsecase struct {
+	// myCfg is used for get config value for album usecase
+	myCfg albumUsecaseConfig
+
+	// albumMediaRepository is repository interface about domain.AlbumMedia model
+	albumMediaRepository domain.AlbumMediaRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// s3Agency is used as agency about aws s3 API
+	s3Agency s3Agency
+}
+
+// AlbumUsecase return implementation of domain.AlbumUsecase
+func AlbumUsecase(
+	cfg albumUsecaseConfig,
+	ar domain.AlbumMediaRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+	sa s3Agency,
+) domain.AlbumUsecase {
+	return &albumUsecase{
+		myCfg:                cfg,
+		albumMediaRepository: ar,
+		childrenRepository:   cr,
+
+		txHandler: th,
+		s3Agency:  sa,
+	}
+}
+
+// albumUsecaseConfig is interface get config value for album usecase
+type albumUsecaseConfig interface {
+	// AlbumMediaS3Bucket return aws s3 bucket name for album media
+	AlbumMediaS3Bucket() string
+
+	// DownloadLinkDuration return valid duration of album media download link
+	DownloadLinkDuration() time.Duration
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// s3Agency is agency that agent various API about aws s3
+type s3Agency interface {
+	// Upload method upload object to s3 streaming body in parts
+	Upload(input *s3manager.UploadInput) (output *s3manager.UploadOutput, err error)
+
+	// DeleteObject method delete object from s3
+	DeleteObject(input *s3.DeleteObjectInput) (output *s3.DeleteObjectOutput, err error)
+
+	// PresignGetObject method return url that anyone can download object with until expire
+	PresignGetObject(input *s3.GetObjectInput, expire time.Duration) (url string, err error)
+}
+
+// UploadAlbumMedia implement UploadAlbumMedia method of domain.AlbumUsecase interface
+func (au *albumUsecase) UploadAlbumMedia(
+	ctx context.Context,
+	parentUUID string,
+	am *domain.AlbumMedia,
+	body io.Reader,
+) (uuid string, err error) {
+	switch contentType := domain.StringValue(am.ContentType); {
+	case strings.HasPrefix(contentType, "image/"):
+		am.MediaType = domain.String(domain.AlbumMediaTypePhoto)
+	case strings.HasPrefix(contentType, "video/"):
+		am.MediaType = domain.String(domain.AlbumMediaTypeVideo)
+	default:
+		err = errors.Errorf("content type %q is not photo or video", contentType)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	br := bufio.NewReaderSize(body, exifHeadSize)
+	if am.TakenAt == nil && domain.StringValue(am.MediaType) == domain.AlbumMediaTypePhoto {
+		head, _ := br.Peek(exifHeadSize)
+		if t, err := exif.DateTimeOriginal(head, domain.ServiceLocation); err == nil {
+			am.TakenAt = domain.Time(t)
+		}
+	}
+
+	now := time.Now()
+	am.UploaderUUID = domain.String(parentUUID)
+	am.UploadedAt = domain.Time(now)
+	if am.TakenAt == nil {
+		am.TakenAt = domain.Time(now)
+	}
+
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = au.getOwnChildren(_tx, parentUUID, domain.StringValue(am.ChildrenUUID)); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if am.UUID, err = au.albumMediaRepository.GetAvailableUUID(_tx); err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to GetAvailableUUID"), Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+	am.MediaUri = domain.String(am.GenerateMediaUri())
+	_ = au.txHandler.Commit(_tx)
+
+	// media is streamed to s3 out of transaction, because upload of large video takes long time
+	if _, err = au.s3Agency.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(au.myCfg.AlbumMediaS3Bucket()),
+		Key:         am.MediaUri,
+		Body:        br,
+		ContentType: am.ContentType,
+	}); err != nil {
+		err = errors.Wrap(err, "s3 Upload return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if err = au.storeAlbumMedia(ctx, am); err != nil {
+		_, _ = au.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(au.myCfg.AlbumMediaS3Bucket()),
+			Key:    am.MediaUri,
+		})
+		return
+	}
+
+	uuid = domain.StringValue(am.UUID)
+	return
+}
+
+// storeAlbumMedia method store metadata of media uploaded to s3 in its own transaction
+func (au *albumUsecase) storeAlbumMedia(ctx context.Context, am *domain.AlbumMedia) (err error) {
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	switch err = au.albumMediaRepository.Store(_tx, am); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "album media Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "album media Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = au.txHandler.Commit(_tx); err != nil {
+		err = errors.Wrap(err, "failed to commit transaction")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// GetAlbumMedias implement GetAlbumMedias method of domain.AlbumUsecase interface
+func (au *albumUsecase) GetAlbumMedias(
+	ctx context.Context,
+	parentUUID, childrenUUID, date, cursor string,
+	limit int,
+) (medias []domain.AlbumMedia, nextCursor string, err error) {
+	var from, to time.Time
+	if date != "" {
+		if from, to, err = domain.DayRange(date); err != nil {
+			err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+			return
+		}
+	}
+
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = au.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	var cursorMedia *domain.AlbumMedia
+	if cursor != "" {
+		switch am, err := au.albumMediaRepository.GetByUUID(_tx, cursor); err.(type) {
+		case nil:
+			if domain.StringValue(am.ChildrenUUID) != childrenUUID {
+				err = errors.New("cursor is not media of that children")
+				_ = au.txHandler.Rollback(_tx)
+				return nil, "", domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			}
+			cursorMedia = &am
+		case domain.ErrRowNotExist:
+			err = errors.New("media of cursor is not exist")
+			_ = au.txHandler.Rollback(_tx)
+			return nil, "", domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		default:
+			err = errors.Wrap(err, "album media GetByUUID return unexpected error")
+			_ = au.txHandler.Rollback(_tx)
+			return nil, "", domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		}
+	}
+
+	medias, err = au.albumMediaRepository.GetByChildrenUUIDPaged(_tx, childrenUUID, from, to, cursorMedia, uint64(limit+1))
+	if err != nil {
+		err = errors.Wrap(err, "album media GetByChildrenUUIDPaged return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if len(medias) > limit {
+		medias = medias[:limit]
+		nextCursor = domain.StringValue(medias[limit-1].UUID)
+	}
+	_ = au.txHandler.Commit(_tx)
+
+	for i := range medias {
+		if medias[i].DownloadUrl, err = au.s3Agency.PresignGetObject(&s3.GetObjectInput{
+			Bucket: aws.String(au.myCfg.AlbumMediaS3Bucket()),
+			Key:    medias[i].MediaUri,
+		}, au.myCfg.DownloadLinkDuration()); err != nil {
+			err = errors.Wrap(err, "s3 PresignGetObject return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			return
+		}
+	}
+	return
+}
+
+// DeleteAlbumMedia implement DeleteAlbumMedia method of domain.AlbumUsecase interface
+func (au *albumUsecase) DeleteAlbumMedia(ctx context.Context, parentUUID, childrenUUID, mediaUUID string) (err error) {
+	_tx, err := au.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = au.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	am, err := au.albumMediaRepository.GetByUUID(_tx, mediaUUID)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("media with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "album media GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if domain.StringValue(am.ChildrenUUID) != childrenUUID {
+		err = errors.New("media with that uuid is not exist in album of that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = au.albumMediaRepository.Delete(_tx, mediaUUID); err != nil {
+		err = errors.Wrap(err, "album media Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	if _, err = au.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(au.myCfg.AlbumMediaS3Bucket()),
+		Key:    am.MediaUri,
+	}); err != nil {
+		err = errors.Wrap(err, "s3 DeleteObject return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = au.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = au.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (au *albumUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = au.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
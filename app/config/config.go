@@ -69,7 +69,7 @@ func (ac *appConfig) MysqlDataSource() string {
 		return *ac.mysqlDataSource
 	}
 
-	format := "%s:%s@tcp(%s)/%s"
+	format := "%s:%s@tcp(%s)/%s?parseTime=true"
 	var args []interface{}
 
 	if viper.IsSet("MYSQL_USERNAME") {
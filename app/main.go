@@ -27,10 +27,37 @@ import (
 	_authRepo "github.com/MyFirstBabyTime/Server/auth/repository/mysql"
 	_authUcase "github.com/MyFirstBabyTime/Server/auth/usecase"
 
+	_expenditureConfig "github.com/MyFirstBabyTime/Server/chlidcare-expenditure/config"
 	_expenditureDelivery "github.com/MyFirstBabyTime/Server/chlidcare-expenditure/delivery/http"
 	_expenditureRepo "github.com/MyFirstBabyTime/Server/chlidcare-expenditure/repository/mysql"
 	_expenditureUcase "github.com/MyFirstBabyTime/Server/chlidcare-expenditure/usecase"
 
+	_expenditureCategoryCatalog "github.com/MyFirstBabyTime/Server/expenditure-category/catalog"
+	_expenditureCategoryDelivery "github.com/MyFirstBabyTime/Server/expenditure-category/delivery/http"
+	_expenditureCategoryRepo "github.com/MyFirstBabyTime/Server/expenditure-category/repository/mysql"
+	_expenditureCategoryUcase "github.com/MyFirstBabyTime/Server/expenditure-category/usecase"
+
+	_expenditureBudgetDelivery "github.com/MyFirstBabyTime/Server/expenditure-budget/delivery/http"
+	_expenditureBudgetRepo "github.com/MyFirstBabyTime/Server/expenditure-budget/repository/mysql"
+	_expenditureBudgetUcase "github.com/MyFirstBabyTime/Server/expenditure-budget/usecase"
+
+	_expenditureStatisticsConfig "github.com/MyFirstBabyTime/Server/expenditure-statistics/config"
+	_expenditureStatisticsDelivery "github.com/MyFirstBabyTime/Server/expenditure-statistics/delivery/http"
+	_expenditureStatisticsEsRepo "github.com/MyFirstBabyTime/Server/expenditure-statistics/repository/elasticsearch"
+	_expenditureStatisticsRepo "github.com/MyFirstBabyTime/Server/expenditure-statistics/repository/mysql"
+	_expenditureStatisticsUcase "github.com/MyFirstBabyTime/Server/expenditure-statistics/usecase"
+
+	_recurringExpenditureConfig "github.com/MyFirstBabyTime/Server/recurring-expenditure/config"
+	_recurringExpenditureDelivery "github.com/MyFirstBabyTime/Server/recurring-expenditure/delivery/http"
+	_recurringExpenditureScheduler "github.com/MyFirstBabyTime/Server/recurring-expenditure/delivery/scheduler"
+	_recurringExpenditureRepo "github.com/MyFirstBabyTime/Server/recurring-expenditure/repository/mysql"
+	_recurringExpenditureUcase "github.com/MyFirstBabyTime/Server/recurring-expenditure/usecase"
+
+	_expenditureImportCatalog "github.com/MyFirstBabyTime/Server/expenditure-import/catalog"
+	_expenditureImportDelivery "github.com/MyFirstBabyTime/Server/expenditure-import/delivery/http"
+	_expenditureImportStatement "github.com/MyFirstBabyTime/Server/expenditure-import/statement"
+	_expenditureImportUcase "github.com/MyFirstBabyTime/Server/expenditure-import/usecase"
+
 	_cloudMaintainerDelivery "github.com/MyFirstBabyTime/Server/cloud-maintainer/delivery/http"
 	_cloudMaintainerUsecase "github.com/MyFirstBabyTime/Server/cloud-maintainer/usecase"
 
@@ -38,6 +65,73 @@ import (
 	_childrenHttpDelivery "github.com/MyFirstBabyTime/Server/children/delivery/http"
 	_childrenRepo "github.com/MyFirstBabyTime/Server/children/repository/mysql"
 	_childrenUcase "github.com/MyFirstBabyTime/Server/children/usecase"
+
+	_vaccinationConfig "github.com/MyFirstBabyTime/Server/vaccination/config"
+	_vaccinationHttpDelivery "github.com/MyFirstBabyTime/Server/vaccination/delivery/http"
+	_vaccinationRepo "github.com/MyFirstBabyTime/Server/vaccination/repository/mysql"
+	_vaccinationSchedule "github.com/MyFirstBabyTime/Server/vaccination/schedule"
+	_vaccinationUcase "github.com/MyFirstBabyTime/Server/vaccination/usecase"
+
+	_feedingHttpDelivery "github.com/MyFirstBabyTime/Server/feeding/delivery/http"
+	_feedingRepo "github.com/MyFirstBabyTime/Server/feeding/repository/mysql"
+	_feedingUcase "github.com/MyFirstBabyTime/Server/feeding/usecase"
+
+	_sleepHttpDelivery "github.com/MyFirstBabyTime/Server/sleep/delivery/http"
+	_sleepRepo "github.com/MyFirstBabyTime/Server/sleep/repository/mysql"
+	_sleepUcase "github.com/MyFirstBabyTime/Server/sleep/usecase"
+
+	_diaperHttpDelivery "github.com/MyFirstBabyTime/Server/diaper/delivery/http"
+	_diaperRepo "github.com/MyFirstBabyTime/Server/diaper/repository/mysql"
+	_diaperUcase "github.com/MyFirstBabyTime/Server/diaper/usecase"
+
+	_milestoneCatalog "github.com/MyFirstBabyTime/Server/milestone/catalog"
+	_milestoneConfig "github.com/MyFirstBabyTime/Server/milestone/config"
+	_milestoneHttpDelivery "github.com/MyFirstBabyTime/Server/milestone/delivery/http"
+	_milestoneRepo "github.com/MyFirstBabyTime/Server/milestone/repository/mysql"
+	_milestoneUcase "github.com/MyFirstBabyTime/Server/milestone/usecase"
+
+	_albumConfig "github.com/MyFirstBabyTime/Server/album/config"
+	_albumHttpDelivery "github.com/MyFirstBabyTime/Server/album/delivery/http"
+	_albumRepo "github.com/MyFirstBabyTime/Server/album/repository/mysql"
+	_albumUcase "github.com/MyFirstBabyTime/Server/album/usecase"
+
+	_healthConfig "github.com/MyFirstBabyTime/Server/health/config"
+	_healthHttpDelivery "github.com/MyFirstBabyTime/Server/health/delivery/http"
+	_healthRepo "github.com/MyFirstBabyTime/Server/health/repository/mysql"
+	_healthUcase "github.com/MyFirstBabyTime/Server/health/usecase"
+
+	_foodIntroductionHttpDelivery "github.com/MyFirstBabyTime/Server/food-introduction/delivery/http"
+	_foodIntroductionRepo "github.com/MyFirstBabyTime/Server/food-introduction/repository/mysql"
+	_foodIntroductionUcase "github.com/MyFirstBabyTime/Server/food-introduction/usecase"
+
+	_temperatureHttpDelivery "github.com/MyFirstBabyTime/Server/temperature/delivery/http"
+	_temperatureRepo "github.com/MyFirstBabyTime/Server/temperature/repository/mysql"
+	_temperatureUcase "github.com/MyFirstBabyTime/Server/temperature/usecase"
+
+	_pumpingHttpDelivery "github.com/MyFirstBabyTime/Server/pumping/delivery/http"
+	_pumpingRepo "github.com/MyFirstBabyTime/Server/pumping/repository/mysql"
+	_pumpingUcase "github.com/MyFirstBabyTime/Server/pumping/usecase"
+
+	_emergencyCardHttpDelivery "github.com/MyFirstBabyTime/Server/emergency-card/delivery/http"
+	_emergencyCardRepo "github.com/MyFirstBabyTime/Server/emergency-card/repository/mysql"
+	_emergencyCardUcase "github.com/MyFirstBabyTime/Server/emergency-card/usecase"
+
+	_activeSessionHttpDelivery "github.com/MyFirstBabyTime/Server/active-session/delivery/http"
+	_activeSessionRepo "github.com/MyFirstBabyTime/Server/active-session/repository/mysql"
+	_activeSessionUcase "github.com/MyFirstBabyTime/Server/active-session/usecase"
+
+	_growthCatalog "github.com/MyFirstBabyTime/Server/growth/catalog"
+	_growthHttpDelivery "github.com/MyFirstBabyTime/Server/growth/delivery/http"
+	_growthRepo "github.com/MyFirstBabyTime/Server/growth/repository/mysql"
+	_growthUcase "github.com/MyFirstBabyTime/Server/growth/usecase"
+
+	_reportConfig "github.com/MyFirstBabyTime/Server/report/config"
+	_reportHttpDelivery "github.com/MyFirstBabyTime/Server/report/delivery/http"
+	_reportPdf "github.com/MyFirstBabyTime/Server/report/pdf"
+	_reportUcase "github.com/MyFirstBabyTime/Server/report/usecase"
+
+	_timelineHttpDelivery "github.com/MyFirstBabyTime/Server/timeline/delivery/http"
+	_timelineUcase "github.com/MyFirstBabyTime/Server/timeline/usecase"
 )
 
 func init() {
@@ -88,30 +182,140 @@ func main() {
 	_s3 := s3.New(s3Ses)
 	_es := elasticSearch.New(config.App.EsEndPoint())
 
+	par := _authRepo.ParentAuthRepository(_authConfig.App, db, _ps, _vl)
 	au := _authUcase.AuthUsecase(
 		_authConfig.App,
-		_authRepo.ParentAuthRepository(_authConfig.App, db, _ps, _vl),
+		par,
 		_authRepo.ParentPhoneCertifyRepository(_authConfig.App, db, _ps, _vl),
 		_tx, _msg, _hash, _jwt, _s3,
 	)
 	_authHttpDelivery.NewAuthHandler(r, au, _vl, _jwt)
 
-	eu := _expenditureUcase.ExpenditureUsecase(
-		_expenditureRepo.ExpenditureRepository(db, _ps, _vl),
-		_tx,
-		_es,
-	)
+	cr := _childrenRepo.ChildrenRepository(_childrenConfig.App, db, _ps, _vl)
+
+	ecc, err := _expenditureCategoryCatalog.Load()
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load expenditure category catalog").Error())
+	}
+	er := _expenditureRepo.ExpenditureRepository(db, _ps, _vl)
+	ecr := _expenditureCategoryRepo.ExpenditureCategoryRepository(db, _ps, _vl)
+	rcr := _expenditureRepo.ExpenditureReceiptRepository(db, _ps, _vl)
+	ebr := _expenditureBudgetRepo.ExpenditureBudgetRepository(db, _ps, _vl)
+	eu := _expenditureUcase.ExpenditureUsecase(_expenditureConfig.App, ecc, er, rcr, ecr, ebr, par, cr, _tx, _es, _msg, _s3)
 	_expenditureDelivery.NewExpenditureHandler(r, eu, _vl, _jwt)
 
+	ecgu := _expenditureCategoryUcase.ExpenditureCategoryUsecase(ecc, ecr, er, ebr, _tx)
+	_expenditureCategoryDelivery.NewExpenditureCategoryHandler(r, ecgu, _vl, _jwt)
+
+	ebu := _expenditureBudgetUcase.ExpenditureBudgetUsecase(ecc, ebr, ecr, er, _tx)
+	_expenditureBudgetDelivery.NewExpenditureBudgetHandler(r, ebu, _vl, _jwt)
+
+	rer := _recurringExpenditureRepo.RecurringExpenditureRepository(db, _ps, _vl)
+	reu := _recurringExpenditureUcase.RecurringExpenditureUsecase(ecc, rer, er, ecr, cr, _tx, _es)
+	_recurringExpenditureDelivery.NewRecurringExpenditureHandler(r, reu, _vl, _jwt)
+	_recurringExpenditureScheduler.NewRecurringExpenditureScheduler(_recurringExpenditureConfig.App, reu)
+
+	eip, err := _expenditureImportCatalog.Load()
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load expenditure import profile catalog").Error())
+	}
+	eiu := _expenditureImportUcase.ExpenditureImportUsecase(eip, ecc, _expenditureImportStatement.New(), er, ecr, cr, _tx, _es)
+	_expenditureImportDelivery.NewExpenditureImportHandler(r, eiu, _vl, _jwt)
+
 	cmu := _cloudMaintainerUsecase.CloudMaintainerUsecase(config.App)
 	_cloudMaintainerDelivery.NewCloudMaintainerHandler(r, cmu, _vl)
 
+	car := _childrenRepo.ChildrenAllergyRepository(db, _ps, _vl)
 	cu := _childrenUcase.ChildrenUsecase(
 		_childrenConfig.App,
-		_childrenRepo.ChildrenRepository(_childrenConfig.App, db, _ps, _vl),
+		cr, car,
+		_childrenRepo.PrenatalCheckupRepository(db, _ps, _vl),
 		_tx, _s3,
 	)
 	_childrenHttpDelivery.NewChildrenHandler(r, cu, _vl, _jwt)
 
+	ea := _expenditureStatisticsRepo.ExpenditureAggregator(db)
+	if _expenditureStatisticsConfig.App.AggregationBackend() == _expenditureStatisticsConfig.AggregationBackendElasticsearch {
+		ea = _expenditureStatisticsEsRepo.ExpenditureAggregator(_es)
+	}
+	esu := _expenditureStatisticsUcase.ExpenditureStatisticsUsecase(ecc, ea, ecr, cr, _tx)
+	_expenditureStatisticsDelivery.NewExpenditureStatisticsHandler(r, esu, _vl, _jwt)
+
+	vs, err := _vaccinationSchedule.Load(_vaccinationConfig.App.ScheduleFile())
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load vaccination schedule").Error())
+	}
+	vrr := _vaccinationRepo.VaccinationRecordRepository(db, _ps, _vl)
+	vu := _vaccinationUcase.VaccinationUsecase(vs, vrr, cr, _tx)
+	_vaccinationHttpDelivery.NewVaccinationHandler(r, vu, _vl, _jwt)
+
+	fr := _feedingRepo.FeedingRepository(db, _ps, _vl)
+	fu := _feedingUcase.FeedingUsecase(fr, cr, _tx)
+	_feedingHttpDelivery.NewFeedingHandler(r, fu, _vl, _jwt)
+
+	sr := _sleepRepo.SleepRepository(db, _ps, _vl)
+	su := _sleepUcase.SleepUsecase(sr, cr, _tx)
+	_sleepHttpDelivery.NewSleepHandler(r, su, _vl, _jwt)
+
+	dr := _diaperRepo.DiaperRepository(db, _ps, _vl)
+	du := _diaperUcase.DiaperUsecase(dr, cr, _tx)
+	_diaperHttpDelivery.NewDiaperHandler(r, du, _vl, _jwt)
+
+	mc, err := _milestoneCatalog.Load()
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load milestone catalog").Error())
+	}
+	mr := _milestoneRepo.MilestoneRecordRepository(db, _ps, _vl)
+	mu := _milestoneUcase.MilestoneUsecase(_milestoneConfig.App, mc, mr, cr, _tx, _s3)
+	_milestoneHttpDelivery.NewMilestoneHandler(r, mu, _vl, _jwt)
+
+	amr := _albumRepo.AlbumMediaRepository(db, _ps, _vl)
+	amu := _albumUcase.AlbumUsecase(_albumConfig.App, amr, cr, _tx, _s3)
+	_albumHttpDelivery.NewAlbumHandler(r, amu, _vl, _jwt)
+
+	hvr := _healthRepo.HospitalVisitRepository(db, _ps, _vl)
+	mdr := _healthRepo.MedicationRepository(db, _ps, _vl)
+	hu := _healthUcase.HealthUsecase(_healthConfig.App, hvr, mdr, cr, _tx, _s3)
+	_healthHttpDelivery.NewHealthHandler(r, hu, _vl, _jwt)
+
+	fir := _foodIntroductionRepo.FoodIntroductionRepository(db, _ps, _vl)
+	fiu := _foodIntroductionUcase.FoodIntroductionUsecase(fir, cr, car, _tx)
+	_foodIntroductionHttpDelivery.NewFoodIntroductionHandler(r, fiu, _vl, _jwt)
+
+	tr := _temperatureRepo.TemperatureRepository(db, _ps, _vl)
+	tu := _temperatureUcase.TemperatureUsecase(tr, cr, par, _tx, _msg)
+	_temperatureHttpDelivery.NewTemperatureHandler(r, tu, _vl, _jwt)
+
+	pr := _pumpingRepo.PumpingRepository(db, _ps, _vl)
+	mbr := _pumpingRepo.MilkBagRepository(db, _ps, _vl)
+	pu := _pumpingUcase.PumpingUsecase(pr, mbr, fr, cr, _tx)
+	_pumpingHttpDelivery.NewPumpingHandler(r, pu, _vl, _jwt)
+
+	epr := _emergencyCardRepo.EmergencyProfileRepository(db, _ps, _vl)
+	esr := _emergencyCardRepo.EmergencyShareRepository(db, _ps, _vl)
+	ecu := _emergencyCardUcase.EmergencyCardUsecase(epr, esr, cr, car, mdr, _tx)
+	_emergencyCardHttpDelivery.NewEmergencyCardHandler(r, ecu, _vl, _jwt)
+
+	asr := _activeSessionRepo.ActiveSessionRepository(db, _ps, _vl)
+	asu := _activeSessionUcase.ActiveSessionUsecase(asr, fr, sr, cr, _tx)
+	_activeSessionHttpDelivery.NewActiveSessionHandler(r, asu, _vl, _jwt)
+
+	gs, err := _growthCatalog.Load()
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load growth standard catalog").Error())
+	}
+	gmr := _growthRepo.GrowthMeasurementRepository(db, _ps, _vl)
+	gu := _growthUcase.GrowthUsecase(gs, gmr, cr, _tx)
+	_growthHttpDelivery.NewGrowthHandler(r, gu, _vl, _jwt)
+
+	ru := _reportUcase.ReportUsecase(
+		_reportConfig.App, vs, gs, cr, gmr, vrr, mdr, fr, sr, _tx, _s3,
+		_reportPdf.New(),
+	)
+	_reportHttpDelivery.NewReportHandler(r, ru, _vl, _jwt)
+
+	tlu := _timelineUcase.TimelineUsecase(fr, sr, dr, tr, mr, er, cr, _tx)
+	_timelineHttpDelivery.NewTimelineHandler(r, tlu, _vl, _jwt)
+
 	log.Fatal(r.Run(":80"))
 }
@@ -38,6 +38,13 @@ func NewChildrenHandler(r *gin.Engine, cu domain.ChildrenUsecase, v validator, j
 	}
 
 	r.POST("parents/uuid/:parent_uuid/children", h.jwtHandler.ParseUUIDFromToken, h.CreateNewChildren)
+	r.POST("children/uuid/:children_uuid/allergies", h.jwtHandler.ParseUUIDFromToken, h.AddChildrenAllergy)
+	r.GET("children/uuid/:children_uuid/allergies", h.jwtHandler.ParseUUIDFromToken, h.GetChildrenAllergies)
+	r.DELETE("children/uuid/:children_uuid/allergies/:allergen", h.jwtHandler.ParseUUIDFromToken, h.DeleteChildrenAllergy)
+	r.GET("children/uuid/:children_uuid/pregnancy", h.jwtHandler.ParseUUIDFromToken, h.GetPregnancyStatus)
+	r.POST("children/uuid/:children_uuid/birth", h.jwtHandler.ParseUUIDFromToken, h.RegisterChildrenBirth)
+	r.POST("children/uuid/:children_uuid/prenatal-checkups", h.jwtHandler.ParseUUIDFromToken, h.CreatePrenatalCheckup)
+	r.GET("children/uuid/:children_uuid/prenatal-checkups", h.jwtHandler.ParseUUIDFromToken, h.GetPrenatalCheckups)
 }
 
 func (ch *childrenHandler) CreateNewChildren(c *gin.Context) {
@@ -55,15 +62,28 @@ func (ch *childrenHandler) CreateNewChildren(c *gin.Context) {
 	chi := &domain.Children{
 		ParentUUID: domain.String(req.ParentUUID),
 		Name:       domain.String(req.Name),
-		Sex:        domain.String(req.Sex),
+	}
+	if req.Sex != "" {
+		chi.Sex = domain.String(req.Sex)
 	}
 
-	if t, err := time.Parse("2006-01-02", req.Birth); err != nil {
-		err = errors.Wrap(err, "failed to parse birth time string")
-		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
-		return
-	} else {
-		chi.Birth = domain.Time(t)
+	if req.Birth != "" {
+		if t, err := time.Parse("2006-01-02", req.Birth); err != nil {
+			err = errors.Wrap(err, "failed to parse birth time string")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		} else {
+			chi.Birth = domain.Time(t)
+		}
+	}
+	if req.ExpectedBirth != "" {
+		if t, err := time.Parse("2006-01-02", req.ExpectedBirth); err != nil {
+			err = errors.Wrap(err, "failed to parse expected birth time string")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		} else {
+			chi.ExpectedBirth = domain.Time(t)
+		}
 	}
 
 	var profile []byte
@@ -96,6 +116,197 @@ func (ch *childrenHandler) CreateNewChildren(c *gin.Context) {
 	return
 }
 
+// AddChildrenAllergy deliver data to AddChildrenAllergy of domain.ChildrenUsecase
+func (ch *childrenHandler) AddChildrenAllergy(c *gin.Context) {
+	req := new(addChildrenAllergyRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	ca := &domain.ChildrenAllergy{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		Allergen:     domain.String(req.Allergen),
+		Severity:     domain.String(req.Severity),
+	}
+	if req.Note != "" {
+		ca.Note = domain.String(req.Note)
+	}
+
+	switch err := ch.cUsecase.AddChildrenAllergy(c.Request.Context(), c.GetString("uuid"), ca); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusCreated, defaultResp(http.StatusCreated, 0, "succeed to add children allergy"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "AddChildrenAllergy return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetChildrenAllergies deliver data to GetChildrenAllergies of domain.ChildrenUsecase
+func (ch *childrenHandler) GetChildrenAllergies(c *gin.Context) {
+	req := new(getChildrenAllergiesRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	allergies, err := ch.cUsecase.GetChildrenAllergies(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get children allergies")
+		resp["allergies"] = allergies
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetChildrenAllergies return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteChildrenAllergy deliver data to DeleteChildrenAllergy of domain.ChildrenUsecase
+func (ch *childrenHandler) DeleteChildrenAllergy(c *gin.Context) {
+	req := new(deleteChildrenAllergyRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ch.cUsecase.DeleteChildrenAllergy(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Allergen); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete children allergy"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteChildrenAllergy return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetPregnancyStatus deliver data to GetPregnancyStatus of domain.ChildrenUsecase
+func (ch *childrenHandler) GetPregnancyStatus(c *gin.Context) {
+	req := new(getPregnancyStatusRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	status, err := ch.cUsecase.GetPregnancyStatus(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get pregnancy status")
+		resp["pregnancy"] = status
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetPregnancyStatus return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// RegisterChildrenBirth deliver data to RegisterChildrenBirth of domain.ChildrenUsecase
+func (ch *childrenHandler) RegisterChildrenBirth(c *gin.Context) {
+	req := new(registerChildrenBirthRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	birth, err := time.Parse("2006-01-02", req.Birth)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse birth time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ch.cUsecase.RegisterChildrenBirth(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, birth, req.Sex); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to register children birth"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "RegisterChildrenBirth return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// CreatePrenatalCheckup deliver data to CreatePrenatalCheckup of domain.ChildrenUsecase
+func (ch *childrenHandler) CreatePrenatalCheckup(c *gin.Context) {
+	req := new(createPrenatalCheckupRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	pc := &domain.PrenatalCheckup{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+	}
+	if req.Hospital != "" {
+		pc.Hospital = domain.String(req.Hospital)
+	}
+	if req.FetalWeight != 0 {
+		pc.FetalWeight = domain.Int64(req.FetalWeight)
+	}
+	if req.FetalHeartRate != 0 {
+		pc.FetalHeartRate = domain.Int64(req.FetalHeartRate)
+	}
+	if req.Note != "" {
+		pc.Note = domain.String(req.Note)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", req.CheckedAt, domain.ServiceLocation); err != nil {
+		err = errors.Wrap(err, "failed to parse checked_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		pc.CheckedAt = domain.Time(t)
+	}
+
+	switch uuid, err := ch.cUsecase.CreatePrenatalCheckup(c.Request.Context(), c.GetString("uuid"), pc); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new prenatal checkup")
+		resp["prenatal_checkup_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreatePrenatalCheckup return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetPrenatalCheckups deliver data to GetPrenatalCheckups of domain.ChildrenUsecase
+func (ch *childrenHandler) GetPrenatalCheckups(c *gin.Context) {
+	req := new(getPrenatalCheckupsRequest)
+	if err := ch.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	checkups, err := ch.cUsecase.GetPrenatalCheckups(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get prenatal checkups")
+		resp["prenatal_checkups"] = checkups
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetPrenatalCheckups return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
 // bindRequest method bind *gin.Context to request having BindFrom method
 func (ch *childrenHandler) bindRequest(req interface {
 	BindFrom(ctx *gin.Context) error
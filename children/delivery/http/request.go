@@ -10,8 +10,9 @@ import (
 type createNewChildrenRequest struct {
 	ParentUUID    string                `uri:"parent_uuid" validate:"required"`
 	Name          string                `form:"name" json:"name" validate:"required,max=20"`
-	Birth         string                `form:"birth" json:"birth" validate:"required,max=20"`
-	Sex           string                `form:"sex" json:"sex" validate:"required,max=20,oneof=male female"`
+	Birth         string                `form:"birth" json:"birth" validate:"required_without=ExpectedBirth,max=20"`
+	ExpectedBirth string                `form:"expected_birth" json:"expected_birth" validate:"max=20"`
+	Sex           string                `form:"sex" json:"sex" validate:"required_with=Birth,omitempty,max=20,oneof=male female"`
 	Profile       *multipart.FileHeader `form:"profile"`
 	ProfileBase64 string                `json:"profile_base64"`
 }
@@ -28,3 +29,86 @@ func (r *createNewChildrenRequest) BindFrom(c *gin.Context) error {
 		return errors.Wrap(c.Bind(r), "failed to Bind")
 	}
 }
+
+// addChildrenAllergyRequest is request for childrenHandler.AddChildrenAllergy
+type addChildrenAllergyRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Allergen     string `json:"allergen" validate:"required,max=30"`
+	Severity     string `json:"severity" validate:"required,oneof=mild moderate severe"`
+	Note         string `json:"note" validate:"max=200"`
+}
+
+func (r *addChildrenAllergyRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getChildrenAllergiesRequest is request for childrenHandler.GetChildrenAllergies
+type getChildrenAllergiesRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getChildrenAllergiesRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// deleteChildrenAllergyRequest is request for childrenHandler.DeleteChildrenAllergy
+type deleteChildrenAllergyRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Allergen     string `uri:"allergen" validate:"required,max=30"`
+}
+
+func (r *deleteChildrenAllergyRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// getPregnancyStatusRequest is request for childrenHandler.GetPregnancyStatus
+type getPregnancyStatusRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getPregnancyStatusRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// registerChildrenBirthRequest is request for childrenHandler.RegisterChildrenBirth
+type registerChildrenBirthRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Birth        string `json:"birth" validate:"required,max=20"`
+	Sex          string `json:"sex" validate:"required,oneof=male female"`
+}
+
+func (r *registerChildrenBirthRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// createPrenatalCheckupRequest is request for childrenHandler.CreatePrenatalCheckup
+type createPrenatalCheckupRequest struct {
+	ChildrenUUID   string `uri:"children_uuid" validate:"required,uuid=children"`
+	CheckedAt      string `json:"checked_at" validate:"required,len=10"`
+	Hospital       string `json:"hospital" validate:"max=50"`
+	FetalWeight    int64  `json:"fetal_weight" validate:"range=0~6000"`
+	FetalHeartRate int64  `json:"fetal_heart_rate" validate:"range=0~250"`
+	Note           string `json:"note" validate:"max=500"`
+}
+
+func (r *createPrenatalCheckupRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getPrenatalCheckupsRequest is request for childrenHandler.GetPrenatalCheckups
+type getPrenatalCheckupsRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getPrenatalCheckupsRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
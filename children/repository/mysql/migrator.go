@@ -6,6 +6,8 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
 )
 
 // migrator is struct that migrate to mysql repository
@@ -34,3 +36,32 @@ func (m migrator) MigrateModel(db *sqlx.DB, model interface {
 
 	return
 }
+
+// MigrateColumns method apply column migrations of model not applied to db yet
+func (m migrator) MigrateColumns(db *sqlx.DB, model interface {
+	TableName() string                    // TableName return table name about model
+	Migrations() []domain.ColumnMigration // Migrations return column migrations about model
+}) (err error) {
+	for _, cm := range model.Migrations() {
+		sql, args, _ := squirrel.Select("IS_NULLABLE").From("information_schema.COLUMNS").
+			Where("TABLE_SCHEMA = DATABASE()").
+			Where(squirrel.Eq{"TABLE_NAME": model.TableName(), "COLUMN_NAME": cm.Column}).ToSql()
+
+		var nullable []string
+		if err = db.Select(&nullable, sql, args...); err != nil {
+			err = errors.Wrapf(err, "check column query returns unexpected error")
+			return
+		}
+		if len(nullable) != 0 && (!cm.Nullable || nullable[0] == "YES") {
+			continue
+		}
+
+		for _, stmt := range cm.Statements {
+			if _, err = db.Exec(stmt); err != nil {
+				err = errors.Wrapf(err, "failed to exec %s.%s column migration", model.TableName(), cm.Column)
+				return
+			}
+		}
+	}
+	return
+}
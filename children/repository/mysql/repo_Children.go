@@ -54,6 +54,10 @@ func ChildrenRepository(
 	if err := repo.migrator.MigrateModel(repo.db, domain.Children{}); err != nil {
 		log.Fatal(errors.Wrap(err, "failed to migrate parent children model").Error())
 	}
+
+	if err := repo.migrator.MigrateColumns(repo.db, domain.Children{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate parent children columns").Error())
+	}
 	return repo
 }
 
@@ -70,8 +74,8 @@ func (cr *childrenRepository) Store(ctx tx.Context, c *domain.Children) (err err
 	}
 
 	_tx, _ := ctx.Tx().(*sqlx.Tx)
-	_sql, args, _ := squirrel.Insert("children").Columns("uuid", "parent_uuid", "name", "birth", "sex", "profile_uri").
-		Values(c.UUID, c.ParentUUID, c.Name, c.Birth, c.Sex, c.ProfileUri).ToSql()
+	_sql, args, _ := squirrel.Insert("children").Columns("uuid", "parent_uuid", "name", "birth", "expected_birth", "sex", "profile_uri").
+		Values(c.UUID, c.ParentUUID, c.Name, c.Birth, c.ExpectedBirth, c.Sex, c.ProfileUri).ToSql()
 
 	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
 	case nil:
@@ -91,10 +95,52 @@ func (cr *childrenRepository) Store(ctx tx.Context, c *domain.Children) (err err
 	return
 }
 
+// Update is implement Update method of domain.ChildrenRepository interface
+// every column except uuid & parent_uuid is updated to value in model
+func (cr *childrenRepository) Update(ctx tx.Context, c *domain.Children) (err error) {
+	if domain.StringValue(c.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = cr.validator.ValidateStruct(c); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Children")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("children").
+		Set("name", c.Name).
+		Set("birth", c.Birth).
+		Set("expected_birth", c.ExpectedBirth).
+		Set("sex", c.Sex).
+		Set("profile_uri", c.ProfileUri).
+		Where("uuid = ?", c.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "failed to update children")
+	}
+	return
+}
+
 // GetByUUID is implement GetByUUID method of domain.ChildrenRepository interface
 func (cr *childrenRepository) GetByUUID(ctx tx.Context, uuid string) (children domain.Children, err error) {
+	return cr.getByUUID(ctx, uuid, "")
+}
+
+// GetByUUIDForUpdate is implement GetByUUIDForUpdate method of domain.ChildrenRepository interface
+// selected row is locked until end of transaction, so that records of children checked & stored together are serialized
+func (cr *childrenRepository) GetByUUIDForUpdate(ctx tx.Context, uuid string) (children domain.Children, err error) {
+	return cr.getByUUID(ctx, uuid, "FOR UPDATE")
+}
+
+// getByUUID method select children with uuid, with suffix of select query
+func (cr *childrenRepository) getByUUID(ctx tx.Context, uuid, suffix string) (children domain.Children, err error) {
 	_tx, _ := ctx.Tx().(*sqlx.Tx)
-	_sql, args, _ := squirrel.Select("*").From("children").Where("uuid = ?", uuid).ToSql()
+	query := squirrel.Select("*").From("children").Where("uuid = ?", uuid)
+	if suffix != "" {
+		query = query.Suffix(suffix)
+	}
+	_sql, args, _ := query.ToSql()
 
 	switch err = _tx.Get(&children, _sql, args...); err {
 	case nil:
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// childrenAllergyRepository is implementation of domain.ChildrenAllergyRepository using mysql
+type childrenAllergyRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// ChildrenAllergyRepository return implementation of domain.ChildrenAllergyRepository using mysql
+func ChildrenAllergyRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.ChildrenAllergyRepository {
+	repo := &childrenAllergyRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.ChildrenAllergy{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate children allergy model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.ChildrenAllergyRepository interface
+func (car *childrenAllergyRepository) Store(ctx tx.Context, ca *domain.ChildrenAllergy) (err error) {
+	if err = car.validator.ValidateStruct(ca); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ChildrenAllergy")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("children_allergy").
+		Columns("children_uuid", "allergen", "severity", "note", "created_at").
+		Values(ca.ChildrenUUID, ca.Allergen, ca.Severity, ca.Note, ca.CreatedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert children allergy")
+			_, key := car.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert children allergy")
+			fk := car.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert children allergy return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert children allergy return unexpected error type")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.ChildrenAllergyRepository interface
+func (car *childrenAllergyRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (cas []domain.ChildrenAllergy, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("children_allergy").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("created_at").ToSql()
+
+	cas = []domain.ChildrenAllergy{}
+	if err = _tx.Select(&cas, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select children allergies return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ChildrenAllergyRepository interface
+func (car *childrenAllergyRepository) Delete(ctx tx.Context, childrenUUID, allergen string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("children_allergy").
+		Where("children_uuid = ? AND allergen = ?", childrenUUID, allergen).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete children allergy return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("children allergy with that allergen is not exist")}
+	}
+	return
+}
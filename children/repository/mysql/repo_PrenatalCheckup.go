@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// prenatalCheckupRepository is implementation of domain.PrenatalCheckupRepository using mysql
+type prenatalCheckupRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// PrenatalCheckupRepository return implementation of domain.PrenatalCheckupRepository using mysql
+func PrenatalCheckupRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.PrenatalCheckupRepository {
+	repo := &prenatalCheckupRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.PrenatalCheckup{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate prenatal checkup model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.PrenatalCheckupRepository interface
+func (pcr *prenatalCheckupRepository) Store(ctx tx.Context, pc *domain.PrenatalCheckup) (err error) {
+	if domain.StringValue(pc.UUID) == "" {
+		if pc.UUID, err = pcr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = pcr.validator.ValidateStruct(pc); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.PrenatalCheckup")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("prenatal_checkup").
+		Columns("uuid", "children_uuid", "checked_at", "gestational_week", "hospital", "fetal_weight", "fetal_heart_rate", "note").
+		Values(pc.UUID, pc.ChildrenUUID, pc.CheckedAt, pc.GestationalWeek, pc.Hospital, pc.FetalWeight, pc.FetalHeartRate, pc.Note).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert prenatal checkup")
+			fk := pcr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert prenatal checkup return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert prenatal checkup return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.PrenatalCheckupRepository interface
+func (pcr *prenatalCheckupRepository) GetByUUID(ctx tx.Context, uuid string) (pc domain.PrenatalCheckup, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("prenatal_checkup").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&pc, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select prenatal checkup")}
+	default:
+		err = errors.Wrap(err, "select prenatal checkup return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.PrenatalCheckupRepository interface
+func (pcr *prenatalCheckupRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (pcs []domain.PrenatalCheckup, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("prenatal_checkup").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("checked_at").ToSql()
+
+	pcs = []domain.PrenatalCheckup{}
+	if err = _tx.Select(&pcs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select prenatal checkups return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of prenatal checkup table
+func (pcr *prenatalCheckupRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	pc := new(domain.PrenatalCheckup)
+
+	for {
+		uuid := pc.GenerateRandomUUID()
+		_, err := pcr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"net/http"
+	"time"
 
 	"github.com/MyFirstBabyTime/Server/domain"
 	"github.com/MyFirstBabyTime/Server/tx"
@@ -20,6 +21,12 @@ type childrenUsecase struct {
 	// childrenRepository is repository interface about domain.Children model
 	childrenRepository domain.ChildrenRepository
 
+	// childrenAllergyRepository is repository interface about domain.ChildrenAllergy model
+	childrenAllergyRepository domain.ChildrenAllergyRepository
+
+	// prenatalCheckupRepository is repository interface about domain.PrenatalCheckup model
+	prenatalCheckupRepository domain.PrenatalCheckupRepository
+
 	// txHandler is used for handling transaction to begin & commit or rollback
 	txHandler txHandler
 
@@ -31,13 +38,17 @@ type childrenUsecase struct {
 func ChildrenUsecase(
 	cfg childrenUsecaseConfig,
 	cr domain.ChildrenRepository,
+	car domain.ChildrenAllergyRepository,
+	pcr domain.PrenatalCheckupRepository,
 	th txHandler,
 	sa s3Agency,
 ) domain.ChildrenUsecase {
 	return &childrenUsecase{
 		myCfg: cfg,
 
-		childrenRepository: cr,
+		childrenRepository:        cr,
+		childrenAllergyRepository: car,
+		prenatalCheckupRepository: pcr,
 
 		txHandler: th,
 		s3Agency:  sa,
@@ -83,6 +94,15 @@ func (cu *childrenUsecase) CreateNewChildren(ctx context.Context, c *domain.Chil
 		}
 	}
 
+	if !c.IsBorn() {
+		if days := c.GestationalDays(time.Now()); days < 0 || days > maxGestationalDays {
+			err = errors.New("expected birth of unborn children is out of pregnancy period")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = cu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
 	if profile != nil && len(profile) != 0 {
 		c.ProfileUri = domain.String(c.GenerateProfileUri())
 	}
@@ -134,3 +154,280 @@ func (cu *childrenUsecase) CreateNewChildren(ctx context.Context, c *domain.Chil
 	_ = cu.txHandler.Commit(_tx)
 	return
 }
+
+// maxGestationalDays is max days of pregnancy accepted for unborn children (42 weeks + 6 days)
+const maxGestationalDays = 300
+
+// GetPregnancyStatus implement GetPregnancyStatus method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) GetPregnancyStatus(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (status domain.PregnancyStatus, err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := cu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if c.IsBorn() {
+		err = errors.New("that children is already born")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenAlreadyBorn}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	status = domain.NewPregnancyStatus(c, time.Now())
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// RegisterChildrenBirth implement RegisterChildrenBirth method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) RegisterChildrenBirth(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	birth time.Time,
+	sex string,
+) (err error) {
+	if birth.After(time.Now()) {
+		err = errors.New("birth can't be in the future")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := cu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if c.IsBorn() {
+		err = errors.New("that children is already born")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenAlreadyBorn}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	// expected birth is kept to compare with real birth
+	c.Birth, c.Sex = domain.Time(birth), domain.String(sex)
+	switch err = cu.childrenRepository.Update(_tx, &c); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "children Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "children Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// CreatePrenatalCheckup implement CreatePrenatalCheckup method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) CreatePrenatalCheckup(
+	ctx context.Context,
+	parentUUID string,
+	pc *domain.PrenatalCheckup,
+) (uuid string, err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := cu.getOwnChildren(_tx, parentUUID, domain.StringValue(pc.ChildrenUUID))
+	if err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if c.IsBorn() {
+		err = errors.New("that children is already born")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenAlreadyBorn}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	pc.GestationalWeek = domain.Int64(int64(c.GestationalDays(domain.TimeValue(pc.CheckedAt)) / 7))
+	switch err = cu.prenatalCheckupRepository.Store(_tx, pc); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "prenatal checkup Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "prenatal checkup Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(pc.UUID)
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// GetPrenatalCheckups implement GetPrenatalCheckups method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) GetPrenatalCheckups(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (checkups []domain.PrenatalCheckup, err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = cu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if checkups, err = cu.prenatalCheckupRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "prenatal checkup GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// AddChildrenAllergy implement AddChildrenAllergy method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) AddChildrenAllergy(ctx context.Context, parentUUID string, ca *domain.ChildrenAllergy) (err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = cu.getOwnChildren(_tx, parentUUID, domain.StringValue(ca.ChildrenUUID)); err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	ca.CreatedAt = domain.Time(time.Now())
+	switch err = cu.childrenAllergyRepository.Store(_tx, ca); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "children allergy Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrEntryDuplicate:
+		err = errors.New("that allergen is already in allergy list of children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenAllergyAlreadyExist}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "children allergy Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// GetChildrenAllergies implement GetChildrenAllergies method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) GetChildrenAllergies(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (allergies []domain.ChildrenAllergy, err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = cu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if allergies, err = cu.childrenAllergyRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "children allergy GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// DeleteChildrenAllergy implement DeleteChildrenAllergy method of domain.ChildrenUsecase interface
+func (cu *childrenUsecase) DeleteChildrenAllergy(ctx context.Context, parentUUID, childrenUUID, allergen string) (err error) {
+	_tx, err := cu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = cu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = cu.childrenAllergyRepository.Delete(_tx, childrenUUID, allergen); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("that allergen is not in allergy list of children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "children allergy Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = cu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = cu.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (cu *childrenUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = cu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
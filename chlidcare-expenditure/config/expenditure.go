@@ -0,0 +1,60 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about childcare expenditure domain
+var App *expenditureConfig
+
+// init function initialize App global variable
+func init() {
+	App = &expenditureConfig{}
+}
+
+// expenditureConfig have config value and implement various interface about expenditure config
+type expenditureConfig struct {
+	// receiptS3Bucket represent aws s3 bucket for expenditure receipt image
+	receiptS3Bucket *string
+
+	// downloadLinkDuration represent time valid duration for receipt image download link
+	downloadLinkDuration *time.Duration
+}
+
+// default const value about expenditureConfig field
+const (
+	defaultReceiptS3Bucket      = "first-baby-time"
+	defaultDownloadLinkDuration = time.Hour
+)
+
+// ReceiptS3Bucket implement ReceiptS3Bucket of expenditureUsecaseConfig
+func (ec *expenditureConfig) ReceiptS3Bucket() string {
+	var key = "expenditure.receiptS3Bucket"
+	if ec.receiptS3Bucket == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultReceiptS3Bucket)
+		}
+		ec.receiptS3Bucket = _string(viper.GetString(key))
+	}
+	return *ec.receiptS3Bucket
+}
+
+// DownloadLinkDuration implement DownloadLinkDuration of expenditureUsecaseConfig
+func (ec *expenditureConfig) DownloadLinkDuration() time.Duration {
+	var key = "expenditure.downloadLinkDuration"
+	if ec.downloadLinkDuration != nil {
+		return *ec.downloadLinkDuration
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultDownloadLinkDuration.String())
+		d = defaultDownloadLinkDuration
+	}
+
+	ec.downloadLinkDuration = &d
+	return *ec.downloadLinkDuration
+}
+
+func _string(s string) *string { return &s }
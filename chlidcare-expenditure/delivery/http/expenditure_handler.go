@@ -1,10 +1,19 @@
 package http
 
 import (
+	"encoding/base64"
+	"fmt"
+	"github.com/MyFirstBabyTime/Server/chlidcare-expenditure/sheet"
 	"github.com/MyFirstBabyTime/Server/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
 	"net/http"
+	"regexp"
+	"time"
 )
 
 //expenditureHandler represent the http handler for article
@@ -34,6 +43,13 @@ func NewExpenditureHandler(r *gin.Engine, eu domain.ExpenditureUsecase, v valida
 	}
 
 	r.POST("expenditure/registration", h.jwtHandler.ParseUUIDFromToken, h.ExpenditureRegistration)
+	r.GET("expenditures", h.jwtHandler.ParseUUIDFromToken, h.GetExpenditures)
+	r.GET("expenditures/export", h.jwtHandler.ParseUUIDFromToken, h.ExportExpenditures)
+	r.GET("expenditures/uuid/:expenditure_uuid", h.jwtHandler.ParseUUIDFromToken, h.GetExpenditure)
+	r.PATCH("expenditures/uuid/:expenditure_uuid", h.jwtHandler.ParseUUIDFromToken, h.UpdateExpenditure)
+	r.DELETE("expenditures/uuid/:expenditure_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteExpenditure)
+	r.GET("expenditures/uuid/:expenditure_uuid/receipts", h.jwtHandler.ParseUUIDFromToken, h.GetExpenditureReceipts)
+	r.DELETE("expenditures/uuid/:expenditure_uuid/receipts/uuid/:receipt_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteExpenditureReceipt)
 }
 
 func (eh *expenditureHandler) ExpenditureRegistration(c *gin.Context) {
@@ -43,13 +59,32 @@ func (eh *expenditureHandler) ExpenditureRegistration(c *gin.Context) {
 		return
 	}
 
-	err := eh.eUsecase.ExpenditureRegistration(c, &domain.Expenditure{
-		ParentUUID: domain.String(req.ParentUUID),
+	// expenditure is always registered to parent of token, parent_uuid in body is only allowed to be same with it
+	if req.ParentUUID != "" && req.ParentUUID != c.GetString("uuid") {
+		msg := "you can't register expenditure of other parent"
+		c.JSON(http.StatusForbidden, defaultResp(http.StatusForbidden, 0, msg))
+		return
+	}
+
+	e := &domain.Expenditure{
+		ParentUUID: domain.String(c.GetString("uuid")),
 		Name:       domain.String(req.Name),
 		Amount:     domain.Int64(req.Amount),
 		Rating:     domain.Int64(req.Rating),
 		Link:       domain.String(req.Link),
-	}, req.BabyUUIDs)
+		SpentAt:    req.SpentAt,
+	}
+	if req.CategoryUUID != "" {
+		e.CategoryUUID = domain.String(req.CategoryUUID)
+	}
+
+	receipts, status, err := readReceipts(req.Receipts, req.ReceiptsBase64)
+	if err != nil {
+		c.JSON(status, defaultResp(status, 0, err.Error()))
+		return
+	}
+
+	err = eh.eUsecase.ExpenditureRegistration(c, c.GetString("uuid"), e, req.BabyUUIDs, receipts)
 
 	switch tErr := err.(type) {
 	case nil:
@@ -64,6 +99,261 @@ func (eh *expenditureHandler) ExpenditureRegistration(c *gin.Context) {
 	return
 }
 
+// GetExpenditure deliver data to GetExpenditure of domain.ExpenditureUsecase
+func (eh *expenditureHandler) GetExpenditure(c *gin.Context) {
+	req := new(getExpenditureRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	e, err := eh.eUsecase.GetExpenditure(c.Request.Context(), c.GetString("uuid"), req.ExpenditureUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure")
+		resp["expenditure"] = e
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetExpenditures deliver data to GetExpenditures of domain.ExpenditureUsecase
+func (eh *expenditureHandler) GetExpenditures(c *gin.Context) {
+	req := new(getExpendituresRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	filter, err := req.Filter()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+	filter.Cursor, filter.Limit = req.Cursor, req.Limit
+
+	expenditures, nextCursor, err := eh.eUsecase.GetExpenditures(c.Request.Context(), c.GetString("uuid"), filter)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditures")
+		resp["expenditures"] = expenditures
+		resp["next_cursor"] = nextCursor
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpenditures return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// sheetWriters is constructor of domain.ExpenditureSheetWriter & content type of file, for each export format
+var sheetWriters = map[string]struct {
+	new         func(w io.Writer) domain.ExpenditureSheetWriter
+	contentType string
+}{
+	domain.ExpenditureExportFormatCSV:  {sheet.NewCSVWriter, "text/csv; charset=utf-8"},
+	domain.ExpenditureExportFormatXLSX: {sheet.NewXLSXWriter, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
+// ExportExpenditures deliver data to ExportExpenditures of domain.ExpenditureUsecase
+// file is streamed as response, so error occurred after streaming started can't be responded as json
+func (eh *expenditureHandler) ExportExpenditures(c *gin.Context) {
+	req := new(exportExpendituresRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	filter, err := req.Filter()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	sw := sheetWriters[req.Format]
+	fw := &fileWriter{
+		c:           c,
+		contentType: sw.contentType,
+		filename:    fmt.Sprintf("expenditures_%s.%s", time.Now().In(domain.ServiceLocation).Format("20060102"), req.Format),
+	}
+	w := sw.new(fw)
+
+	if err = eh.eUsecase.ExportExpenditures(c.Request.Context(), c.GetString("uuid"), filter, w); err == nil {
+		err = w.Close()
+	}
+	if err != nil && fw.started {
+		log.Println(errors.Wrap(err, "failed to stream expenditures file").Error())
+		return
+	}
+
+	switch tErr := err.(type) {
+	case nil:
+		break
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "ExportExpenditures return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// fileWriter is io.Writer writing to response as attachment file
+// header of response is set at first write, so that error can be responded as json before it
+type fileWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+// Write method set header of file response at first & write p to response body
+func (fw *fileWriter) Write(p []byte) (int, error) {
+	if !fw.started {
+		fw.started = true
+		fw.c.Header("Content-Type", fw.contentType)
+		fw.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fw.filename))
+		fw.c.Status(http.StatusOK)
+	}
+	return fw.c.Writer.Write(p)
+}
+
+// UpdateExpenditure deliver data to UpdateExpenditure of domain.ExpenditureUsecase
+func (eh *expenditureHandler) UpdateExpenditure(c *gin.Context) {
+	req := new(updateExpenditureRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	e := &domain.Expenditure{
+		UUID:         domain.String(req.ExpenditureUUID),
+		Name:         req.Name,
+		Amount:       req.Amount,
+		Rating:       req.Rating,
+		Link:         req.Link,
+		CategoryUUID: req.CategoryUUID,
+		SpentAt:      req.SpentAt,
+	}
+	receipts, status, err := readReceipts(req.Receipts, req.ReceiptsBase64)
+	if err != nil {
+		c.JSON(status, defaultResp(status, 0, err.Error()))
+		return
+	}
+
+	switch err := eh.eUsecase.UpdateExpenditure(c.Request.Context(), c.GetString("uuid"), e, req.BabyUUIDs, receipts); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to update expenditure")
+		resp["expenditure"] = e
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "UpdateExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteExpenditure deliver data to DeleteExpenditure of domain.ExpenditureUsecase
+func (eh *expenditureHandler) DeleteExpenditure(c *gin.Context) {
+	req := new(deleteExpenditureRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := eh.eUsecase.DeleteExpenditure(c.Request.Context(), c.GetString("uuid"), req.ExpenditureUUID); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete expenditure"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetExpenditureReceipts deliver data to GetExpenditureReceipts of domain.ExpenditureUsecase
+func (eh *expenditureHandler) GetExpenditureReceipts(c *gin.Context) {
+	req := new(expenditureReceiptsRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	receipts, err := eh.eUsecase.GetExpenditureReceipts(c.Request.Context(), c.GetString("uuid"), req.ExpenditureUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure receipts")
+		resp["receipts"] = receipts
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpenditureReceipts return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteExpenditureReceipt deliver data to DeleteExpenditureReceipt of domain.ExpenditureUsecase
+func (eh *expenditureHandler) DeleteExpenditureReceipt(c *gin.Context) {
+	req := new(deleteExpenditureReceiptRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	err := eh.eUsecase.DeleteExpenditureReceipt(c.Request.Context(), c.GetString("uuid"), req.ExpenditureUUID, req.ReceiptUUID)
+	switch tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete expenditure receipt"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteExpenditureReceipt return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// readReceipts function read receipt images from multipart files & base64 strings, with http status for error
+func readReceipts(files []*multipart.FileHeader, base64s []string) (receipts [][]byte, status int, err error) {
+	for _, fh := range files {
+		file, oErr := fh.Open()
+		if oErr != nil {
+			err, status = errors.Wrap(oErr, "failed to open receipt file"), http.StatusBadRequest
+			return
+		}
+		receipt, rErr := ioutil.ReadAll(file)
+		_ = file.Close()
+		if rErr != nil {
+			err, status = errors.Wrap(rErr, "failed to read receipt file"), http.StatusInternalServerError
+			return
+		}
+		receipts = append(receipts, receipt)
+	}
+	for _, s := range base64s {
+		s = string(regexp.MustCompile("^data:image/\\w+;base64,").ReplaceAll([]byte(s), []byte("")))
+		receipt, dErr := base64.StdEncoding.DecodeString(s)
+		if dErr != nil {
+			err, status = errors.Wrap(dErr, "failed to decode base64 string to byte array"), http.StatusBadRequest
+			return
+		}
+		receipts = append(receipts, receipt)
+	}
+	return
+}
+
 // bindRequest method bind *gin.Context to request having BindFrom method
 func (eh *expenditureHandler) bindRequest(req interface {
 	BindFrom(ctx *gin.Context) error
@@ -1,19 +1,176 @@
 package http
 
 import (
+	"github.com/MyFirstBabyTime/Server/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"mime/multipart"
+	"time"
 )
 
 type expenditureRegistration struct {
-	ParentUUID string   `json:"parent_uuid" validate:"required,uuid=parent"`
-	BabyUUIDs  []string `json:"baby_uuids" validate:"required"`
-	Name       string   `json:"name" validate:"required"`
-	Amount     int64    `json:"amount" validate:"required,range=0~1000000000"`
-	Rating     int64    `json:"rating" validate:"required,range=0~5"`
-	Link       string   `json:"link"`
+	ParentUUID string   `form:"parent_uuid" json:"parent_uuid" validate:"omitempty,uuid=parent"`
+	BabyUUIDs  []string `form:"baby_uuids" json:"baby_uuids" validate:"required"`
+	Name       string   `form:"name" json:"name" validate:"required"`
+	Amount     int64    `form:"amount" json:"amount" validate:"required,range=0~1000000000"`
+	Rating     int64    `form:"rating" json:"rating" validate:"required,range=0~5"`
+	Link       string   `form:"link" json:"link"`
+
+	// CategoryUUID is uuid of default or custom expenditure category, not categorized if not set
+	CategoryUUID string `form:"category_uuid" json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+
+	// SpentAt is RFC3339 time that money was spent, now if not set
+	SpentAt *time.Time `form:"spent_at" json:"spent_at"`
+
+	// Receipts & ReceiptsBase64 are receipt images attached to expenditure (multipart or base64 json)
+	Receipts       []*multipart.FileHeader `form:"receipts"`
+	ReceiptsBase64 []string                `json:"receipts_base64"`
 }
 
 func (r *expenditureRegistration) BindFrom(c *gin.Context) error {
-	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+	switch c.ContentType() {
+	case "application/json":
+		return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+	default:
+		return errors.Wrap(c.Bind(r), "failed to Bind")
+	}
+}
+
+// getExpenditureRequest is request for expenditureHandler.GetExpenditure
+type getExpenditureRequest struct {
+	ExpenditureUUID string `uri:"expenditure_uuid" validate:"required,uuid=item"`
+}
+
+func (r *getExpenditureRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// expenditureFilterQuery is query condition of expenditures, used for listing & exporting expenditures
+// Sort is one of name, amount, rating & spent_at, descending order with '-' prefix (ex. -amount)
+// StartDate & EndDate are inclusive dates (yyyy-mm-dd) of spent_at
+// expenditures in subcategories are also listed if CategoryUUID is upper category
+type expenditureFilterQuery struct {
+	BabyUUID     string `form:"baby_uuid" validate:"omitempty,uuid=children"`
+	CategoryUUID string `form:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	MinAmount    *int64 `form:"min_amount" validate:"omitempty,range=0~1000000000"`
+	MaxAmount    *int64 `form:"max_amount" validate:"omitempty,range=0~1000000000"`
+	Rating       *int64 `form:"rating" validate:"omitempty,range=0~5"`
+	Name         string `form:"name" validate:"max=20"`
+	StartDate    string `form:"start_date" validate:"omitempty,len=10"`
+	EndDate      string `form:"end_date" validate:"omitempty,len=10"`
+	Sort         string `form:"sort" validate:"omitempty,oneof=name -name amount -amount rating -rating spent_at -spent_at"`
+}
+
+// Filter method return domain.ExpenditureFilter having condition of query
+func (q expenditureFilterQuery) Filter() (filter domain.ExpenditureFilter, err error) {
+	filter = domain.ExpenditureFilter{
+		BabyUUID:     q.BabyUUID,
+		CategoryUUID: q.CategoryUUID,
+		MinAmount:    q.MinAmount,
+		MaxAmount:    q.MaxAmount,
+		Rating:       q.Rating,
+		Name:         q.Name,
+		Sort:         q.Sort,
+	}
+	if q.StartDate != "" {
+		from, _, dErr := domain.DayRange(q.StartDate)
+		if dErr != nil {
+			err = errors.Wrap(dErr, "invalid start_date")
+			return
+		}
+		filter.SpentFrom = domain.Time(from)
+	}
+	if q.EndDate != "" {
+		_, to, dErr := domain.DayRange(q.EndDate)
+		if dErr != nil {
+			err = errors.Wrap(dErr, "invalid end_date")
+			return
+		}
+		filter.SpentTo = domain.Time(to)
+	}
+	return
+}
+
+// getExpendituresRequest is request for expenditureHandler.GetExpenditures
+type getExpendituresRequest struct {
+	expenditureFilterQuery
+	Cursor string `form:"cursor" validate:"omitempty,uuid=item"`
+	Limit  int    `form:"limit" validate:"range=1~100"`
+}
+
+// defaultExpenditureLimit is count of expenditures returned at once if limit is not set
+const defaultExpenditureLimit = 20
+
+func (r *getExpendituresRequest) BindFrom(c *gin.Context) error {
+	r.Limit = defaultExpenditureLimit
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// exportExpendituresRequest is request for expenditureHandler.ExportExpenditures
+// Format is csv or xlsx, csv if not set
+type exportExpendituresRequest struct {
+	expenditureFilterQuery
+	Format string `form:"format" validate:"oneof=csv xlsx"`
+}
+
+func (r *exportExpendituresRequest) BindFrom(c *gin.Context) error {
+	r.Format = domain.ExpenditureExportFormatCSV
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// updateExpenditureRequest is request for expenditureHandler.UpdateExpenditure
+// field not in body is not updated, and baby tags are replaced only if BabyUUIDs is in body
+// receipts in request are added to receipts already attached to expenditure
+type updateExpenditureRequest struct {
+	ExpenditureUUID string                  `uri:"expenditure_uuid" validate:"required,uuid=item"`
+	Name            *string                 `form:"name" json:"name" validate:"omitempty,min=1,max=20"`
+	Amount          *int64                  `form:"amount" json:"amount" validate:"omitempty,range=0~1000000000"`
+	Rating          *int64                  `form:"rating" json:"rating" validate:"omitempty,range=0~5"`
+	Link            *string                 `form:"link" json:"link" validate:"omitempty,max=100"`
+	CategoryUUID    *string                 `form:"category_uuid" json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	SpentAt         *time.Time              `form:"spent_at" json:"spent_at"`
+	BabyUUIDs       []string                `form:"baby_uuids" json:"baby_uuids" validate:"omitempty,dive,uuid=children"`
+	Receipts        []*multipart.FileHeader `form:"receipts"`
+	ReceiptsBase64  []string                `json:"receipts_base64"`
+}
+
+func (r *updateExpenditureRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+
+	switch c.ContentType() {
+	case "application/json":
+		return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+	default:
+		return errors.Wrap(c.Bind(r), "failed to Bind")
+	}
+}
+
+// deleteExpenditureRequest is request for expenditureHandler.DeleteExpenditure
+type deleteExpenditureRequest struct {
+	ExpenditureUUID string `uri:"expenditure_uuid" validate:"required,uuid=item"`
+}
+
+func (r *deleteExpenditureRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// expenditureReceiptsRequest is request for expenditureHandler.GetExpenditureReceipts
+type expenditureReceiptsRequest struct {
+	ExpenditureUUID string `uri:"expenditure_uuid" validate:"required,uuid=item"`
+}
+
+func (r *expenditureReceiptsRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// deleteExpenditureReceiptRequest is request for expenditureHandler.DeleteExpenditureReceipt
+type deleteExpenditureReceiptRequest struct {
+	ExpenditureUUID string `uri:"expenditure_uuid" validate:"required,uuid=item"`
+	ReceiptUUID     string `uri:"receipt_uuid" validate:"required,uuid=expenditure_receipt"`
+}
+
+func (r *deleteExpenditureReceiptRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
 }
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/MyFirstBabyTime/Server/domain"
 	"github.com/MyFirstBabyTime/Server/tx"
@@ -10,6 +11,8 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"log"
+	"strings"
+	"time"
 )
 
 type expenditureRepository struct {
@@ -46,6 +49,10 @@ func ExpenditureRepository(
 		log.Fatal(errors.Wrap(err, "failed to migrate parent auth model").Error())
 	}
 
+	if err := repo.migrator.MigrateColumns(repo.db, domain.Expenditure{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate expenditure columns").Error())
+	}
+
 	if err := repo.migrator.MigrateModel(repo.db, domain.ExpenditureBabyTag{}); err != nil {
 		log.Fatal(errors.Wrap(err, "failed to migrate parent auth model").Error())
 	}
@@ -79,8 +86,8 @@ func (er *expenditureRepository) Store(ctx tx.Context, e *domain.Expenditure, ba
 
 	_tx, _ := ctx.Tx().(*sqlx.Tx)
 	_sql, args, _ := squirrel.Insert("expenditure").
-		Columns("uuid", "parent_uuid", "name", "amount", "rating", "link").
-		Values(e.UUID, e.ParentUUID, e.Name, e.Amount, e.Rating, e.Link).ToSql()
+		Columns("uuid", "parent_uuid", "name", "amount", "rating", "link", "category_uuid", "spent_at", "created_at", "updated_at").
+		Values(e.UUID, e.ParentUUID, e.Name, e.Amount, e.Rating, e.Link, e.CategoryUUID, e.SpentAt, e.CreatedAt, e.UpdatedAt).ToSql()
 
 	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
 	case nil:
@@ -139,6 +146,216 @@ func (er *expenditureRepository) Store(ctx tx.Context, e *domain.Expenditure, ba
 	return
 }
 
+// Update is implement Update method of domain.ExpenditureRepository interface
+func (er *expenditureRepository) Update(ctx tx.Context, e *domain.Expenditure) (err error) {
+	if domain.StringValue(e.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = er.validator.ValidateStruct(e); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Expenditure")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("expenditure").
+		Set("name", e.Name).
+		Set("amount", e.Amount).
+		Set("rating", e.Rating).
+		Set("link", e.Link).
+		Set("category_uuid", e.CategoryUUID).
+		Set("spent_at", e.SpentAt).
+		Set("updated_at", e.UpdatedAt).
+		Where("uuid = ?", e.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "failed to update expenditure")
+	}
+	return
+}
+
+// ReplaceBabyTags is implement ReplaceBabyTags method of domain.ExpenditureRepository interface
+// tags are deleted & inserted in transaction of ctx, so tag set is replaced atomically
+func (er *expenditureRepository) ReplaceBabyTags(ctx tx.Context, expenditureUUID string, babyUUIDs []string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure_baby_tag").Where("expenditure_uuid = ?", expenditureUUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "delete expenditure_baby_tag return unexpected error")
+		return
+	}
+
+	for _, babyUUID := range babyUUIDs {
+		_sql, args, _ = squirrel.Insert("expenditure_baby_tag").
+			Columns("expenditure_uuid", "baby_uuid").
+			Values(expenditureUUID, babyUUID).ToSql()
+
+		switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+		case nil:
+			continue
+		case *mysql.MySQLError:
+			switch tErr.Number {
+			case mysqlerr.ER_DUP_ENTRY:
+				err = errors.Wrap(err, "failed to insert expenditure_baby_tag")
+				_, key := er.sqlMsgParser.EntryDuplicate(tErr.Message)
+				err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+			default:
+				err = errors.Wrap(err, "insert expenditure_baby_tag unexpected mysql error")
+			}
+		default:
+			err = errors.Wrap(err, "insert expenditure_baby_tag return unexpected error")
+		}
+		return
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ExpenditureRepository interface
+// baby tags of expenditure are deleted by ON DELETE CASCADE
+func (er *expenditureRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete expenditure return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("expenditure with that uuid is not exist")}
+	}
+	return
+}
+
+// SumAmountByCategory is implement SumAmountByCategory method of domain.ExpenditureRepository interface
+// amount of expenditures spent in from ~ to is summed by category
+func (er *expenditureRepository) SumAmountByCategory(ctx tx.Context, parentUUID string, from, to time.Time) (sums []domain.ExpenditureCategorySum, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("category_uuid", "SUM(amount) AS amount").From("expenditure").
+		Where("parent_uuid = ? AND spent_at >= ? AND spent_at < ?", parentUUID, from, to).
+		GroupBy("category_uuid").ToSql()
+
+	sums = []domain.ExpenditureCategorySum{}
+	if err = _tx.Select(&sums, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select sum of expenditure amount return unexpected error")
+	}
+	return
+}
+
+// expenditureSortColumns is column & order of each sort value in domain.ExpenditureFilter
+var expenditureSortColumns = map[string]struct {
+	column string
+	desc   bool
+}{
+	domain.ExpenditureSortName:        {"name", false},
+	domain.ExpenditureSortNameDesc:    {"name", true},
+	domain.ExpenditureSortAmount:      {"amount", false},
+	domain.ExpenditureSortAmountDesc:  {"amount", true},
+	domain.ExpenditureSortRating:      {"rating", false},
+	domain.ExpenditureSortRatingDesc:  {"rating", true},
+	domain.ExpenditureSortSpentAt:     {"spent_at", false},
+	domain.ExpenditureSortSpentAtDesc: {"spent_at", true},
+}
+
+// GetByParentUUID is implement GetByParentUUID method of domain.ExpenditureRepository interface
+// uuid is used as tie breaker of sort column, so that cursor point exact position in order
+func (er *expenditureRepository) GetByParentUUID(ctx tx.Context, parentUUID string, filter domain.ExpenditureFilter) (expenditures []domain.Expenditure, err error) {
+	sort, ok := expenditureSortColumns[filter.Sort]
+	if !ok {
+		err = errors.Errorf("invalid expenditure sort value %q", filter.Sort)
+		return
+	}
+
+	query := squirrel.Select("*").From("expenditure").Where("parent_uuid = ?", parentUUID)
+	if filter.BabyUUID != "" {
+		query = query.Where("uuid IN (SELECT expenditure_uuid FROM expenditure_baby_tag WHERE baby_uuid = ?)", filter.BabyUUID)
+	}
+	if len(filter.CategoryUUIDs) != 0 {
+		query = query.Where(squirrel.Eq{"category_uuid": filter.CategoryUUIDs})
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.Rating != nil {
+		query = query.Where("rating = ?", *filter.Rating)
+	}
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+	if filter.SpentFrom != nil {
+		query = query.Where("spent_at >= ?", *filter.SpentFrom)
+	}
+	if filter.SpentTo != nil {
+		query = query.Where("spent_at < ?", *filter.SpentTo)
+	}
+
+	op, order := ">", "ASC"
+	if sort.desc {
+		op, order = "<", "DESC"
+	}
+	if filter.Cursor != "" {
+		query = query.Where(fmt.Sprintf("(%[1]s, uuid) %[2]s (SELECT %[1]s, uuid FROM expenditure WHERE uuid = ?)", sort.column, op), filter.Cursor)
+	}
+	query = query.OrderBy(fmt.Sprintf("%s %s", sort.column, order), fmt.Sprintf("uuid %s", order))
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := query.ToSql()
+
+	expenditures = []domain.Expenditure{}
+	if err = _tx.Select(&expenditures, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditures return unexpected error")
+	}
+	return
+}
+
+// likeEscaper escape wildcard characters of LIKE pattern
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// GetByBabyUUIDInRange is implement GetByBabyUUIDInRange method of domain.ExpenditureRepository interface
+// expenditure is linked to baby by expenditure_baby_tag, and spent time (spent_at) is used as range
+func (er *expenditureRepository) GetByBabyUUIDInRange(
+	ctx tx.Context,
+	parentUUID, babyUUID string,
+	from, to time.Time,
+) (expenditures []domain.Expenditure, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure").
+		Where("parent_uuid = ?", parentUUID).
+		Where("uuid IN (SELECT expenditure_uuid FROM expenditure_baby_tag WHERE baby_uuid = ?)", babyUUID).
+		Where("spent_at >= ? AND spent_at < ?", from, to).
+		OrderBy("spent_at", "uuid").ToSql()
+
+	expenditures = []domain.Expenditure{}
+	if err = _tx.Select(&expenditures, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditures of baby return unexpected error")
+	}
+	return
+}
+
+// GetBabyTagsByExpenditureUUIDs is implement GetBabyTagsByExpenditureUUIDs method of domain.ExpenditureRepository interface
+func (er *expenditureRepository) GetBabyTagsByExpenditureUUIDs(ctx tx.Context, expenditureUUIDs []string) (tags []domain.ExpenditureBabyTag, err error) {
+	tags = []domain.ExpenditureBabyTag{}
+	if len(expenditureUUIDs) == 0 {
+		return
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_baby_tag").
+		Where(squirrel.Eq{"expenditure_uuid": expenditureUUIDs}).
+		OrderBy("expenditure_uuid", "baby_uuid").ToSql()
+
+	if err = _tx.Select(&tags, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditure baby tags return unexpected error")
+	}
+	return
+}
+
 func (er *expenditureRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
 	e := new(domain.Expenditure)
 
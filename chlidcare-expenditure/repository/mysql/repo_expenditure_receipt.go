@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureReceiptRepository is implementation of domain.ExpenditureReceiptRepository using mysql
+type expenditureReceiptRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// ExpenditureReceiptRepository return implementation of domain.ExpenditureReceiptRepository using mysql
+func ExpenditureReceiptRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.ExpenditureReceiptRepository {
+	repo := &expenditureReceiptRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.ExpenditureReceipt{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate expenditure receipt model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.ExpenditureReceiptRepository interface
+// ReceiptUri is generated with uuid if it is not set
+func (rr *expenditureReceiptRepository) Store(ctx tx.Context, r *domain.ExpenditureReceipt) (err error) {
+	if domain.StringValue(r.UUID) == "" {
+		if r.UUID, err = rr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+	if r.ReceiptUri == nil {
+		r.ReceiptUri = domain.String(r.GenerateReceiptUri())
+	}
+
+	if err = rr.validator.ValidateStruct(r); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ExpenditureReceipt")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("expenditure_receipt").
+		Columns("uuid", "expenditure_uuid", "receipt_uri", "created_at").
+		Values(r.UUID, r.ExpenditureUUID, r.ReceiptUri, r.CreatedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert expenditure receipt")
+			fk := rr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert expenditure receipt return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert expenditure receipt return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.ExpenditureReceiptRepository interface
+func (rr *expenditureReceiptRepository) GetByUUID(ctx tx.Context, uuid string) (r domain.ExpenditureReceipt, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_receipt").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&r, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select expenditure receipt")}
+	default:
+		err = errors.Wrap(err, "select expenditure receipt return unexpected error")
+	}
+	return
+}
+
+// GetByExpenditureUUID is implement GetByExpenditureUUID method of domain.ExpenditureReceiptRepository interface
+func (rr *expenditureReceiptRepository) GetByExpenditureUUID(ctx tx.Context, expenditureUUID string) (rs []domain.ExpenditureReceipt, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_receipt").
+		Where("expenditure_uuid = ?", expenditureUUID).
+		OrderBy("created_at", "uuid").ToSql()
+
+	rs = []domain.ExpenditureReceipt{}
+	if err = _tx.Select(&rs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditure receipts return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ExpenditureReceiptRepository interface
+func (rr *expenditureReceiptRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure_receipt").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete expenditure receipt return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("expenditure receipt with that uuid is not exist")}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of expenditure receipt table
+func (rr *expenditureReceiptRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	r := new(domain.ExpenditureReceipt)
+
+	for {
+		uuid := r.GenerateRandomUUID()
+		_, err := rr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// utf8BOM is byte order mark written at start of csv, so that excel read korean text in csv as UTF-8
+const utf8BOM = "\xEF\xBB\xBF"
+
+// formulaPrefixes is first characters making spreadsheet read cell as formula
+const formulaPrefixes = "=+-@\t\r"
+
+// csvWriter is struct that write expenditure rows to w as csv, implementing domain.ExpenditureSheetWriter
+// nothing is written to w until first row is written or writer is closed
+type csvWriter struct {
+	w         io.Writer
+	csv       *csv.Writer
+	headerSet bool
+}
+
+// NewCSVWriter return domain.ExpenditureSheetWriter writing csv with UTF-8 BOM to w
+func NewCSVWriter(w io.Writer) domain.ExpenditureSheetWriter {
+	return &csvWriter{
+		w:   w,
+		csv: csv.NewWriter(w),
+	}
+}
+
+// WriteRow implement WriteRow method of domain.ExpenditureSheetWriter interface
+func (cw *csvWriter) WriteRow(row domain.ExpenditureExportRow) (err error) {
+	if err = cw.writeHeader(); err != nil {
+		return
+	}
+
+	err = cw.csv.Write([]string{
+		row.Date(),
+		escapeFormula(row.Name),
+		strconv.FormatInt(row.Amount, 10),
+		escapeFormula(row.Category),
+		strconv.FormatInt(row.Rating, 10),
+		escapeFormula(row.Link),
+		escapeFormula(row.ChildrenNames()),
+	})
+	return errors.Wrap(err, "failed to write csv row")
+}
+
+// Close implement Close method of domain.ExpenditureSheetWriter interface
+func (cw *csvWriter) Close() (err error) {
+	if err = cw.writeHeader(); err != nil {
+		return
+	}
+
+	cw.csv.Flush()
+	return errors.Wrap(cw.csv.Error(), "failed to flush csv")
+}
+
+// writeHeader method write BOM & header row once
+func (cw *csvWriter) writeHeader() (err error) {
+	if cw.headerSet {
+		return
+	}
+	cw.headerSet = true
+
+	if _, err = io.WriteString(cw.w, utf8BOM); err != nil {
+		return errors.Wrap(err, "failed to write BOM")
+	}
+	return errors.Wrap(cw.csv.Write(domain.ExpenditureExportColumns), "failed to write csv header")
+}
+
+// escapeFormula function prefix text with ' if spreadsheet would run it as formula
+// text of csv cell is written by user (or imported from bank statement), so it must not be run in excel
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
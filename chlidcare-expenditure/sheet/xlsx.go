@@ -0,0 +1,164 @@
+package sheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// xlsxSheetName is name of worksheet that expenditures are written in
+const xlsxSheetName = "지출"
+
+// xlsxParts is fixed parts of xlsx package (office open xml) except worksheet, in order written to zip
+// worksheet has text in inline string, so that shared string table & style are not needed
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + xlsxSheetName + `" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter is struct that write expenditure rows to w as xlsx, implementing domain.ExpenditureSheetWriter
+// rows are streamed into worksheet entry of zip, so whole file is not kept in memory
+// nothing is written to w until first row is written or writer is closed
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+// NewXLSXWriter return domain.ExpenditureSheetWriter writing xlsx to w
+func NewXLSXWriter(w io.Writer) domain.ExpenditureSheetWriter {
+	return &xlsxWriter{
+		zip: zip.NewWriter(w),
+	}
+}
+
+// WriteRow implement WriteRow method of domain.ExpenditureSheetWriter interface
+func (xw *xlsxWriter) WriteRow(row domain.ExpenditureExportRow) (err error) {
+	if err = xw.writeHeader(); err != nil {
+		return
+	}
+
+	return xw.writeCells(
+		textCell(row.Date()),
+		textCell(row.Name),
+		numberCell(row.Amount),
+		textCell(row.Category),
+		numberCell(row.Rating),
+		textCell(row.Link),
+		textCell(row.ChildrenNames()),
+	)
+}
+
+// Close implement Close method of domain.ExpenditureSheetWriter interface
+func (xw *xlsxWriter) Close() (err error) {
+	if err = xw.writeHeader(); err != nil {
+		return
+	}
+
+	if _, err = io.WriteString(xw.sheet, `</sheetData></worksheet>`); err != nil {
+		return errors.Wrap(err, "failed to write end of worksheet")
+	}
+	return errors.Wrap(xw.zip.Close(), "failed to close xlsx zip")
+}
+
+// writeHeader method write fixed parts, start of worksheet & header row once
+func (xw *xlsxWriter) writeHeader() (err error) {
+	if xw.sheet != nil {
+		return
+	}
+
+	for _, part := range xlsxParts {
+		f, cErr := xw.zip.Create(part.name)
+		if cErr != nil {
+			return errors.Wrapf(cErr, "failed to create %s in xlsx zip", part.name)
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return errors.Wrapf(err, "failed to write %s in xlsx zip", part.name)
+		}
+	}
+
+	if xw.sheet, err = xw.zip.Create("xl/worksheets/sheet1.xml"); err != nil {
+		return errors.Wrap(err, "failed to create worksheet in xlsx zip")
+	}
+	if _, err = io.WriteString(xw.sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return errors.Wrap(err, "failed to write start of worksheet")
+	}
+
+	cells := make([]cell, 0, len(domain.ExpenditureExportColumns))
+	for _, column := range domain.ExpenditureExportColumns {
+		cells = append(cells, textCell(column))
+	}
+	return xw.writeCells(cells...)
+}
+
+// writeCells method write cells as next row of worksheet
+func (xw *xlsxWriter) writeCells(cells ...cell) (err error) {
+	xw.rows++
+	if _, err = fmt.Fprintf(xw.sheet, `<row r="%d">`, xw.rows); err != nil {
+		return errors.Wrap(err, "failed to write xlsx row")
+	}
+	for i, c := range cells {
+		if err = c.write(xw.sheet, fmt.Sprintf("%c%d", 'A'+i, xw.rows)); err != nil {
+			return errors.Wrap(err, "failed to write xlsx cell")
+		}
+	}
+	_, err = io.WriteString(xw.sheet, `</row>`)
+	return errors.Wrap(err, "failed to write xlsx row")
+}
+
+// cell is one cell of worksheet, text is written as inline string if it is not number
+type cell struct {
+	text     string
+	isNumber bool
+}
+
+// textCell function return cell having text s
+func textCell(s string) cell {
+	return cell{text: s}
+}
+
+// numberCell function return cell having number n
+func numberCell(n int64) cell {
+	return cell{text: strconv.FormatInt(n, 10), isNumber: true}
+}
+
+// write method write cell with reference ref (ex. A1) to w
+func (c cell) write(w io.Writer, ref string) (err error) {
+	if c.isNumber {
+		_, err = fmt.Fprintf(w, `<c r="%s"><v>%s</v></c>`, ref, c.text)
+		return
+	}
+
+	if _, err = fmt.Fprintf(w, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref); err != nil {
+		return
+	}
+	if err = xml.EscapeText(w, []byte(c.text)); err != nil {
+		return
+	}
+	_, err = io.WriteString(w, `</t></is></c>`)
+	return
+}
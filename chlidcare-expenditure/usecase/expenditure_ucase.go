@@ -1,37 +1,97 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"fmt"
 	"github.com/MyFirstBabyTime/Server/domain"
 	"github.com/MyFirstBabyTime/Server/tx"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type expenditureUsecase struct {
+	// myCfg is used for getting expenditure usecase config
+	myCfg expenditureUsecaseConfig
+
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
 	// expenditureRepository is repository interface about domain.ExpenditureRepository
 	expenditureRepository domain.ExpenditureRepository
 
+	// expenditureReceiptRepository is repository interface about domain.ExpenditureReceipt model
+	expenditureReceiptRepository domain.ExpenditureReceiptRepository
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// expenditureBudgetRepository is repository interface about domain.ExpenditureBudget model
+	expenditureBudgetRepository domain.ExpenditureBudgetRepository
+
+	// parentAuthRepository is repository interface about domain.ParentAuth model
+	parentAuthRepository domain.ParentAuthRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
 	// txHandler is used for handling transaction to begin & commit or rollback
 	txHandler txHandler
 
 	elasticSearch elasticSearch
+
+	// messageAgency is used as agency about message API
+	messageAgency messageAgency
+
+	// s3Agency is used as agency about aws s3 API
+	s3Agency s3Agency
 }
 
 func ExpenditureUsecase(
+	cfg expenditureUsecaseConfig,
+	dc []domain.ExpenditureCategory,
 	er domain.ExpenditureRepository,
+	rcr domain.ExpenditureReceiptRepository,
+	ecr domain.ExpenditureCategoryRepository,
+	ebr domain.ExpenditureBudgetRepository,
+	par domain.ParentAuthRepository,
+	cr domain.ChildrenRepository,
 	th txHandler,
 	es elasticSearch,
+	ma messageAgency,
+	sa s3Agency,
 ) *expenditureUsecase {
 	return &expenditureUsecase{
-		expenditureRepository: er,
+		myCfg:                         cfg,
+		defaultCategories:             dc,
+		expenditureRepository:         er,
+		expenditureReceiptRepository:  rcr,
+		expenditureCategoryRepository: ecr,
+		expenditureBudgetRepository:   ebr,
+		parentAuthRepository:          par,
+		childrenRepository:            cr,
 
 		txHandler:     th,
 		elasticSearch: es,
+		messageAgency: ma,
+		s3Agency:      sa,
 	}
 }
 
+// expenditureUsecaseConfig is interface get config value for expenditure usecase
+type expenditureUsecaseConfig interface {
+	// ReceiptS3Bucket return aws s3 bucket name for expenditure receipt image
+	ReceiptS3Bucket() string
+
+	// DownloadLinkDuration return valid duration of receipt image download link
+	DownloadLinkDuration() time.Duration
+}
+
 // txHandler is used for handling transaction to begin & commit or rollback
 type txHandler interface {
 	// BeginTx method start transaction (get option from ctx)
@@ -45,16 +105,89 @@ type txHandler interface {
 }
 
 type elasticSearch interface {
-	Create(ctx context.Context, index string, s string) (err error)
+	// Create method index document s with id in index
+	Create(ctx context.Context, index, id, s string) (err error)
+
+	// Update method replace document with id in index to document s
+	Update(ctx context.Context, index, id, s string) (err error)
+
+	// Delete method delete document with id in index
+	Delete(ctx context.Context, index, id string) (err error)
+}
+
+// messageAgency is agency that agent various API about message
+type messageAgency interface {
+	// SendSMSToOne method send SMS message to one receiver
+	SendSMSToOne(receiver, content string) (err error)
+}
+
+// s3Agency is agency that agent various API about aws s3
+type s3Agency interface {
+	// PutObject method put(insert or update) object to s3
+	PutObject(input *s3.PutObjectInput) (output *s3.PutObjectOutput, err error)
+
+	// DeleteObject method delete object from s3
+	DeleteObject(input *s3.DeleteObjectInput) (output *s3.DeleteObjectOutput, err error)
+
+	// PresignGetObject method return url that anyone can download object with until expire
+	PresignGetObject(input *s3.GetObjectInput, expire time.Duration) (url string, err error)
 }
 
-func (eu *expenditureUsecase) ExpenditureRegistration(ctx context.Context, req *domain.Expenditure, babyUUIDs []string) (err error) {
+// esIndex is elasticsearch index that expenditure document is stored in, with expenditure uuid as document id
+const esIndex = domain.ExpenditureSearchIndex
+
+// ExpenditureRegistration implement ExpenditureRegistration method of domain.ExpenditureUsecase interface
+// expenditure is spent now if SpentAt is not set
+// parent is notified by SMS if expenditure make monthly spend cross alert rate of budget
+func (eu *expenditureUsecase) ExpenditureRegistration(
+	ctx context.Context,
+	parentUUID string,
+	req *domain.Expenditure,
+	babyUUIDs []string,
+	receipts [][]byte,
+) (err error) {
+	if len(receipts) > domain.MaxExpenditureReceiptCount {
+		err = errors.Errorf("expenditure can have up to %d receipts", domain.MaxExpenditureReceiptCount)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	// category, budget & receipts are checked with parent in token, not with value of req
+	req.ParentUUID = domain.String(parentUUID)
+
+	now := time.Now()
+	if req.SpentAt == nil {
+		req.SpentAt = domain.Time(now)
+	}
+	req.CreatedAt = domain.Time(now)
+	req.UpdatedAt = domain.Time(now)
+
 	_tx, err := eu.txHandler.BeginTx(ctx, nil)
 	if err != nil {
 		err = errors.Wrap(err, "failed to begin transaction")
 		return
 	}
 
+	categories, err := eu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+	if req.CategoryUUID != nil {
+		if _, ok := categories.Find(*req.CategoryUUID); !ok {
+			err = errors.New("category with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+	for _, babyUUID := range babyUUIDs {
+		if err = eu.checkOwnChildren(_tx, parentUUID, babyUUID); err != nil {
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
 	switch err = eu.expenditureRepository.Store(_tx, req, babyUUIDs); tErr := err.(type) {
 	case nil:
 		break
@@ -62,6 +195,7 @@ func (eu *expenditureUsecase) ExpenditureRegistration(ctx context.Context, req *
 		err = errors.Wrap(err, "Expenditure Store return invalid model")
 		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
 		_ = eu.txHandler.Rollback(_tx)
+		return
 	case domain.ErrNoReferencedRow:
 		switch tErr.ForeignKey {
 		case "parent_uuid":
@@ -105,8 +239,13 @@ func (eu *expenditureUsecase) ExpenditureRegistration(ctx context.Context, req *
 		return
 	}
 
-	body, _ := esRequestBodyGenerator(req, babyUUIDs)
-	err = eu.elasticSearch.Create(ctx, "Expenditure", body)
+	if err = eu.storeReceipts(_tx, parentUUID, domain.StringValue(req.UUID), receipts); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	body, _ := domain.ExpenditureSearchDocument(req, babyUUIDs, categories.Path(domain.StringValue(req.CategoryUUID)))
+	err = eu.elasticSearch.Create(ctx, esIndex, domain.StringValue(req.UUID), body)
 
 	if err != nil {
 		err = errors.Wrap(err, "Expenditure Store return unexpected elasticSearch error")
@@ -115,22 +254,719 @@ func (eu *expenditureUsecase) ExpenditureRegistration(ctx context.Context, req *
 		return
 	}
 
+	phoneNumber, content := eu.checkBudgetAlert(_tx, req, categories)
 	_ = eu.txHandler.Commit(_tx)
+
+	if phoneNumber != "" {
+		if sErr := eu.messageAgency.SendSMSToOne(phoneNumber, content); sErr != nil {
+			log.Println(errors.Wrap(sErr, "SendSMSToOne return unexpected error").Error())
+		}
+	}
 	return nil
 }
 
-func esRequestBodyGenerator(req *domain.Expenditure, babyUUIDS []string) (string, error) {
-	data := make(map[string]interface{})
+// checkBudgetAlert method return phone number of parent & alert content if expenditure e cross alert rate of budget
+// phone number is empty if no alert is needed, and failure of checking don't fail registration
+func (eu *expenditureUsecase) checkBudgetAlert(
+	_tx tx.Context,
+	e *domain.Expenditure,
+	categories domain.ExpenditureCategories,
+) (phoneNumber, content string) {
+	parentUUID := domain.StringValue(e.ParentUUID)
+	budgets, err := eu.expenditureBudgetRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		log.Println(errors.Wrap(err, "expenditure budget GetByParentUUID return unexpected error").Error())
+		return
+	}
+
+	covering := make([]domain.ExpenditureBudget, 0, len(budgets))
+	for _, eb := range budgets {
+		if eb.Covers(categories, domain.StringValue(e.CategoryUUID)) {
+			covering = append(covering, eb)
+		}
+	}
+	if len(covering) == 0 {
+		return
+	}
+
+	month := domain.MonthOf(domain.TimeValue(e.SpentAt))
+	from, to, _ := domain.MonthRange(month)
+	sums, err := eu.expenditureRepository.SumAmountByCategory(_tx, parentUUID, from, to)
+	if err != nil {
+		log.Println(errors.Wrap(err, "expenditure SumAmountByCategory return unexpected error").Error())
+		return
+	}
+
+	lines := []string{}
+	for _, eb := range covering {
+		status := domain.NewExpenditureBudgetStatus(eb, categories, sums, month)
+		rate, crossed := status.CrossedAlertRate(domain.Int64Value(e.Amount))
+		if !crossed {
+			continue
+		}
+
+		name := "전체"
+		if ec, ok := categories.Find(domain.StringValue(eb.CategoryUUID)); ok {
+			name = domain.StringValue(ec.Name)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s 예산의 %d%%를 사용했어요. (%d원 / %d원)", month, name, rate, status.Spent, status.Budget))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	p, err := eu.parentAuthRepository.GetByUUID(_tx, parentUUID)
+	if err != nil {
+		log.Println(errors.Wrap(err, "parent auth GetByUUID return unexpected error").Error())
+		return
+	}
+
+	phoneNumber = domain.StringValue(p.PhoneNumber)
+	content = "[육아는 처음이지 예산 알림]\n" + strings.Join(lines, "\n")
+	return
+}
+
+// GetExpenditure implement GetExpenditure method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) GetExpenditure(ctx context.Context, parentUUID, uuid string) (e domain.Expenditure, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if e, err = eu.getOwnExpenditure(_tx, parentUUID, uuid); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	expenditures := []domain.Expenditure{e}
+	if err = eu.setBabyUUIDs(_tx, expenditures); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+	e = expenditures[0]
+
+	if e.Receipts, err = eu.expenditureReceiptRepository.GetByExpenditureUUID(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "expenditure receipt GetByExpenditureUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
 
-	data["UUID"] = req.UUID
-	data["ParentUUID"] = req.ParentUUID
-	data["Name"] = req.Name
-	data["Amount"] = req.Amount
-	data["Rating"] = req.Rating
-	data["Link"] = req.Link
-	data["baby"] = babyUUIDS
+	_ = eu.txHandler.Commit(_tx)
+	err = eu.setReceiptDownloadUrls(e.Receipts)
+	return
+}
 
-	body, err := json.Marshal(data)
+// GetExpenditures implement GetExpenditures method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) GetExpenditures(
+	ctx context.Context,
+	parentUUID string,
+	filter domain.ExpenditureFilter,
+) (expenditures []domain.Expenditure, nextCursor string, err error) {
+	if filter.Sort == "" {
+		filter.Sort = domain.ExpenditureSortName
+	}
+	if filter.Limit <= 0 {
+		err = errors.New("limit must be greater than 0")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+	if err = validateFilter(filter); err != nil {
+		return
+	}
 
-	return string(body), err
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if filter.CategoryUUID != "" {
+		categories, cErr := eu.getCategories(_tx, parentUUID)
+		if cErr != nil {
+			err = cErr
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+		if err = setCategoryUUIDs(categories, &filter); err != nil {
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	if filter.Cursor != "" {
+		if _, err = eu.getOwnExpenditure(_tx, parentUUID, filter.Cursor); err != nil {
+			err = errors.New("invalid cursor, expenditure with that uuid is not in list")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	// select one more to know if there is next page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	if expenditures, err = eu.expenditureRepository.GetByParentUUID(_tx, parentUUID, filter); err != nil {
+		err = errors.Wrap(err, "expenditure GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+	if len(expenditures) > limit {
+		expenditures = expenditures[:limit]
+		nextCursor = domain.StringValue(expenditures[limit-1].UUID)
+	}
+
+	if err = eu.setBabyUUIDs(_tx, expenditures); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// exportPageSize is count of expenditures read from repository at once while exporting
+const exportPageSize = 500
+
+// ExportExpenditures implement ExportExpenditures method of domain.ExpenditureUsecase interface
+// expenditures are read page by page & written to w right away, in order of spent_at if sort is not set
+// nothing is written to w if error is returned before first page is read
+func (eu *expenditureUsecase) ExportExpenditures(
+	ctx context.Context,
+	parentUUID string,
+	filter domain.ExpenditureFilter,
+	w domain.ExpenditureSheetWriter,
+) (err error) {
+	if filter.Sort == "" {
+		filter.Sort = domain.ExpenditureSortSpentAt
+	}
+	filter.Cursor, filter.Limit = "", exportPageSize
+	if err = validateFilter(filter); err != nil {
+		return
+	}
+
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	categories, err := eu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+	if filter.CategoryUUID != "" {
+		if err = setCategoryUUIDs(categories, &filter); err != nil {
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	childrenNames := map[string]string{}
+	for {
+		expenditures, gErr := eu.expenditureRepository.GetByParentUUID(_tx, parentUUID, filter)
+		if gErr != nil {
+			err = errors.Wrap(gErr, "expenditure GetByParentUUID return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+		if len(expenditures) == 0 {
+			break
+		}
+
+		if err = eu.setBabyUUIDs(_tx, expenditures); err != nil {
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+
+		for _, e := range expenditures {
+			row := domain.ExpenditureExportRow{
+				SpentAt:  domain.TimeValue(e.SpentAt),
+				Name:     domain.StringValue(e.Name),
+				Amount:   domain.Int64Value(e.Amount),
+				Category: categories.CategoryName(domain.StringValue(e.CategoryUUID)),
+				Rating:   domain.Int64Value(e.Rating),
+				Link:     domain.StringValue(e.Link),
+				Children: make([]string, 0, len(e.BabyUUIDs)),
+			}
+			for _, babyUUID := range e.BabyUUIDs {
+				if _, ok := childrenNames[babyUUID]; !ok {
+					if childrenNames[babyUUID], err = eu.getChildrenName(_tx, parentUUID, babyUUID); err != nil {
+						_ = eu.txHandler.Rollback(_tx)
+						return
+					}
+				}
+				row.Children = append(row.Children, childrenNames[babyUUID])
+			}
+
+			if err = w.WriteRow(row); err != nil {
+				err = errors.Wrap(err, "failed to write expenditure row")
+				_ = eu.txHandler.Rollback(_tx)
+				return
+			}
+		}
+
+		if len(expenditures) < filter.Limit {
+			break
+		}
+		filter.Cursor = domain.StringValue(expenditures[len(expenditures)-1].UUID)
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// UpdateExpenditure implement UpdateExpenditure method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) UpdateExpenditure(
+	ctx context.Context,
+	parentUUID string,
+	e *domain.Expenditure,
+	babyUUIDs []string,
+	receipts [][]byte,
+) (err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	cur, err := eu.getOwnExpenditure(_tx, parentUUID, domain.StringValue(e.UUID))
+	if err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if e.Name != nil {
+		cur.Name = e.Name
+	}
+	if e.Amount != nil {
+		cur.Amount = e.Amount
+	}
+	if e.Rating != nil {
+		cur.Rating = e.Rating
+	}
+	if e.Link != nil {
+		cur.Link = e.Link
+	}
+	if e.SpentAt != nil {
+		cur.SpentAt = e.SpentAt
+	}
+
+	categories, err := eu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+	if e.CategoryUUID != nil {
+		if _, ok := categories.Find(*e.CategoryUUID); !ok {
+			err = errors.New("category with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+		cur.CategoryUUID = e.CategoryUUID
+	}
+	cur.UpdatedAt = domain.Time(time.Now())
+
+	switch err = eu.expenditureRepository.Update(_tx, &cur); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "expenditure Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if babyUUIDs != nil {
+		for _, babyUUID := range babyUUIDs {
+			if err = eu.checkOwnChildren(_tx, parentUUID, babyUUID); err != nil {
+				_ = eu.txHandler.Rollback(_tx)
+				return
+			}
+		}
+
+		switch err = eu.expenditureRepository.ReplaceBabyTags(_tx, domain.StringValue(cur.UUID), babyUUIDs); err.(type) {
+		case nil:
+			cur.BabyUUIDs = babyUUIDs
+		case domain.ErrEntryDuplicate:
+			err = errors.New("baby uuids must not be duplicated")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		default:
+			err = errors.Wrap(err, "expenditure ReplaceBabyTags return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	} else {
+		expenditures := []domain.Expenditure{cur}
+		if err = eu.setBabyUUIDs(_tx, expenditures); err != nil {
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+		cur = expenditures[0]
+	}
+
+	if err = eu.storeReceipts(_tx, parentUUID, domain.StringValue(cur.UUID), receipts); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	// document is replaced before commit, so that mysql is rolled back if elasticsearch fail
+	body, _ := domain.ExpenditureSearchDocument(&cur, cur.BabyUUIDs, categories.Path(domain.StringValue(cur.CategoryUUID)))
+	if err = eu.elasticSearch.Update(ctx, esIndex, domain.StringValue(cur.UUID), body); err != nil {
+		err = errors.Wrap(err, "Expenditure Update return unexpected elasticSearch error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	*e = cur
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// DeleteExpenditure implement DeleteExpenditure method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) DeleteExpenditure(ctx context.Context, parentUUID, uuid string) (err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnExpenditure(_tx, parentUUID, uuid); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	receipts, err := eu.expenditureReceiptRepository.GetByExpenditureUUID(_tx, uuid)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure receipt GetByExpenditureUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	// receipt rows are deleted together by foreign key cascade
+	switch err = eu.expenditureRepository.Delete(_tx, uuid); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("expenditure with that uuid is already deleted")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = eu.elasticSearch.Delete(ctx, esIndex, uuid); err != nil {
+		err = errors.Wrap(err, "Expenditure Delete return unexpected elasticSearch error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+
+	// receipt images are deleted after commit, failure of it only leave unused object in s3
+	for _, r := range receipts {
+		if _, dErr := eu.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(eu.myCfg.ReceiptS3Bucket()),
+			Key:    r.ReceiptUri,
+		}); dErr != nil {
+			log.Println(errors.Wrap(dErr, "s3 DeleteObject return unexpected error").Error())
+		}
+	}
+	return
+}
+
+// GetExpenditureReceipts implement GetExpenditureReceipts method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) GetExpenditureReceipts(
+	ctx context.Context,
+	parentUUID, uuid string,
+) (receipts []domain.ExpenditureReceipt, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnExpenditure(_tx, parentUUID, uuid); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if receipts, err = eu.expenditureReceiptRepository.GetByExpenditureUUID(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "expenditure receipt GetByExpenditureUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	err = eu.setReceiptDownloadUrls(receipts)
+	return
+}
+
+// DeleteExpenditureReceipt implement DeleteExpenditureReceipt method of domain.ExpenditureUsecase interface
+func (eu *expenditureUsecase) DeleteExpenditureReceipt(ctx context.Context, parentUUID, uuid, receiptUUID string) (err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnExpenditure(_tx, parentUUID, uuid); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	r, err := eu.expenditureReceiptRepository.GetByUUID(_tx, receiptUUID)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("receipt with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure receipt GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if domain.StringValue(r.ExpenditureUUID) != uuid {
+		err = errors.New("receipt with that uuid is not attached to that expenditure")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = eu.expenditureReceiptRepository.Delete(_tx, receiptUUID); err != nil {
+		err = errors.Wrap(err, "expenditure receipt Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if _, err = eu.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(eu.myCfg.ReceiptS3Bucket()),
+		Key:    r.ReceiptUri,
+	}); err != nil {
+		err = errors.Wrap(err, "s3 DeleteObject return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// storeReceipts method store receipt images attached to expenditure with expenditureUUID & put them to s3
+// expenditure must be owned by parent with parentUUID (parent in token), checked before anything is put to s3
+// total count of receipts attached to one expenditure can't be over domain.MaxExpenditureReceiptCount
+func (eu *expenditureUsecase) storeReceipts(_tx tx.Context, parentUUID, expenditureUUID string, receipts [][]byte) (err error) {
+	if len(receipts) == 0 {
+		return
+	}
+
+	if _, err = eu.getOwnExpenditure(_tx, parentUUID, expenditureUUID); err != nil {
+		return
+	}
+
+	attached, err := eu.expenditureReceiptRepository.GetByExpenditureUUID(_tx, expenditureUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure receipt GetByExpenditureUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	if len(attached)+len(receipts) > domain.MaxExpenditureReceiptCount {
+		err = errors.Errorf("expenditure can have up to %d receipts", domain.MaxExpenditureReceiptCount)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	now := time.Now()
+	for _, receipt := range receipts {
+		r := &domain.ExpenditureReceipt{
+			ExpenditureUUID: domain.String(expenditureUUID),
+			CreatedAt:       domain.Time(now),
+		}
+		if err = eu.expenditureReceiptRepository.Store(_tx, r); err != nil {
+			err = errors.Wrap(err, "expenditure receipt Store return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			return
+		}
+
+		if _, err = eu.s3Agency.PutObject(&s3.PutObjectInput{
+			Bucket: aws.String(eu.myCfg.ReceiptS3Bucket()),
+			Key:    r.ReceiptUri,
+			Body:   bytes.NewReader(receipt),
+		}); err != nil {
+			err = errors.Wrap(err, "s3 PutObject return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			return
+		}
+	}
+	return
+}
+
+// setReceiptDownloadUrls method set presigned download url to each receipt
+func (eu *expenditureUsecase) setReceiptDownloadUrls(receipts []domain.ExpenditureReceipt) (err error) {
+	for i := range receipts {
+		if receipts[i].DownloadUrl, err = eu.s3Agency.PresignGetObject(&s3.GetObjectInput{
+			Bucket: aws.String(eu.myCfg.ReceiptS3Bucket()),
+			Key:    receipts[i].ReceiptUri,
+		}, eu.myCfg.DownloadLinkDuration()); err != nil {
+			err = errors.Wrap(err, "s3 PresignGetObject return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			return
+		}
+	}
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (eu *expenditureUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := eu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, eu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
+
+// getChildrenName method return name of children with uuid, empty if children is not exist or not owned by parent
+func (eu *expenditureUsecase) getChildrenName(_tx tx.Context, parentUUID, uuid string) (name string, err error) {
+	switch c, gErr := eu.childrenRepository.GetByUUID(_tx, uuid); gErr.(type) {
+	case nil:
+		if domain.StringValue(c.ParentUUID) == parentUUID {
+			name = domain.StringValue(c.Name)
+		}
+	case domain.ErrRowNotExist:
+		break
+	default:
+		err = errors.Wrap(gErr, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// checkOwnChildren method return usecase error if children with uuid is not exist or not owned by parent
+func (eu *expenditureUsecase) checkOwnChildren(_tx tx.Context, parentUUID, uuid string) (err error) {
+	c, err := eu.childrenRepository.GetByUUID(_tx, uuid)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
+
+// getOwnExpenditure method return expenditure with uuid if parent with parentUUID own that expenditure
+func (eu *expenditureUsecase) getOwnExpenditure(_tx tx.Context, parentUUID, uuid string) (e domain.Expenditure, err error) {
+	switch e, err = eu.expenditureRepository.GetByUUID(_tx, uuid); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("expenditure with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "expenditure GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(e.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that expenditure")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
+
+// setBabyUUIDs method set uuid of tagged babies to each expenditure
+func (eu *expenditureUsecase) setBabyUUIDs(_tx tx.Context, expenditures []domain.Expenditure) (err error) {
+	uuids := make([]string, len(expenditures))
+	for i, e := range expenditures {
+		uuids[i] = domain.StringValue(e.UUID)
+	}
+
+	tags, err := eu.expenditureRepository.GetBabyTagsByExpenditureUUIDs(_tx, uuids)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure GetBabyTagsByExpenditureUUIDs return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	babyUUIDs := map[string][]string{}
+	for _, tag := range tags {
+		babyUUIDs[domain.StringValue(tag.ExpenditureUUID)] = append(babyUUIDs[domain.StringValue(tag.ExpenditureUUID)], domain.StringValue(tag.BabyUUID))
+	}
+	for i := range expenditures {
+		expenditures[i].BabyUUIDs = babyUUIDs[domain.StringValue(expenditures[i].UUID)]
+		if expenditures[i].BabyUUIDs == nil {
+			expenditures[i].BabyUUIDs = []string{}
+		}
+	}
+	return
+}
+
+// validateFilter function return usecase error if condition of filter is not valid
+func validateFilter(filter domain.ExpenditureFilter) (err error) {
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		err = errors.New("min amount must be less than or equal to max amount")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+	if filter.SpentFrom != nil && filter.SpentTo != nil && !filter.SpentFrom.Before(*filter.SpentTo) {
+		err = errors.New("spent from must be before spent to")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+	}
+	return
+}
+
+// setCategoryUUIDs function set CategoryUUID of filter with uuid of its subcategories to CategoryUUIDs
+func setCategoryUUIDs(categories domain.ExpenditureCategories, filter *domain.ExpenditureFilter) (err error) {
+	if _, ok := categories.Find(filter.CategoryUUID); !ok {
+		err = errors.New("category with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	}
+	filter.CategoryUUIDs = categories.WithSubcategories(filter.CategoryUUID)
+	return
 }
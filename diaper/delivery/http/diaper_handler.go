@@ -0,0 +1,150 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// diaperHandler represent the http handler for diaper
+type diaperHandler struct {
+	dUsecase   domain.DiaperUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewDiaperHandler will initialize the diaper resources endpoint
+func NewDiaperHandler(r *gin.Engine, du domain.DiaperUsecase, v validator, jh jwtHandler) {
+	h := &diaperHandler{
+		dUsecase:   du,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/diapers", h.jwtHandler.ParseUUIDFromToken, h.CreateDiaper)
+	r.GET("children/uuid/:children_uuid/diapers", h.jwtHandler.ParseUUIDFromToken, h.GetDiapersByDate)
+	r.GET("children/uuid/:children_uuid/diapers/summary", h.jwtHandler.ParseUUIDFromToken, h.GetDiaperSummary)
+}
+
+// CreateDiaper deliver data to CreateDiaper of domain.DiaperUsecase
+func (dh *diaperHandler) CreateDiaper(c *gin.Context) {
+	req := new(createDiaperRequest)
+	if err := dh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	d := &domain.Diaper{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		Kind:         domain.String(req.Kind),
+	}
+	if req.Color != "" {
+		d.Color = domain.String(req.Color)
+	}
+	if req.Consistency != "" {
+		d.Consistency = domain.String(req.Consistency)
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.ChangedAt); err != nil {
+		err = errors.Wrap(err, "failed to parse changed_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		d.ChangedAt = domain.Time(t)
+	}
+
+	switch uuid, err := dh.dUsecase.CreateDiaper(c.Request.Context(), c.GetString("uuid"), d); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new diaper")
+		resp["diaper_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateDiaper return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetDiapersByDate deliver data to GetDiapersByDate of domain.DiaperUsecase
+func (dh *diaperHandler) GetDiapersByDate(c *gin.Context) {
+	req := new(getDiapersByDateRequest)
+	if err := dh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	diapers, err := dh.dUsecase.GetDiapersByDate(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get diapers by date")
+		resp["diapers"] = diapers
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetDiapersByDate return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetDiaperSummary deliver data to GetDiaperSummary of domain.DiaperUsecase
+func (dh *diaperHandler) GetDiaperSummary(c *gin.Context) {
+	req := new(getDiaperSummaryRequest)
+	if err := dh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	days, err := dh.dUsecase.GetDiaperSummary(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.StartDate, req.EndDate)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get diaper summary")
+		resp["days"] = days
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetDiaperSummary return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (dh *diaperHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := dh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createDiaperRequest is request for diaperHandler.CreateDiaper
+type createDiaperRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Kind         string `json:"kind" validate:"required,oneof=wet dirty both"`
+	ChangedAt    string `json:"changed_at" validate:"required,max=30"`
+	Color        string `json:"color" validate:"omitempty,oneof=yellow green brown black red white"`
+	Consistency  string `json:"consistency" validate:"omitempty,oneof=watery loose soft formed hard mucus"`
+}
+
+func (r *createDiaperRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getDiapersByDateRequest is request for diaperHandler.GetDiapersByDate
+type getDiapersByDateRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+}
+
+func (r *getDiapersByDateRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// getDiaperSummaryRequest is request for diaperHandler.GetDiaperSummary
+type getDiaperSummaryRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	StartDate    string `form:"start_date" validate:"required,len=10"`
+	EndDate      string `form:"end_date" validate:"required,len=10"`
+}
+
+func (r *getDiaperSummaryRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
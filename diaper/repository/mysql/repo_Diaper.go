@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// diaperRepository is implementation of domain.DiaperRepository using mysql
+type diaperRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// DiaperRepository return implementation of domain.DiaperRepository using mysql
+func DiaperRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.DiaperRepository {
+	repo := &diaperRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Diaper{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate diaper model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.DiaperRepository interface
+func (dr *diaperRepository) Store(ctx tx.Context, d *domain.Diaper) (err error) {
+	if domain.StringValue(d.UUID) == "" {
+		if d.UUID, err = dr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = dr.validator.ValidateStruct(d); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Diaper")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("diaper").
+		Columns("uuid", "children_uuid", "kind", "changed_at", "color", "consistency").
+		Values(d.UUID, d.ChildrenUUID, d.Kind, d.ChangedAt, d.Color, d.Consistency).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert diaper")
+			fk := dr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert diaper return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert diaper return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.DiaperRepository interface
+func (dr *diaperRepository) GetByUUID(ctx tx.Context, uuid string) (d domain.Diaper, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("diaper").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&d, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select diaper")}
+	default:
+		err = errors.Wrap(err, "select diaper return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.DiaperRepository interface
+func (dr *diaperRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (diapers []domain.Diaper, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("diaper").
+		Where("children_uuid = ?", childrenUUID).
+		Where("changed_at >= ? AND changed_at < ?", from, to).
+		OrderBy("changed_at").ToSql()
+
+	diapers = []domain.Diaper{}
+	if err = _tx.Select(&diapers, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select diapers return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of diaper table
+func (dr *diaperRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	d := new(domain.Diaper)
+
+	for {
+		uuid := d.GenerateRandomUUID()
+		_, err := dr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
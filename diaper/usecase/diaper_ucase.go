@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxSummaryDays is max count of days that diaper summary can be requested at once
+const maxSummaryDays = 31
+
+// diaperUsecase is used for usecase layer which implement domain.DiaperUsecase interface
+type diaperUsecase struct {
+	// diaperRepository is repository interface about domain.Diaper model
+	diaperRepository domain.DiaperRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// DiaperUsecase return implementation of domain.DiaperUsecase
+func DiaperUsecase(
+	dr domain.DiaperRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.DiaperUsecase {
+	return &diaperUsecase{
+		diaperRepository:   dr,
+		childrenRepository: cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreateDiaper implement CreateDiaper method of domain.DiaperUsecase interface
+func (du *diaperUsecase) CreateDiaper(ctx context.Context, parentUUID string, d *domain.Diaper) (uuid string, err error) {
+	_tx, err := du.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = du.getOwnChildren(_tx, parentUUID, domain.StringValue(d.ChildrenUUID)); err != nil {
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = du.diaperRepository.Store(_tx, d); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "diaper Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = du.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "diaper Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(d.UUID)
+	_ = du.txHandler.Commit(_tx)
+	return
+}
+
+// GetDiapersByDate implement GetDiapersByDate method of domain.DiaperUsecase interface
+func (du *diaperUsecase) GetDiapersByDate(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (diapers []domain.Diaper, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := du.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = du.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	if diapers, err = du.diaperRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to); err != nil {
+		err = errors.Wrap(err, "diaper GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = du.txHandler.Commit(_tx)
+	return
+}
+
+// GetDiaperSummary implement GetDiaperSummary method of domain.DiaperUsecase interface
+func (du *diaperUsecase) GetDiaperSummary(
+	ctx context.Context,
+	parentUUID, childrenUUID, startDate, endDate string,
+) (days []domain.DiaperDailySummary, err error) {
+	from, _, err := domain.DayRange(startDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse start date"), Status: http.StatusBadRequest}
+		return
+	}
+	_, to, err := domain.DayRange(endDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse end date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	n := int(to.Sub(from).Hours() / 24)
+	if n <= 0 || n > maxSummaryDays {
+		err = errors.Errorf("summary range must be 1 ~ %d days", maxSummaryDays)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := du.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := du.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	diapers, err := du.diaperRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "diaper GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = du.txHandler.Rollback(_tx)
+		return
+	}
+
+	now := time.Now()
+	days = make([]domain.DiaperDailySummary, n)
+	for i := range days {
+		dayFrom := from.AddDate(0, 0, i)
+		days[i] = domain.NewDiaperDailySummary(dayFrom.Format("2006-01-02"), dayFrom, dayFrom.AddDate(0, 0, 1), domain.TimeValue(c.Birth), now, diapers)
+	}
+
+	_ = du.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (du *diaperUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = du.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// ActiveSessionUsecase is interface about usecase layer using in delivery layer
+type ActiveSessionUsecase interface {
+	// StartActiveSession method start timer session of children, only one session per children & type can be active
+	StartActiveSession(ctx context.Context, parentUUID string, as *ActiveSession) (uuid string, err error)
+
+	// GetActiveSessions method return active sessions of children with durations settled at now
+	GetActiveSessions(ctx context.Context, parentUUID, childrenUUID string) (sessions []ActiveSession, err error)
+
+	// PauseActiveSession method pause running timer of session
+	PauseActiveSession(ctx context.Context, parentUUID, childrenUUID, sessionUUID string) (as ActiveSession, err error)
+
+	// ResumeActiveSession method resume paused timer of session
+	ResumeActiveSession(ctx context.Context, parentUUID, childrenUUID, sessionUUID string) (as ActiveSession, err error)
+
+	// SwitchActiveSessionSide method switch breast side of feeding session
+	SwitchActiveSessionSide(ctx context.Context, parentUUID, childrenUUID, sessionUUID string) (as ActiveSession, err error)
+
+	// StopActiveSession method stop session & store it as feeding or sleep record
+	// sleepType is used only for sleep session, and recordUUID is uuid of stored record
+	StopActiveSession(ctx context.Context, parentUUID, childrenUUID, sessionUUID, sleepType string) (recordType, recordUUID string, err error)
+}
+
+// ActiveSessionRepository is repository interface about ActiveSession model
+type ActiveSessionRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (ActiveSession, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]ActiveSession, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, as *ActiveSession) error
+	Update(ctx tx.Context, as *ActiveSession) error
+	Delete(ctx tx.Context, uuid string) error
+}
+
+// session type value of ActiveSession
+const (
+	ActiveSessionFeeding = "feeding"
+	ActiveSessionSleep   = "sleep"
+)
+
+// state value of ActiveSession
+const (
+	ActiveSessionRunning = "running"
+	ActiveSessionPaused  = "paused"
+)
+
+// ActiveSession is model represent live timer session of children shared between parents' devices
+// running time is settled into ElapsedSeconds (and seconds of current side) at pause, switch side & stop
+// SegmentStartedAt is start of current running segment, and nil while paused
+type ActiveSession struct {
+	UUID             *string    `db:"uuid" json:"uuid" validate:"required,uuid=active_session"`
+	ChildrenUUID     *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	SessionType      *string    `db:"session_type" json:"session_type" validate:"required,oneof=feeding sleep"`
+	State            *string    `db:"state" json:"state" validate:"required,oneof=running paused"`
+	StartedAt        *time.Time `db:"started_at" json:"started_at" validate:"required"`
+	SegmentStartedAt *time.Time `db:"segment_started_at" json:"segment_started_at,omitempty"`
+	Side             *string    `db:"side" json:"side,omitempty" validate:"omitempty,oneof=left right"`
+	ElapsedSeconds   *int64     `db:"elapsed_seconds" json:"elapsed_seconds" validate:"range=0~604800"`
+	LeftSeconds      *int64     `db:"left_seconds" json:"left_seconds" validate:"range=0~604800"`
+	RightSeconds     *int64     `db:"right_seconds" json:"right_seconds" validate:"range=0~604800"`
+}
+
+// TableName return table name about ActiveSession model
+func (_ ActiveSession) TableName() string {
+	return "active_session"
+}
+
+// Schema return rdbms schema about ActiveSession model
+func (_ ActiveSession) Schema() string {
+	return `CREATE TABLE active_session (
+		uuid               CHAR(11)    NOT NULL,
+		children_uuid      CHAR(11)    NOT NULL,
+		session_type       VARCHAR(10) NOT NULL,
+		state              VARCHAR(10) NOT NULL,
+		started_at         DATETIME    NOT NULL,
+		segment_started_at DATETIME,
+		side               VARCHAR(10),
+		elapsed_seconds    INT(6)      NOT NULL,
+		left_seconds       INT(6)      NOT NULL,
+		right_seconds      INT(6)      NOT NULL,
+		PRIMARY KEY (uuid),
+		UNIQUE (children_uuid, session_type),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (as ActiveSession) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("l%s", string(random))
+}
+
+// Settle method add time of current running segment until now to elapsed seconds & seconds of current side
+// segment restart from now if session is running
+func (as *ActiveSession) Settle(now time.Time) {
+	if StringValue(as.State) != ActiveSessionRunning || as.SegmentStartedAt == nil {
+		return
+	}
+
+	sec := int64(now.Sub(*as.SegmentStartedAt).Seconds())
+	if sec < 0 {
+		sec = 0
+	}
+	as.ElapsedSeconds = Int64(Int64Value(as.ElapsedSeconds) + sec)
+	switch StringValue(as.Side) {
+	case BreastSideLeft:
+		as.LeftSeconds = Int64(Int64Value(as.LeftSeconds) + sec)
+	case BreastSideRight:
+		as.RightSeconds = Int64(Int64Value(as.RightSeconds) + sec)
+	}
+	as.SegmentStartedAt = Time(now)
+}
+
+// Pause method settle running time & pause session, return false if session is not running
+func (as *ActiveSession) Pause(now time.Time) bool {
+	if StringValue(as.State) != ActiveSessionRunning {
+		return false
+	}
+	as.Settle(now)
+	as.State, as.SegmentStartedAt = String(ActiveSessionPaused), nil
+	return true
+}
+
+// Resume method resume paused session from now, return false if session is not paused
+func (as *ActiveSession) Resume(now time.Time) bool {
+	if StringValue(as.State) != ActiveSessionPaused {
+		return false
+	}
+	as.State, as.SegmentStartedAt = String(ActiveSessionRunning), Time(now)
+	return true
+}
+
+// SwitchSide method settle running time & switch breast side, return false if session is not feeding
+func (as *ActiveSession) SwitchSide(now time.Time) bool {
+	if StringValue(as.SessionType) != ActiveSessionFeeding {
+		return false
+	}
+	as.Settle(now)
+	if StringValue(as.Side) == BreastSideLeft {
+		as.Side = String(BreastSideRight)
+	} else {
+		as.Side = String(BreastSideLeft)
+	}
+	return true
+}
+
+// ToFeeding method return breast feeding record of session settled at now
+// duration is rounded to minutes, and side is both if both sides were used
+func (as ActiveSession) ToFeeding(now time.Time) Feeding {
+	as.Settle(now)
+
+	side := StringValue(as.Side)
+	if Int64Value(as.LeftSeconds) > 0 && Int64Value(as.RightSeconds) > 0 {
+		side = BreastSideBoth
+	}
+	return Feeding{
+		ChildrenUUID: as.ChildrenUUID,
+		FeedingType:  String(FeedingTypeBreast),
+		FedAt:        as.StartedAt,
+		BreastSide:   String(side),
+		Duration:     Int64((Int64Value(as.ElapsedSeconds) + 30) / 60),
+	}
+}
+
+// ToSleep method return sleep record of session from start until now
+// paused time is included because sleep record keep only start & end time
+func (as ActiveSession) ToSleep(now time.Time, sleepType string) Sleep {
+	return Sleep{
+		ChildrenUUID: as.ChildrenUUID,
+		SleepType:    String(sleepType),
+		StartedAt:    as.StartedAt,
+		EndedAt:      Time(now),
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// AlbumUsecase is interface about usecase layer using in delivery layer
+type AlbumUsecase interface {
+	// UploadAlbumMedia method stream media in body to s3 & store metadata of media
+	UploadAlbumMedia(ctx context.Context, parentUUID string, am *AlbumMedia, body io.Reader) (uuid string, err error)
+
+	// GetAlbumMedias method return medias of children ordered by taken at (latest first)
+	// medias are filtered by date if date is not empty, and next page starts after media with cursor uuid
+	GetAlbumMedias(ctx context.Context, parentUUID, childrenUUID, date, cursor string, limit int) (medias []AlbumMedia, nextCursor string, err error)
+
+	// DeleteAlbumMedia method delete media of children from s3 & metadata
+	DeleteAlbumMedia(ctx context.Context, parentUUID, childrenUUID, mediaUUID string) (err error)
+}
+
+// AlbumMediaRepository is repository interface about AlbumMedia model
+type AlbumMediaRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (AlbumMedia, error)
+	GetByChildrenUUIDPaged(ctx tx.Context, childrenUUID string, from, to time.Time, cursor *AlbumMedia, limit uint64) ([]AlbumMedia, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, am *AlbumMedia) error
+	Delete(ctx tx.Context, uuid string) error
+}
+
+// media type value of AlbumMedia
+const (
+	AlbumMediaTypePhoto = "photo"
+	AlbumMediaTypeVideo = "video"
+)
+
+// AlbumMedia is model represent photo or video in album of children using in album domain
+// TakenAt is read from exif of photo, or set to upload time if not exist
+// media is private in s3, so DownloadUrl is presigned url set when media is returned
+type AlbumMedia struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=album"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	UploaderUUID *string    `db:"uploader_uuid" json:"uploader_uuid" validate:"required,uuid=parent"`
+	MediaType    *string    `db:"media_type" json:"media_type" validate:"required,oneof=photo video"`
+	ContentType  *string    `db:"content_type" json:"content_type" validate:"required,max=50"`
+	MediaUri     *string    `db:"media_uri" json:"media_uri" validate:"required,max=100"`
+	Caption      *string    `db:"caption" json:"caption,omitempty" validate:"max=200"`
+	TakenAt      *time.Time `db:"taken_at" json:"taken_at" validate:"required"`
+	UploadedAt   *time.Time `db:"uploaded_at" json:"uploaded_at" validate:"required"`
+	DownloadUrl  string     `db:"-" json:"download_url"`
+}
+
+// TableName return table name about AlbumMedia model
+func (_ AlbumMedia) TableName() string {
+	return "album_media"
+}
+
+// Schema return rdbms schema about AlbumMedia model
+func (_ AlbumMedia) Schema() string {
+	return `CREATE TABLE album_media (
+		uuid          CHAR(11)     NOT NULL,
+		children_uuid CHAR(11)     NOT NULL,
+		uploader_uuid CHAR(11)     NOT NULL,
+		media_type    VARCHAR(10)  NOT NULL,
+		content_type  VARCHAR(50)  NOT NULL,
+		media_uri     VARCHAR(100) NOT NULL,
+		caption       VARCHAR(200),
+		taken_at      DATETIME     NOT NULL,
+		uploaded_at   DATETIME     NOT NULL,
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, taken_at, uuid),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE,
+		FOREIGN KEY (uploader_uuid)
+			REFERENCES parent_auth (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (am AlbumMedia) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("a%s", string(random))
+}
+
+// GenerateMediaUri method return MediaUri value with field value
+func (am AlbumMedia) GenerateMediaUri() string {
+	return fmt.Sprintf("/albums/children/uuid/%s/%s", StringValue(am.ChildrenUUID), StringValue(am.UUID))
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/MyFirstBabyTime/Server/tx"
 	"math/rand"
@@ -9,21 +10,121 @@ import (
 )
 
 type ExpenditureUsecase interface {
-	ExpenditureRegistration(ctx context.Context, req *Expenditure, babyUUIDs []string) (err error)
+	// ExpenditureRegistration method store expenditure of parent with tagged babies & receipt images
+	// ParentUUID of req is always set to parentUUID, which must be uuid of parent in token
+	ExpenditureRegistration(ctx context.Context, parentUUID string, req *Expenditure, babyUUIDs []string, receipts [][]byte) (err error)
+
+	// GetExpenditure method return expenditure of parent with tagged baby uuids & receipts having download url
+	GetExpenditure(ctx context.Context, parentUUID, uuid string) (e Expenditure, err error)
+
+	// GetExpenditures method return expenditures of parent matched with filter & uuid of last one as next cursor
+	// nextCursor is empty if there is no more expenditure
+	GetExpenditures(ctx context.Context, parentUUID string, filter ExpenditureFilter) (expenditures []Expenditure, nextCursor string, err error)
+
+	// ExportExpenditures method write every expenditure of parent matched with filter to w, Cursor & Limit of filter are not used
+	ExportExpenditures(ctx context.Context, parentUUID string, filter ExpenditureFilter, w ExpenditureSheetWriter) (err error)
+
+	// UpdateExpenditure method update field of expenditure not nil in e, baby tags are replaced if babyUUIDs is not nil
+	// receipts are attached in addition to receipts already attached
+	UpdateExpenditure(ctx context.Context, parentUUID string, e *Expenditure, babyUUIDs []string, receipts [][]byte) (err error)
+
+	// DeleteExpenditure method delete expenditure of parent with baby tags & receipts
+	DeleteExpenditure(ctx context.Context, parentUUID, uuid string) (err error)
+
+	// GetExpenditureReceipts method return receipts attached to expenditure of parent
+	GetExpenditureReceipts(ctx context.Context, parentUUID, uuid string) (receipts []ExpenditureReceipt, err error)
+
+	// DeleteExpenditureReceipt method delete receipt attached to expenditure of parent
+	DeleteExpenditureReceipt(ctx context.Context, parentUUID, uuid, receiptUUID string) (err error)
 }
 
 type ExpenditureRepository interface {
 	Store(ctx tx.Context, e *Expenditure, babyUUIDs []string) (err error)
+	GetByUUID(ctx tx.Context, uuid string) (Expenditure, error)
+	GetByParentUUID(ctx tx.Context, parentUUID string, filter ExpenditureFilter) ([]Expenditure, error)
+	GetByBabyUUIDInRange(ctx tx.Context, parentUUID, babyUUID string, from, to time.Time) ([]Expenditure, error)
+	GetBabyTagsByExpenditureUUIDs(ctx tx.Context, expenditureUUIDs []string) ([]ExpenditureBabyTag, error)
+	Update(ctx tx.Context, e *Expenditure) error
+	ReplaceBabyTags(ctx tx.Context, expenditureUUID string, babyUUIDs []string) error
+	Delete(ctx tx.Context, uuid string) error
+	SumAmountByCategory(ctx tx.Context, parentUUID string, from, to time.Time) ([]ExpenditureCategorySum, error)
+}
+
+// ExpenditureSearchIndex is elasticsearch index that expenditure documents are stored in
+// index name must be lowercase in elasticsearch
+const ExpenditureSearchIndex = "expenditure"
+
+// ExpenditureSearchDocument function return search document of expenditure indexed in ExpenditureSearchIndex
+// Categories is uuid of category with its upper category, so that document is found by both of them
+func ExpenditureSearchDocument(req *Expenditure, babyUUIDS, categoryPath []string) (string, error) {
+	data := make(map[string]interface{})
+
+	data["UUID"] = req.UUID
+	data["ParentUUID"] = req.ParentUUID
+	data["Name"] = req.Name
+	data["Amount"] = req.Amount
+	data["Rating"] = req.Rating
+	data["Link"] = req.Link
+	data["CategoryUUID"] = req.CategoryUUID
+	data["Categories"] = categoryPath
+	data["SpentAt"] = req.SpentAt
+	data["CreatedAt"] = req.CreatedAt
+	data["UpdatedAt"] = req.UpdatedAt
+	data["baby"] = babyUUIDS
+
+	body, err := json.Marshal(data)
+
+	return string(body), err
+}
+
+// sort value of ExpenditureFilter ('-' prefix means descending order)
+const (
+	ExpenditureSortName        = "name"
+	ExpenditureSortNameDesc    = "-name"
+	ExpenditureSortAmount      = "amount"
+	ExpenditureSortAmountDesc  = "-amount"
+	ExpenditureSortRating      = "rating"
+	ExpenditureSortRatingDesc  = "-rating"
+	ExpenditureSortSpentAt     = "spent_at"
+	ExpenditureSortSpentAtDesc = "-spent_at"
+)
+
+// ExpenditureFilter is condition & page of expenditures listed by GetExpenditures
+// nil or empty field is not used as condition, and Cursor is uuid of last expenditure in previous page
+// SpentFrom is inclusive and SpentTo is exclusive bound of spent_at
+// CategoryUUIDs is set by usecase with CategoryUUID & uuid of its subcategories
+type ExpenditureFilter struct {
+	BabyUUID      string
+	CategoryUUID  string
+	CategoryUUIDs []string
+	MinAmount     *int64
+	MaxAmount     *int64
+	Rating        *int64
+	Name          string
+	SpentFrom     *time.Time
+	SpentTo       *time.Time
+	Sort          string
+	Cursor        string
+	Limit         int
 }
 
 // Expenditure is model represent expenditure using in child_expenditure domain
+// SpentAt is when money was spent, and CreatedAt & UpdatedAt are when expenditure was written
+// CategoryUUID is uuid of default or custom ExpenditureCategory, not referenced by FK as default one is not in table
+// BabyUUIDs is uuid of babies tagged in expenditure_baby_tag
 type Expenditure struct {
-	UUID       *string `db:"uuid" validate:"uuid=item"`
-	ParentUUID *string `db:"parent_uuid" validate:"required uuid=parent"`
-	Name       *string `db:"name" validate:"required"`
-	Amount     *int64  `db:"amount" validate:"required"`
-	Rating     *int64  `db:"rating" validate:"range=0~5"`
-	Link       *string `db:"link"`
+	UUID         *string              `db:"uuid" json:"uuid" validate:"uuid=item"`
+	ParentUUID   *string              `db:"parent_uuid" json:"parent_uuid" validate:"required,uuid=parent"`
+	Name         *string              `db:"name" json:"name" validate:"required,max=20"`
+	Amount       *int64               `db:"amount" json:"amount" validate:"required"`
+	Rating       *int64               `db:"rating" json:"rating" validate:"range=0~5"`
+	Link         *string              `db:"link" json:"link,omitempty" validate:"max=100"`
+	CategoryUUID *string              `db:"category_uuid" json:"category_uuid,omitempty" validate:"omitempty,uuid=expenditure_category"`
+	SpentAt      *time.Time           `db:"spent_at" json:"spent_at" validate:"required"`
+	CreatedAt    *time.Time           `db:"created_at" json:"created_at" validate:"required"`
+	UpdatedAt    *time.Time           `db:"updated_at" json:"updated_at" validate:"required"`
+	BabyUUIDs    []string             `db:"-" json:"baby_uuids"`
+	Receipts     []ExpenditureReceipt `db:"-" json:"receipts,omitempty"`
 }
 
 // TableName return table name about Expenditure model
@@ -39,13 +140,46 @@ func (_ Expenditure) Schema() string {
 		amount 		INT(15) 	NOT NULL,
 		rating 		INT(1) 		NOT NULL,
 		link 		VARCHAR(100),
+		category_uuid CHAR(11),
+		spent_at 	DATETIME 	NOT NULL,
+		created_at 	DATETIME 	NOT NULL,
+		updated_at 	DATETIME 	NOT NULL,
 		PRIMARY KEY (uuid),
+		INDEX (parent_uuid, spent_at),
+		INDEX (category_uuid),
 		FOREIGN KEY (parent_uuid)
 			REFERENCES parent_auth(uuid)
 			ON DELETE CASCADE
 	);`
 }
 
+// Migrations return column migrations of expenditure table created before expenditure had timestamps & category
+// existing rows are filled with migrated time, because time they were spent & created is unknown
+func (_ Expenditure) Migrations() []ColumnMigration {
+	return []ColumnMigration{{
+		Column: "spent_at",
+		Statements: []string{
+			"ALTER TABLE expenditure ADD COLUMN spent_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, ADD INDEX (parent_uuid, spent_at)",
+			"ALTER TABLE expenditure ALTER COLUMN spent_at DROP DEFAULT",
+		},
+	}, {
+		Column: "created_at",
+		Statements: []string{
+			"ALTER TABLE expenditure ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP",
+			"ALTER TABLE expenditure ALTER COLUMN created_at DROP DEFAULT",
+		},
+	}, {
+		Column: "updated_at",
+		Statements: []string{
+			"ALTER TABLE expenditure ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP",
+			"ALTER TABLE expenditure ALTER COLUMN updated_at DROP DEFAULT",
+		},
+	}, {
+		Column:     "category_uuid",
+		Statements: []string{"ALTER TABLE expenditure ADD COLUMN category_uuid CHAR(11) AFTER link, ADD INDEX (category_uuid)"},
+	}}
+}
+
 // GenerateRandomUUID method return random UUID value
 func (e Expenditure) GenerateRandomUUID() string {
 	rand.Seed(time.Now().UnixNano())
@@ -58,8 +192,8 @@ func (e Expenditure) GenerateRandomUUID() string {
 }
 
 type ExpenditureBabyTag struct {
-	ExpenditureUUID *string `db:"expenditure_uuid" validate:"required uuid=item"`
-	BabyUUID        *string `db:"baby_uuid" validate:"required uuid=baby"`
+	ExpenditureUUID *string `db:"expenditure_uuid" validate:"required,uuid=item"`
+	BabyUUID        *string `db:"baby_uuid" validate:"required,uuid=children"`
 }
 
 // TableName return table name about Expenditure model
@@ -12,34 +12,76 @@ import (
 // ChildrenUsecase is interface about usecase layer using in delivery layer
 type ChildrenUsecase interface {
 	CreateNewChildren(ctx context.Context, c *Children, profile []byte) (uuid string, err error)
+
+	// AddChildrenAllergy method add allergy to standing allergy list of children
+	AddChildrenAllergy(ctx context.Context, parentUUID string, ca *ChildrenAllergy) (err error)
+
+	// GetChildrenAllergies method return standing allergy list of children
+	GetChildrenAllergies(ctx context.Context, parentUUID, childrenUUID string) (allergies []ChildrenAllergy, err error)
+
+	// DeleteChildrenAllergy method delete allergy from standing allergy list of children
+	DeleteChildrenAllergy(ctx context.Context, parentUUID, childrenUUID, allergen string) (err error)
+
+	// GetPregnancyStatus method return due date & gestational age of unborn children
+	GetPregnancyStatus(ctx context.Context, parentUUID, childrenUUID string) (status PregnancyStatus, err error)
+
+	// RegisterChildrenBirth method change unborn children to born with real birth date & sex
+	// records stored before birth are kept with same children uuid
+	RegisterChildrenBirth(ctx context.Context, parentUUID, childrenUUID string, birth time.Time, sex string) (err error)
+
+	// CreatePrenatalCheckup method store prenatal checkup of unborn children
+	CreatePrenatalCheckup(ctx context.Context, parentUUID string, pc *PrenatalCheckup) (uuid string, err error)
+
+	// GetPrenatalCheckups method return prenatal checkups of children
+	GetPrenatalCheckups(ctx context.Context, parentUUID, childrenUUID string) (checkups []PrenatalCheckup, err error)
 }
 
 // ChildrenRepository is repository interface about Children model
 type ChildrenRepository interface {
 	GetByUUID(ctx tx.Context, uuid string) (children Children, err error)
+	GetByUUIDForUpdate(ctx tx.Context, uuid string) (children Children, err error)
 	GetAvailableUUID(ctx tx.Context) (*string, error)
 	Store(ctx tx.Context, c *Children) error
+	Update(ctx tx.Context, c *Children) error
+}
+
+// ChildrenAllergyRepository is repository interface about ChildrenAllergy model
+type ChildrenAllergyRepository interface {
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]ChildrenAllergy, error)
+	Store(ctx tx.Context, ca *ChildrenAllergy) error
+	Delete(ctx tx.Context, childrenUUID, allergen string) error
+}
+
+// PrenatalCheckupRepository is repository interface about PrenatalCheckup model
+type PrenatalCheckupRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (PrenatalCheckup, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]PrenatalCheckup, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, pc *PrenatalCheckup) error
 }
 
 // Children is model represent parent children using in children domain
+// unborn children has ExpectedBirth without Birth, and Sex is optional until birth
 type Children struct {
-	UUID       *string    `db:"uuid" validate:"required,uuid=children"`
-	ParentUUID *string    `db:"parent_uuid" validate:"required,uuid=parent"`
-	Name       *string    `db:"name" validate:"required,min=1,max=10"`
-	Birth      *time.Time `db:"birth" validate:"required"`
-	Sex        *string    `db:"sex" validate:"required,oneof=male female"`
-	ProfileUri *string    `db:"profile_uri"`
+	UUID          *string    `db:"uuid" validate:"required,uuid=children"`
+	ParentUUID    *string    `db:"parent_uuid" validate:"required,uuid=parent"`
+	Name          *string    `db:"name" validate:"required,min=1,max=10"`
+	Birth         *time.Time `db:"birth" validate:"required_without=ExpectedBirth"`
+	ExpectedBirth *time.Time `db:"expected_birth"`
+	Sex           *string    `db:"sex" validate:"required_with=Birth,omitempty,oneof=male female"`
+	ProfileUri    *string    `db:"profile_uri"`
 }
 
 // Schema return rdbms schema about Children model
 func (_ Children) Schema() string {
 	return `CREATE TABLE children (
-		uuid        CHAR(11) NOT NULL,
-		parent_uuid CHAR(11) NOT NULL,
-		name        VARCHAR(10) NOT NULL,
-		birth       DATETIME NOT NULL,
-		sex         VARCHAR(10) NOT NULL,
-		profile_uri VARCHAR(100),
+		uuid           CHAR(11) NOT NULL,
+		parent_uuid    CHAR(11) NOT NULL,
+		name           VARCHAR(10) NOT NULL,
+		birth          DATETIME,
+		expected_birth DATETIME,
+		sex            VARCHAR(10),
+		profile_uri    VARCHAR(100),
 		PRIMARY KEY (uuid),
 		FOREIGN KEY (parent_uuid)
 			REFERENCES parent_auth (uuid)
@@ -53,6 +95,22 @@ func (_ Children) TableName() string {
 	return "children"
 }
 
+// Migrations return column migrations of children table created before children could be unborn
+func (_ Children) Migrations() []ColumnMigration {
+	return []ColumnMigration{{
+		Column:     "birth",
+		Nullable:   true,
+		Statements: []string{"ALTER TABLE children MODIFY COLUMN birth DATETIME"},
+	}, {
+		Column:     "sex",
+		Nullable:   true,
+		Statements: []string{"ALTER TABLE children MODIFY COLUMN sex VARCHAR(10)"},
+	}, {
+		Column:     "expected_birth",
+		Statements: []string{"ALTER TABLE children ADD COLUMN expected_birth DATETIME AFTER birth"},
+	}}
+}
+
 // GenerateRandomUUID generate & return random uuid value
 func (c Children) GenerateRandomUUID() string {
 	rand.Seed(time.Now().UnixNano())
@@ -68,3 +126,116 @@ func (c Children) GenerateRandomUUID() string {
 func (c Children) GenerateProfileUri() string {
 	return fmt.Sprintf("/profiles/children/uuid/%s", StringValue(c.UUID))
 }
+
+// IsBorn method return if children is already born
+func (c Children) IsBorn() bool {
+	return c.Birth != nil
+}
+
+// pregnancyDays is count of days from first day of last menstrual period to due date (40 weeks)
+const pregnancyDays = 280
+
+// GestationalDays method return days of pregnancy at now calculated back from expected birth
+func (c Children) GestationalDays(now time.Time) int {
+	due := TimeValue(c.ExpectedBirth)
+	return pregnancyDays - int(due.Sub(now).Hours()/24)
+}
+
+// PregnancyStatus is due date & gestational age of unborn children
+type PregnancyStatus struct {
+	ExpectedBirth   time.Time `json:"expected_birth"`
+	GestationalWeek int       `json:"gestational_week"`
+	GestationalDay  int       `json:"gestational_day"`
+	DaysUntilDue    int       `json:"days_until_due"`
+}
+
+// NewPregnancyStatus function return PregnancyStatus of unborn children at now
+func NewPregnancyStatus(c Children, now time.Time) PregnancyStatus {
+	days := c.GestationalDays(now)
+	return PregnancyStatus{
+		ExpectedBirth:   TimeValue(c.ExpectedBirth),
+		GestationalWeek: days / 7,
+		GestationalDay:  days % 7,
+		DaysUntilDue:    pregnancyDays - days,
+	}
+}
+
+// ChildrenAllergy is model represent standing allergy of children
+// Allergen is code in AllergenCatalog or free text for allergen not in catalog
+type ChildrenAllergy struct {
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	Allergen     *string    `db:"allergen" json:"allergen" validate:"required,min=1,max=30"`
+	Severity     *string    `db:"severity" json:"severity" validate:"required,oneof=mild moderate severe"`
+	Note         *string    `db:"note" json:"note,omitempty" validate:"max=200"`
+	CreatedAt    *time.Time `db:"created_at" json:"created_at" validate:"required"`
+}
+
+// TableName return table name about ChildrenAllergy model
+func (_ ChildrenAllergy) TableName() string {
+	return "children_allergy"
+}
+
+// Schema return rdbms schema about ChildrenAllergy model
+func (_ ChildrenAllergy) Schema() string {
+	return `CREATE TABLE children_allergy (
+		children_uuid CHAR(11)     NOT NULL,
+		allergen      VARCHAR(30)  NOT NULL,
+		severity      VARCHAR(10)  NOT NULL,
+		note          VARCHAR(200),
+		created_at    DATETIME     NOT NULL,
+		PRIMARY KEY (children_uuid, allergen),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// PrenatalCheckup is model represent prenatal checkup of unborn children
+// GestationalWeek is calculated from expected birth of children at CheckedAt
+type PrenatalCheckup struct {
+	UUID            *string    `db:"uuid" json:"uuid" validate:"required,uuid=prenatal"`
+	ChildrenUUID    *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	CheckedAt       *time.Time `db:"checked_at" json:"checked_at" validate:"required"`
+	GestationalWeek *int64     `db:"gestational_week" json:"gestational_week" validate:"range=0~45"`
+	Hospital        *string    `db:"hospital" json:"hospital,omitempty" validate:"max=50"`
+	FetalWeight     *int64     `db:"fetal_weight" json:"fetal_weight,omitempty" validate:"range=0~6000"`
+	FetalHeartRate  *int64     `db:"fetal_heart_rate" json:"fetal_heart_rate,omitempty" validate:"range=0~250"`
+	Note            *string    `db:"note" json:"note,omitempty" validate:"max=500"`
+}
+
+// TableName return table name about PrenatalCheckup model
+func (_ PrenatalCheckup) TableName() string {
+	return "prenatal_checkup"
+}
+
+// Schema return rdbms schema about PrenatalCheckup model
+func (_ PrenatalCheckup) Schema() string {
+	return `CREATE TABLE prenatal_checkup (
+		uuid             CHAR(11)     NOT NULL,
+		children_uuid    CHAR(11)     NOT NULL,
+		checked_at       DATETIME     NOT NULL,
+		gestational_week INT(2)       NOT NULL,
+		hospital         VARCHAR(50),
+		fetal_weight     INT(4),
+		fetal_heart_rate INT(3),
+		note             VARCHAR(500),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, checked_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (pc PrenatalCheckup) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("n%s", string(random))
+}
@@ -15,4 +15,35 @@ const (
 	// use in authUsecase.LoginParentAuth
 	NotExistParentID  = -131
 	IncorrectParentPW = -132
+
+	// use in sleepUsecase.CreateSleep
+	SleepSessionOverlapped = -201
+
+	// use in milestoneUsecase.CreateMilestoneRecord
+	MilestoneAlreadyAchieved = -211
+
+	// use in childrenUsecase.AddChildrenAllergy
+	ChildrenAllergyAlreadyExist = -221
+
+	// use in childrenUsecase.RegisterChildrenBirth, CreatePrenatalCheckup & GetPregnancyStatus
+	ChildrenAlreadyBorn = -231
+
+	// use in usecase needing birth of children (ex. vaccinationUsecase, milestoneUsecase)
+	ChildrenNotBornYet = -232
+
+	// use in pumpingUsecase.ConsumeMilkBag
+	MilkBagAlreadyConsumed = -241
+	MilkBagExpired         = -242
+
+	// use in activeSessionUsecase.StartActiveSession
+	ActiveSessionAlreadyExist = -251
+
+	// use in activeSessionUsecase.PauseActiveSession, ResumeActiveSession & SwitchActiveSessionSide
+	ActiveSessionInvalidState = -252
+
+	// use in expenditureCategoryUsecase.CreateExpenditureCategory & UpdateExpenditureCategory
+	ExpenditureCategoryAlreadyExist = -261
+
+	// use in expenditureCategoryUsecase.DeleteExpenditureCategory
+	ExpenditureCategoryInUse = -262
 )
@@ -32,6 +32,20 @@ func Int64Value(v *int64) int64 {
 	return 0
 }
 
+// Float64 returns a pointer to the float64 value passed in.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Float64Value returns the value of the float64 pointer passed in or
+// 0 if the pointer is nil.
+func Float64Value(v *float64) float64 {
+	if v != nil {
+		return *v
+	}
+	return 0
+}
+
 // Bool returns a pointer to the bool value passed in.
 func Bool(v bool) *bool {
 	return &v
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ServiceLocation is location that date of request & summary is based on
+var ServiceLocation = time.FixedZone("KST", 9*60*60)
+
+// DayRange function return start & end time of the date string (ex. 2021-05-01) in ServiceLocation
+func DayRange(date string) (from, to time.Time, err error) {
+	if from, err = time.ParseInLocation("2006-01-02", date, ServiceLocation); err != nil {
+		return
+	}
+	to = from.AddDate(0, 0, 1)
+	return
+}
+
+// MonthRange function return start & end time of the month string (ex. 2021-05) in ServiceLocation
+func MonthRange(month string) (from, to time.Time, err error) {
+	if from, err = time.ParseInLocation("2006-01", month, ServiceLocation); err != nil {
+		return
+	}
+	to = from.AddDate(0, 1, 0)
+	return
+}
+
+// MonthOf function return month string (ex. 2021-05) of t in ServiceLocation
+func MonthOf(t time.Time) string {
+	return t.In(ServiceLocation).Format("2006-01")
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// DiaperUsecase is interface about usecase layer using in delivery layer
+type DiaperUsecase interface {
+	// CreateDiaper method store new diaper change of children
+	CreateDiaper(ctx context.Context, parentUUID string, d *Diaper) (uuid string, err error)
+
+	// GetDiapersByDate method return diaper changes of children in the date
+	GetDiapersByDate(ctx context.Context, parentUUID, childrenUUID, date string) (diapers []Diaper, err error)
+
+	// GetDiaperSummary method return daily diaper summaries of children from start date to end date
+	GetDiaperSummary(ctx context.Context, parentUUID, childrenUUID, startDate, endDate string) (days []DiaperDailySummary, err error)
+}
+
+// DiaperRepository is repository interface about Diaper model
+type DiaperRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Diaper, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]Diaper, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, d *Diaper) error
+}
+
+// kind value of Diaper
+const (
+	DiaperKindWet   = "wet"
+	DiaperKindDirty = "dirty"
+	DiaperKindBoth  = "both"
+)
+
+// Diaper is model represent diaper change of children using in diaper domain
+type Diaper struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=diaper"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	Kind         *string    `db:"kind" json:"kind" validate:"required,oneof=wet dirty both"`
+	ChangedAt    *time.Time `db:"changed_at" json:"changed_at" validate:"required"`
+	Color        *string    `db:"color" json:"color,omitempty" validate:"omitempty,oneof=yellow green brown black red white"`
+	Consistency  *string    `db:"consistency" json:"consistency,omitempty" validate:"omitempty,oneof=watery loose soft formed hard mucus"`
+}
+
+// TableName return table name about Diaper model
+func (_ Diaper) TableName() string {
+	return "diaper"
+}
+
+// Schema return rdbms schema about Diaper model
+func (_ Diaper) Schema() string {
+	return `CREATE TABLE diaper (
+		uuid          CHAR(11)    NOT NULL,
+		children_uuid CHAR(11)    NOT NULL,
+		kind          VARCHAR(10) NOT NULL,
+		changed_at    DATETIME    NOT NULL,
+		color         VARCHAR(10),
+		consistency   VARCHAR(10),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, changed_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (d Diaper) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("d%s", string(random))
+}
+
+// IsWet method return if diaper change is wet (wet or both)
+func (d Diaper) IsWet() bool {
+	return StringValue(d.Kind) == DiaperKindWet || StringValue(d.Kind) == DiaperKindBoth
+}
+
+// IsDirty method return if diaper change is dirty (dirty or both)
+func (d Diaper) IsDirty() bool {
+	return StringValue(d.Kind) == DiaperKindDirty || StringValue(d.Kind) == DiaperKindBoth
+}
+
+// WetDiaperThreshold function return minimum count of wet diaper per day expected at the age in days
+// newborn is expected to have one more wet diaper each day until 6 per day from 6th day of life
+func WetDiaperThreshold(ageDays int) int64 {
+	switch {
+	case ageDays < 0:
+		return 0
+	case ageDays < 5:
+		return int64(ageDays + 1)
+	case ageDays < 365:
+		return 6
+	default:
+		return 4
+	}
+}
+
+// DiaperDailySummary is diaper summary of one day
+type DiaperDailySummary struct {
+	Date         string `json:"date"`
+	TotalCount   int64  `json:"total_count"`
+	WetCount     int64  `json:"wet_count"`
+	DirtyCount   int64  `json:"dirty_count"`
+	WetThreshold int64  `json:"wet_threshold"`
+	Completed    bool   `json:"completed"`
+	LowWet       bool   `json:"low_wet"`
+}
+
+// NewDiaperDailySummary function return summary of diapers in from ~ to
+// LowWet is flagged only for day completed before now, with fewer wet diapers than threshold
+// day without any diaper logged is not flagged, because it means parent didn't log rather than baby didn't wet
+func NewDiaperDailySummary(date string, from, to, birth, now time.Time, diapers []Diaper) (summary DiaperDailySummary) {
+	summary.Date = date
+	for _, d := range diapers {
+		if t := TimeValue(d.ChangedAt); t.Before(from) || !t.Before(to) {
+			continue
+		}
+
+		summary.TotalCount++
+		if d.IsWet() {
+			summary.WetCount++
+		}
+		if d.IsDirty() {
+			summary.DirtyCount++
+		}
+	}
+
+	summary.WetThreshold = WetDiaperThreshold(int(from.Sub(birth).Hours() / 24))
+	summary.Completed = !to.After(now)
+	summary.LowWet = summary.Completed && summary.TotalCount > 0 && summary.WetCount < summary.WetThreshold
+	return
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mrand "math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// EmergencyCardUsecase is interface about usecase layer using in delivery layer
+type EmergencyCardUsecase interface {
+	// SetEmergencyProfile method store emergency profile of children, emergency contacts are replaced with contacts in profile
+	SetEmergencyProfile(ctx context.Context, parentUUID string, ep *EmergencyProfile) (err error)
+
+	// GetEmergencyCard method return emergency card of children built with profile, allergies & current medications
+	GetEmergencyCard(ctx context.Context, parentUUID, childrenUUID string) (card EmergencyCard, err error)
+
+	// CreateEmergencyShare method mint share token of emergency card expiring after ttl
+	CreateEmergencyShare(ctx context.Context, parentUUID, childrenUUID string, ttl time.Duration) (share EmergencyShare, err error)
+
+	// GetEmergencyShares method return share tokens of children not expired & not revoked
+	GetEmergencyShares(ctx context.Context, parentUUID, childrenUUID string) (shares []EmergencyShare, err error)
+
+	// RevokeEmergencyShare method revoke share token so that card can't be opened with it anymore
+	RevokeEmergencyShare(ctx context.Context, parentUUID, childrenUUID, token string) (err error)
+
+	// GetSharedEmergencyCard method return emergency card opened with share token without authentication
+	GetSharedEmergencyCard(ctx context.Context, token string) (card EmergencyCard, err error)
+}
+
+// EmergencyProfileRepository is repository interface about EmergencyProfile & EmergencyContact model
+type EmergencyProfileRepository interface {
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) (EmergencyProfile, error)
+	Upsert(ctx tx.Context, ep *EmergencyProfile) error
+
+	GetContactsByChildrenUUID(ctx tx.Context, childrenUUID string) ([]EmergencyContact, error)
+	GetContactByUUID(ctx tx.Context, uuid string) (EmergencyContact, error)
+	GetAvailableContactUUID(ctx tx.Context) (*string, error)
+	StoreContact(ctx tx.Context, ec *EmergencyContact) error
+	DeleteContactsByChildrenUUID(ctx tx.Context, childrenUUID string) error
+}
+
+// EmergencyShareRepository is repository interface about EmergencyShare model
+type EmergencyShareRepository interface {
+	GetByToken(ctx tx.Context, token string) (EmergencyShare, error)
+	GetAvailableByChildrenUUID(ctx tx.Context, childrenUUID string, now time.Time) ([]EmergencyShare, error)
+	Store(ctx tx.Context, es *EmergencyShare) error
+	Revoke(ctx tx.Context, token string, revokedAt time.Time) error
+}
+
+// EmergencyProfile is model represent emergency information of children using in emergency card domain
+// Contacts are stored in other table, ordered by priority
+type EmergencyProfile struct {
+	ChildrenUUID      *string            `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	BloodType         *string            `db:"blood_type" json:"blood_type,omitempty" validate:"omitempty,oneof=A+ A- B+ B- O+ O- AB+ AB-"`
+	ChronicConditions *string            `db:"chronic_conditions" json:"chronic_conditions,omitempty" validate:"max=500"`
+	ClinicName        *string            `db:"clinic_name" json:"clinic_name,omitempty" validate:"max=50"`
+	ClinicPhone       *string            `db:"clinic_phone" json:"clinic_phone,omitempty" validate:"max=20"`
+	Note              *string            `db:"note" json:"note,omitempty" validate:"max=500"`
+	UpdatedAt         *time.Time         `db:"updated_at" json:"updated_at" validate:"required"`
+	Contacts          []EmergencyContact `db:"-" json:"contacts"`
+}
+
+// TableName return table name about EmergencyProfile model
+func (_ EmergencyProfile) TableName() string {
+	return "emergency_profile"
+}
+
+// Schema return rdbms schema about EmergencyProfile model
+func (_ EmergencyProfile) Schema() string {
+	return `CREATE TABLE emergency_profile (
+		children_uuid      CHAR(11)     NOT NULL,
+		blood_type         VARCHAR(3),
+		chronic_conditions VARCHAR(500),
+		clinic_name        VARCHAR(50),
+		clinic_phone       VARCHAR(20),
+		note               VARCHAR(500),
+		updated_at         DATETIME     NOT NULL,
+		PRIMARY KEY (children_uuid),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// EmergencyContact is model represent person to contact in emergency of children
+// contact with lower Priority value should be called first
+type EmergencyContact struct {
+	UUID         *string `db:"uuid" json:"uuid" validate:"required,uuid=emergency_contact"`
+	ChildrenUUID *string `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	Name         *string `db:"name" json:"name" validate:"required,min=1,max=20"`
+	Relation     *string `db:"relation" json:"relation" validate:"required,min=1,max=20"`
+	PhoneNumber  *string `db:"phone_number" json:"phone_number" validate:"required,min=1,max=20"`
+	Priority     *int64  `db:"priority" json:"priority" validate:"range=1~10"`
+}
+
+// TableName return table name about EmergencyContact model
+func (_ EmergencyContact) TableName() string {
+	return "emergency_contact"
+}
+
+// Schema return rdbms schema about EmergencyContact model
+func (_ EmergencyContact) Schema() string {
+	return `CREATE TABLE emergency_contact (
+		uuid          CHAR(11)    NOT NULL,
+		children_uuid CHAR(11)    NOT NULL,
+		name          VARCHAR(20) NOT NULL,
+		relation      VARCHAR(20) NOT NULL,
+		phone_number  VARCHAR(20) NOT NULL,
+		priority      INT(2)      NOT NULL,
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, priority),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (ec EmergencyContact) GenerateRandomUUID() string {
+	mrand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[mrand.Intn(len(is))]
+	}
+	return fmt.Sprintf("o%s", string(random))
+}
+
+// EmergencyShare is model represent share token opening emergency card of children without authentication
+// RevokedAt is set when parent revoke token before it expire
+type EmergencyShare struct {
+	Token        *string    `db:"token" json:"token" validate:"required,len=64,hexadecimal"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	CreatedAt    *time.Time `db:"created_at" json:"created_at" validate:"required"`
+	ExpiresAt    *time.Time `db:"expires_at" json:"expires_at" validate:"required"`
+	RevokedAt    *time.Time `db:"revoked_at" json:"revoked_at,omitempty"`
+}
+
+// TableName return table name about EmergencyShare model
+func (_ EmergencyShare) TableName() string {
+	return "emergency_share"
+}
+
+// Schema return rdbms schema about EmergencyShare model
+func (_ EmergencyShare) Schema() string {
+	return `CREATE TABLE emergency_share (
+		token         CHAR(64) NOT NULL,
+		children_uuid CHAR(11) NOT NULL,
+		created_at    DATETIME NOT NULL,
+		expires_at    DATETIME NOT NULL,
+		revoked_at    DATETIME,
+		PRIMARY KEY (token),
+		INDEX (children_uuid, expires_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomToken generate & return random share token value
+// token is used as credential, so it is generated with crypto/rand unlike uuid of other models
+func (es EmergencyShare) GenerateRandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// IsAvailable method return if card can be opened with share token at now
+func (es EmergencyShare) IsAvailable(now time.Time) bool {
+	return es.RevokedAt == nil && now.Before(TimeValue(es.ExpiresAt))
+}
+
+// EmergencyCard is read-only emergency information of children shown to daycare staff or babysitter
+type EmergencyCard struct {
+	ChildrenName      string             `json:"children_name"`
+	Birth             *time.Time         `json:"birth,omitempty"`
+	Sex               string             `json:"sex,omitempty"`
+	BloodType         string             `json:"blood_type,omitempty"`
+	Allergies         []ChildrenAllergy  `json:"allergies"`
+	ChronicConditions string             `json:"chronic_conditions,omitempty"`
+	Medications       []Medication       `json:"medications"`
+	Contacts          []EmergencyContact `json:"contacts"`
+	ClinicName        string             `json:"clinic_name,omitempty"`
+	ClinicPhone       string             `json:"clinic_phone,omitempty"`
+	Note              string             `json:"note,omitempty"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+}
+
+// NewEmergencyCard function return EmergencyCard of children at now
+// only medications not ended before today are included as current medications
+func NewEmergencyCard(c Children, ep EmergencyProfile, allergies []ChildrenAllergy, medications []Medication, now time.Time) EmergencyCard {
+	card := EmergencyCard{
+		ChildrenName:      StringValue(c.Name),
+		Birth:             c.Birth,
+		Sex:               StringValue(c.Sex),
+		BloodType:         StringValue(ep.BloodType),
+		Allergies:         allergies,
+		ChronicConditions: StringValue(ep.ChronicConditions),
+		Medications:       []Medication{},
+		Contacts:          ep.Contacts,
+		ClinicName:        StringValue(ep.ClinicName),
+		ClinicPhone:       StringValue(ep.ClinicPhone),
+		Note:              StringValue(ep.Note),
+		GeneratedAt:       now,
+	}
+	if card.Allergies == nil {
+		card.Allergies = []ChildrenAllergy{}
+	}
+	if card.Contacts == nil {
+		card.Contacts = []EmergencyContact{}
+	}
+
+	y, m, d := now.In(ServiceLocation).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, ServiceLocation)
+	for _, medication := range medications {
+		if medication.StartDate != nil && medication.StartDate.After(now) {
+			continue
+		}
+		if medication.EndDate != nil && medication.EndDate.Before(today) {
+			continue
+		}
+		card.Medications = append(card.Medications, medication)
+	}
+	return card
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// ExpenditureBudgetUsecase is interface about usecase layer using in delivery layer
+type ExpenditureBudgetUsecase interface {
+	// SetExpenditureBudget method set monthly budget of parent for category (overall if CategoryUUID is nil)
+	// budget already set for same category is updated
+	SetExpenditureBudget(ctx context.Context, eb *ExpenditureBudget) (uuid string, err error)
+
+	// GetExpenditureBudgetStatuses method return spent & remaining amount of every budget of parent in month (ex. 2021-05)
+	GetExpenditureBudgetStatuses(ctx context.Context, parentUUID, month string) (statuses []ExpenditureBudgetStatus, err error)
+
+	// DeleteExpenditureBudget method delete budget of parent
+	DeleteExpenditureBudget(ctx context.Context, parentUUID, uuid string) (err error)
+}
+
+// ExpenditureBudgetRepository is repository interface about ExpenditureBudget model
+type ExpenditureBudgetRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (ExpenditureBudget, error)
+	GetByParentUUID(ctx tx.Context, parentUUID string) ([]ExpenditureBudget, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, eb *ExpenditureBudget) error
+	Update(ctx tx.Context, eb *ExpenditureBudget) error
+	Delete(ctx tx.Context, uuid string) error
+	DeleteByCategoryUUID(ctx tx.Context, categoryUUID string) error
+}
+
+// ExpenditureBudgetAlertRates is percentages of budget that parent is notified when monthly spend cross
+var ExpenditureBudgetAlertRates = []int64{80, 100}
+
+// ExpenditureBudget is model represent monthly budget of family using in childcare expenditure domain
+// budget without CategoryUUID is overall budget, and budget of upper category include its subcategories
+type ExpenditureBudget struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=expenditure_budget"`
+	ParentUUID   *string    `db:"parent_uuid" json:"parent_uuid" validate:"required,uuid=parent"`
+	CategoryUUID *string    `db:"category_uuid" json:"category_uuid,omitempty" validate:"omitempty,uuid=expenditure_category"`
+	Amount       *int64     `db:"amount" json:"amount" validate:"required,range=1~1000000000"`
+	CreatedAt    *time.Time `db:"created_at" json:"created_at" validate:"required"`
+	UpdatedAt    *time.Time `db:"updated_at" json:"updated_at" validate:"required"`
+}
+
+// TableName return table name about ExpenditureBudget model
+func (_ ExpenditureBudget) TableName() string {
+	return "expenditure_budget"
+}
+
+// Schema return schema about ExpenditureBudget model
+func (_ ExpenditureBudget) Schema() string {
+	return `CREATE TABLE expenditure_budget (
+		uuid          CHAR(11) NOT NULL,
+		parent_uuid   CHAR(11) NOT NULL,
+		category_uuid CHAR(11),
+		amount        INT(15)  NOT NULL,
+		created_at    DATETIME NOT NULL,
+		updated_at    DATETIME NOT NULL,
+		PRIMARY KEY (uuid),
+		FOREIGN KEY (parent_uuid)
+			REFERENCES parent_auth(uuid)
+			ON DELETE CASCADE
+	);`
+}
+
+// GenerateRandomUUID method return random UUID value
+func (_ ExpenditureBudget) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("u%s", string(random))
+}
+
+// ExpenditureCategorySum is sum of expenditure amount in one category (nil CategoryUUID means not categorized)
+type ExpenditureCategorySum struct {
+	CategoryUUID *string `db:"category_uuid" json:"category_uuid"`
+	Amount       int64   `db:"amount" json:"amount"`
+}
+
+// ExpenditureBudgetStatus is budget with amount spent in month & remaining amount (negative if overspent)
+type ExpenditureBudgetStatus struct {
+	BudgetUUID   string  `json:"budget_uuid"`
+	CategoryUUID *string `json:"category_uuid,omitempty"`
+	Month        string  `json:"month"`
+	Budget       int64   `json:"budget"`
+	Spent        int64   `json:"spent"`
+	Remaining    int64   `json:"remaining"`
+}
+
+// NewExpenditureBudgetStatus function return status of budget in month with sums of amount in month by category
+func NewExpenditureBudgetStatus(
+	eb ExpenditureBudget,
+	categories ExpenditureCategories,
+	sums []ExpenditureCategorySum,
+	month string,
+) (s ExpenditureBudgetStatus) {
+	s = ExpenditureBudgetStatus{
+		BudgetUUID:   StringValue(eb.UUID),
+		CategoryUUID: eb.CategoryUUID,
+		Month:        month,
+		Budget:       Int64Value(eb.Amount),
+	}
+
+	for _, sum := range sums {
+		if eb.Covers(categories, StringValue(sum.CategoryUUID)) {
+			s.Spent += sum.Amount
+		}
+	}
+	s.Remaining = s.Budget - s.Spent
+	return
+}
+
+// Covers method return if expenditure in category with categoryUUID is counted in budget
+func (eb ExpenditureBudget) Covers(categories ExpenditureCategories, categoryUUID string) bool {
+	if eb.CategoryUUID == nil {
+		return true
+	}
+	for _, uuid := range categories.WithSubcategories(*eb.CategoryUUID) {
+		if uuid == categoryUUID {
+			return true
+		}
+	}
+	return false
+}
+
+// CrossedAlertRate method return highest rate in ExpenditureBudgetAlertRates crossed by last added amount
+// crossed is false if no rate is crossed by added amount
+func (s ExpenditureBudgetStatus) CrossedAlertRate(added int64) (rate int64, crossed bool) {
+	if s.Budget <= 0 {
+		return
+	}
+	before := s.Spent - added
+	for _, r := range ExpenditureBudgetAlertRates {
+		limit := s.Budget * r / 100
+		if before < limit && s.Spent >= limit {
+			rate, crossed = r, true
+		}
+	}
+	return
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// ExpenditureCategoryUsecase is interface about usecase layer using in delivery layer
+type ExpenditureCategoryUsecase interface {
+	// GetExpenditureCategories method return default categories & custom categories of parent as tree
+	GetExpenditureCategories(ctx context.Context, parentUUID string) (categories []ExpenditureCategory, err error)
+
+	// CreateExpenditureCategory method create custom category of parent, under UpperUUID if it is set
+	CreateExpenditureCategory(ctx context.Context, ec *ExpenditureCategory) (uuid string, err error)
+
+	// UpdateExpenditureCategory method rename custom category of parent
+	UpdateExpenditureCategory(ctx context.Context, ec *ExpenditureCategory) (err error)
+
+	// DeleteExpenditureCategory method delete custom category of parent not having subcategory or expenditure
+	DeleteExpenditureCategory(ctx context.Context, parentUUID, uuid string) (err error)
+}
+
+// ExpenditureCategoryRepository is repository interface about ExpenditureCategory model
+// only custom categories are stored, default categories are loaded from catalog
+type ExpenditureCategoryRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (ExpenditureCategory, error)
+	GetByParentUUID(ctx tx.Context, parentUUID string) ([]ExpenditureCategory, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, ec *ExpenditureCategory) error
+	Update(ctx tx.Context, ec *ExpenditureCategory) error
+	Delete(ctx tx.Context, uuid string) error
+}
+
+// ExpenditureCategory is model represent category of expenditure using in childcare expenditure domain
+// default category has no ParentUUID, and category having UpperUUID is subcategory of that category
+// category is at most two level, so subcategory can't have subcategory
+type ExpenditureCategory struct {
+	UUID          *string               `db:"uuid" json:"uuid" validate:"required,uuid=expenditure_category"`
+	ParentUUID    *string               `db:"parent_uuid" json:"parent_uuid,omitempty" validate:"required,uuid=parent"`
+	UpperUUID     *string               `db:"upper_uuid" json:"upper_uuid,omitempty" validate:"omitempty,uuid=expenditure_category"`
+	Name          *string               `db:"name" json:"name" validate:"required,min=1,max=20"`
+	CreatedAt     *time.Time            `db:"created_at" json:"-" validate:"required"`
+	Subcategories []ExpenditureCategory `db:"-" json:"subcategories,omitempty"`
+}
+
+// TableName return table name about ExpenditureCategory model
+func (_ ExpenditureCategory) TableName() string {
+	return "expenditure_category"
+}
+
+// Schema return schema about ExpenditureCategory model
+func (_ ExpenditureCategory) Schema() string {
+	return `CREATE TABLE expenditure_category (
+		uuid        CHAR(11)    NOT NULL,
+		parent_uuid CHAR(11)    NOT NULL,
+		upper_uuid  CHAR(11),
+		name        VARCHAR(20) NOT NULL,
+		created_at  DATETIME    NOT NULL,
+		PRIMARY KEY (uuid),
+		FOREIGN KEY (parent_uuid)
+			REFERENCES parent_auth(uuid)
+			ON DELETE CASCADE
+	);`
+}
+
+// GenerateRandomUUID method return random UUID value
+// first digit is never 0, so that it doesn't collide with uuid of default category (x0...)
+func (_ ExpenditureCategory) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	random[0] = is[rand.Intn(len(is)-1)+1]
+	for i := 1; i < len(random); i++ {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("x%s", string(random))
+}
+
+// IsDefault method return if category is default category in catalog
+func (ec ExpenditureCategory) IsDefault() bool {
+	return ec.ParentUUID == nil
+}
+
+// ExpenditureCategories is categories usable by one family, default categories with custom categories of parent
+type ExpenditureCategories []ExpenditureCategory
+
+// Find method return category with uuid
+func (ecs ExpenditureCategories) Find(uuid string) (ExpenditureCategory, bool) {
+	for _, ec := range ecs {
+		if StringValue(ec.UUID) == uuid {
+			return ec, true
+		}
+	}
+	return ExpenditureCategory{}, false
+}
+
+// FindByName method return category named name under upperUUID (top level if upperUUID is empty)
+func (ecs ExpenditureCategories) FindByName(upperUUID, name string) (ExpenditureCategory, bool) {
+	for _, ec := range ecs {
+		if StringValue(ec.UpperUUID) == upperUUID && StringValue(ec.Name) == name {
+			return ec, true
+		}
+	}
+	return ExpenditureCategory{}, false
+}
+
+// WithSubcategories method return uuid of category with uuid of its subcategories
+func (ecs ExpenditureCategories) WithSubcategories(uuid string) (uuids []string) {
+	uuids = []string{uuid}
+	for _, ec := range ecs {
+		if StringValue(ec.UpperUUID) == uuid {
+			uuids = append(uuids, StringValue(ec.UUID))
+		}
+	}
+	return
+}
+
+// Path method return uuid of upper category (if exist) & category with uuid
+func (ecs ExpenditureCategories) Path(uuid string) (path []string) {
+	ec, ok := ecs.Find(uuid)
+	if !ok {
+		return []string{}
+	}
+	if ec.UpperUUID != nil {
+		path = append(path, *ec.UpperUUID)
+	}
+	return append(path, uuid)
+}
+
+// CategoryName method return name of category with uuid, joined with name of upper category (ex. 식비 > 분유)
+func (ecs ExpenditureCategories) CategoryName(uuid string) string {
+	names := make([]string, 0, 2)
+	for _, categoryUUID := range ecs.Path(uuid) {
+		ec, _ := ecs.Find(categoryUUID)
+		names = append(names, StringValue(ec.Name))
+	}
+	return strings.Join(names, " > ")
+}
+
+// Tree method return top level categories with Subcategories set, in order of categories
+func (ecs ExpenditureCategories) Tree() (tree []ExpenditureCategory) {
+	tree = []ExpenditureCategory{}
+	for _, ec := range ecs {
+		if ec.UpperUUID != nil {
+			continue
+		}
+		ec.Subcategories = []ExpenditureCategory{}
+		for _, sub := range ecs {
+			if StringValue(sub.UpperUUID) == StringValue(ec.UUID) {
+				ec.Subcategories = append(ec.Subcategories, sub)
+			}
+		}
+		tree = append(tree, ec)
+	}
+	return
+}
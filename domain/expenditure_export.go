@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// format of file that expenditures are exported as
+const (
+	ExpenditureExportFormatCSV  = "csv"
+	ExpenditureExportFormatXLSX = "xlsx"
+)
+
+// ExpenditureExportColumns is header row of exported expenditure sheet
+var ExpenditureExportColumns = []string{"날짜", "항목", "금액", "카테고리", "평점", "링크", "아이"}
+
+// ExpenditureExportRow is one row of exported expenditure sheet
+// Category is name of category with its upper category, and Children is name of tagged children
+type ExpenditureExportRow struct {
+	SpentAt  time.Time
+	Name     string
+	Amount   int64
+	Category string
+	Rating   int64
+	Link     string
+	Children []string
+}
+
+// Date method return spent date of row formatted in service location
+func (r ExpenditureExportRow) Date() string {
+	return r.SpentAt.In(ServiceLocation).Format("2006-01-02")
+}
+
+// ChildrenNames method return name of tagged children joined in one cell
+func (r ExpenditureExportRow) ChildrenNames() string {
+	return strings.Join(r.Children, ", ")
+}
+
+// ExpenditureSheetWriter is interface writing expenditure rows to spreadsheet file (csv, xlsx)
+// header row is written before first row, and file is completed by Close even if there is no row
+type ExpenditureSheetWriter interface {
+	// WriteRow method write one expenditure row to sheet
+	WriteRow(row ExpenditureExportRow) (err error)
+
+	// Close method flush rows written & complete file
+	Close() (err error)
+}
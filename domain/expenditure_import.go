@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExpenditureImportUsecase is interface about usecase layer using in delivery layer
+type ExpenditureImportUsecase interface {
+	// GetImportProfiles method return column mapping profiles of csv file that can be imported
+	GetImportProfiles(ctx context.Context) (profiles []ExpenditureImportProfile)
+
+	// PreviewImport method return rows parsed from csv file with profile, marking row duplicated with expenditure of parent
+	// nothing is stored, and rows to import are sent again to CommitImport
+	PreviewImport(ctx context.Context, parentUUID, profileCode string, file []byte) (rows []ExpenditureImportRow, err error)
+
+	// CommitImport method store every item in import as expenditure of parent in one transaction & return uuid of them
+	CommitImport(ctx context.Context, parentUUID string, ei ExpenditureImport) (uuids []string, err error)
+}
+
+// MaxExpenditureImportRows is max count of rows that can be imported at once
+const MaxExpenditureImportRows = 1000
+
+// encoding of csv file read with ExpenditureImportProfile
+const (
+	ExpenditureImportEncodingUTF8  = "utf-8"
+	ExpenditureImportEncodingEUCKR = "euc-kr"
+)
+
+// ExpenditureImportProfile is column mapping of csv file exported by card company or bank
+// row is found by name of column in header row, and TimeColumn is empty if time is in DateColumn or not exist
+// amount is spent if it is positive, or negative if NegativeAmount is true (bank having one signed amount column)
+// row having empty or zero amount is not expenditure (ex. deposit) and is skipped
+type ExpenditureImportProfile struct {
+	Code           string `json:"code"`
+	Name           string `json:"name"`
+	Encoding       string `json:"encoding"`
+	DateColumn     string `json:"date_column"`
+	TimeColumn     string `json:"time_column,omitempty"`
+	NameColumn     string `json:"name_column"`
+	AmountColumn   string `json:"amount_column"`
+	NegativeAmount bool   `json:"negative_amount"`
+}
+
+// ExpenditureImportProfiles is profiles of csv file that can be imported
+type ExpenditureImportProfiles []ExpenditureImportProfile
+
+// Find method return profile with code
+func (eips ExpenditureImportProfiles) Find(code string) (ExpenditureImportProfile, bool) {
+	for _, eip := range eips {
+		if eip.Code == code {
+			return eip, true
+		}
+	}
+	return ExpenditureImportProfile{}, false
+}
+
+// ExpenditureImportRow is one row parsed from csv file, Line is number of record in file (blank line is not counted)
+// Error is reason that row can't be imported, and Duplicate is true if same expenditure is already stored or in previous row
+type ExpenditureImportRow struct {
+	Line      int        `json:"line"`
+	SpentAt   *time.Time `json:"spent_at,omitempty"`
+	Name      string     `json:"name"`
+	Amount    int64      `json:"amount"`
+	Duplicate bool       `json:"duplicate"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// ExpenditureImport is expenditures to import, CategoryUUID & BabyUUIDs are set to every item
+type ExpenditureImport struct {
+	Items        []ExpenditureImportItem
+	CategoryUUID *string
+	BabyUUIDs    []string
+}
+
+// ExpenditureImportItem is one expenditure to import, selected from rows returned by PreviewImport
+type ExpenditureImportItem struct {
+	SpentAt time.Time
+	Name    string
+	Amount  int64
+}
+
+// ExpenditureDuplicateKey function return key that expenditures having same key are regarded as duplicated
+// expenditures spent in same day of service location with same name & amount are duplicated
+func ExpenditureDuplicateKey(spentAt time.Time, name string, amount int64) string {
+	return fmt.Sprintf("%s/%d/%s", spentAt.In(ServiceLocation).Format("2006-01-02"), amount, name)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// ExpenditureReceiptRepository is repository interface about ExpenditureReceipt model
+type ExpenditureReceiptRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (ExpenditureReceipt, error)
+	GetByExpenditureUUID(ctx tx.Context, expenditureUUID string) ([]ExpenditureReceipt, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, er *ExpenditureReceipt) error
+	Delete(ctx tx.Context, uuid string) error
+}
+
+// MaxExpenditureReceiptCount is max count of receipt images that can be attached to one expenditure
+const MaxExpenditureReceiptCount = 10
+
+// ExpenditureReceipt is model represent receipt image attached to Expenditure
+// image is stored in s3 with ReceiptUri as key, and deleted together when expenditure is deleted
+// image is private in s3, so DownloadUrl is presigned url set when receipt is returned
+type ExpenditureReceipt struct {
+	UUID            *string    `db:"uuid" json:"uuid" validate:"required,uuid=expenditure_receipt"`
+	ExpenditureUUID *string    `db:"expenditure_uuid" json:"expenditure_uuid" validate:"required,uuid=item"`
+	ReceiptUri      *string    `db:"receipt_uri" json:"receipt_uri" validate:"required,max=100"`
+	CreatedAt       *time.Time `db:"created_at" json:"created_at" validate:"required"`
+	DownloadUrl     string     `db:"-" json:"download_url"`
+}
+
+// TableName return table name about ExpenditureReceipt model
+func (_ ExpenditureReceipt) TableName() string {
+	return "expenditure_receipt"
+}
+
+// Schema return schema about ExpenditureReceipt model
+func (_ ExpenditureReceipt) Schema() string {
+	return `CREATE TABLE expenditure_receipt (
+		uuid             CHAR(11)     NOT NULL,
+		expenditure_uuid CHAR(11)     NOT NULL,
+		receipt_uri      VARCHAR(100) NOT NULL,
+		created_at       DATETIME     NOT NULL,
+		PRIMARY KEY (uuid),
+		FOREIGN KEY (expenditure_uuid)
+			REFERENCES expenditure(uuid)
+			ON DELETE CASCADE
+	);`
+}
+
+// GenerateRandomUUID method return random UUID value
+func (_ ExpenditureReceipt) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("w%s", string(random))
+}
+
+// GenerateReceiptUri method return ReceiptUri value with field value
+func (er ExpenditureReceipt) GenerateReceiptUri() string {
+	return fmt.Sprintf("/expenditures/uuid/%s/receipts/%s", StringValue(er.ExpenditureUUID), StringValue(er.UUID))
+}
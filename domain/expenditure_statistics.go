@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// ExpenditureStatisticsUsecase is interface about usecase layer using in delivery layer
+// startMonth & endMonth are inclusive months (ex. 2021-05)
+type ExpenditureStatisticsUsecase interface {
+	// GetMonthlyTotals method return total of each month with delta from previous month
+	GetMonthlyTotals(ctx context.Context, parentUUID, startMonth, endMonth string) (totals []ExpenditureMonthlyTotal, err error)
+
+	// GetCategoryTotals method return total of each category as tree, total of upper category include its subcategories
+	GetCategoryTotals(ctx context.Context, parentUUID, startMonth, endMonth string) (totals []ExpenditureCategoryTotal, err error)
+
+	// GetChildrenTotals method return total of expenditures tagged with each children
+	GetChildrenTotals(ctx context.Context, parentUUID, startMonth, endMonth string) (totals []ExpenditureChildrenTotal, err error)
+
+	// GetAgeMonthlyAverages method return total of each month of age & average per month of age for each children
+	GetAgeMonthlyAverages(ctx context.Context, parentUUID string) (averages []ExpenditureAgeMonthlyAverage, err error)
+}
+
+// ExpenditureAggregator is interface aggregating amount of expenditures of parent
+// it is implemented with both of mysql & elasticsearch, so that aggregation can be moved to search engine
+type ExpenditureAggregator interface {
+	// SumByRanges method return sum of each range between boundaries (len(boundaries)-1 sums), only tagged with babyUUID if it is not empty
+	SumByRanges(ctx tx.Context, parentUUID, babyUUID string, boundaries []time.Time) ([]ExpenditureSum, error)
+
+	// SumByCategory method return sum of each category in from ~ to
+	SumByCategory(ctx tx.Context, parentUUID string, from, to time.Time) ([]ExpenditureCategorySum, error)
+
+	// SumByBaby method return sum of each baby tagged in expenditures in from ~ to
+	SumByBaby(ctx tx.Context, parentUUID string, from, to time.Time) ([]ExpenditureBabySum, error)
+}
+
+// ExpenditureSum is sum of amount & count of expenditures
+type ExpenditureSum struct {
+	Amount int64 `db:"amount" json:"amount"`
+	Count  int64 `db:"count" json:"count"`
+}
+
+// ExpenditureBabySum is sum of expenditure amount tagged with one baby
+// expenditure tagged with several babies is counted for each of them
+type ExpenditureBabySum struct {
+	BabyUUID string `db:"baby_uuid" json:"baby_uuid"`
+	Amount   int64  `db:"amount" json:"amount"`
+	Count    int64  `db:"count" json:"count"`
+}
+
+// ExpenditureMonthlyTotal is total of expenditures spent in month
+// DeltaRate is percentage of Delta to total of previous month, nil if previous month has no expenditure
+type ExpenditureMonthlyTotal struct {
+	Month     string   `json:"month"`
+	Amount    int64    `json:"amount"`
+	Count     int64    `json:"count"`
+	Delta     int64    `json:"delta"`
+	DeltaRate *float64 `json:"delta_rate"`
+}
+
+// ExpenditureCategoryTotal is total of expenditures in category, not categorized one has no CategoryUUID
+type ExpenditureCategoryTotal struct {
+	CategoryUUID  *string                    `json:"category_uuid"`
+	Name          string                     `json:"name"`
+	Amount        int64                      `json:"amount"`
+	Subcategories []ExpenditureCategoryTotal `json:"subcategories,omitempty"`
+}
+
+// ExpenditureChildrenTotal is total of expenditures tagged with children
+type ExpenditureChildrenTotal struct {
+	ChildrenUUID string `json:"children_uuid"`
+	Name         string `json:"name"`
+	Amount       int64  `json:"amount"`
+	Count        int64  `json:"count"`
+}
+
+// ExpenditureAgeMonthlyAverage is expenditures tagged with children by month of age
+// Average is divided by count of months of age including current one
+type ExpenditureAgeMonthlyAverage struct {
+	ChildrenUUID string                  `json:"children_uuid"`
+	Name         string                  `json:"name"`
+	Average      int64                   `json:"average"`
+	AgeMonths    []ExpenditureAgeMonthly `json:"age_months"`
+}
+
+// ExpenditureAgeMonthly is total of expenditures in one month of age
+type ExpenditureAgeMonthly struct {
+	AgeMonth int   `json:"age_month"`
+	Amount   int64 `json:"amount"`
+	Count    int64 `json:"count"`
+}
+
+// NewExpenditureMonthlyTotals function return monthly totals of months with sums
+// sums has one more sum of previous month at first, which is used only for delta
+func NewExpenditureMonthlyTotals(months []string, sums []ExpenditureSum) (totals []ExpenditureMonthlyTotal) {
+	totals = make([]ExpenditureMonthlyTotal, 0, len(months))
+	for i, month := range months {
+		prev, cur := sums[i], sums[i+1]
+		total := ExpenditureMonthlyTotal{
+			Month:  month,
+			Amount: cur.Amount,
+			Count:  cur.Count,
+			Delta:  cur.Amount - prev.Amount,
+		}
+		if prev.Amount != 0 {
+			rate := float64(total.Delta) / float64(prev.Amount) * 100
+			total.DeltaRate = &rate
+		}
+		totals = append(totals, total)
+	}
+	return
+}
+
+// NewExpenditureCategoryTotals function return totals of categories with sums as tree in order of categories
+// category without expenditure is omitted, and not categorized total is placed at last
+func NewExpenditureCategoryTotals(categories ExpenditureCategories, sums []ExpenditureCategorySum) (totals []ExpenditureCategoryTotal) {
+	amounts := map[string]int64{}
+	for _, sum := range sums {
+		uuid := StringValue(sum.CategoryUUID)
+		if _, ok := categories.Find(uuid); !ok {
+			uuid = ""
+		}
+		amounts[uuid] += sum.Amount
+	}
+
+	totals = []ExpenditureCategoryTotal{}
+	for _, ec := range categories.Tree() {
+		total := ExpenditureCategoryTotal{
+			CategoryUUID: ec.UUID,
+			Name:         StringValue(ec.Name),
+			Amount:       amounts[StringValue(ec.UUID)],
+		}
+		for _, sub := range ec.Subcategories {
+			amount := amounts[StringValue(sub.UUID)]
+			if amount == 0 {
+				continue
+			}
+			total.Amount += amount
+			total.Subcategories = append(total.Subcategories, ExpenditureCategoryTotal{
+				CategoryUUID: sub.UUID,
+				Name:         StringValue(sub.Name),
+				Amount:       amount,
+			})
+		}
+		if total.Amount != 0 {
+			totals = append(totals, total)
+		}
+	}
+
+	if amount := amounts[""]; amount != 0 {
+		totals = append(totals, ExpenditureCategoryTotal{Name: "미분류", Amount: amount})
+	}
+	return
+}
+
+// AgeMonthBoundaries function return start time of each month of age from birth until now, with now at last
+func AgeMonthBoundaries(birth, now time.Time) (boundaries []time.Time) {
+	for m := 0; ; m++ {
+		start := birth.AddDate(0, m, 0)
+		if !start.Before(now) {
+			break
+		}
+		boundaries = append(boundaries, start)
+	}
+	return append(boundaries, now)
+}
+
+// ListMonths function return months from startMonth to endMonth (inclusive)
+func ListMonths(startMonth, endMonth string) (months []string, err error) {
+	from, _, err := MonthRange(startMonth)
+	if err != nil {
+		return
+	}
+	to, _, err := MonthRange(endMonth)
+	if err != nil {
+		return
+	}
+
+	for t := from; !t.After(to); t = t.AddDate(0, 1, 0) {
+		months = append(months, MonthOf(t))
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// FeedingUsecase is interface about usecase layer using in delivery layer
+type FeedingUsecase interface {
+	// CreateFeeding method store new feeding of children
+	CreateFeeding(ctx context.Context, parentUUID string, f *Feeding) (uuid string, err error)
+
+	// GetFeedingsByDate method return feedings of children in the date
+	GetFeedingsByDate(ctx context.Context, parentUUID, childrenUUID, date string) (feedings []Feeding, err error)
+
+	// GetFeedingSummary method return daily totals of children in the date & time since the last feeding
+	GetFeedingSummary(ctx context.Context, parentUUID, childrenUUID, date string) (summary FeedingSummary, err error)
+}
+
+// FeedingRepository is repository interface about Feeding model
+type FeedingRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Feeding, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]Feeding, error)
+	GetLastByChildrenUUID(ctx tx.Context, childrenUUID string) (Feeding, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, f *Feeding) error
+}
+
+// type value of Feeding
+const (
+	FeedingTypeBreast = "breast"
+	FeedingTypeBottle = "bottle"
+	FeedingTypeSolid  = "solid"
+)
+
+// breast side value of Feeding
+const (
+	BreastSideLeft  = "left"
+	BreastSideRight = "right"
+	BreastSideBoth  = "both"
+)
+
+// bottle content value of Feeding
+const (
+	BottleContentFormula   = "formula"
+	BottleContentExpressed = "expressed"
+)
+
+// Feeding is model represent feeding of children using in feeding domain
+type Feeding struct {
+	UUID          *string    `db:"uuid" json:"uuid" validate:"required,uuid=feeding"`
+	ChildrenUUID  *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	FeedingType   *string    `db:"feeding_type" json:"feeding_type" validate:"required,oneof=breast bottle solid"`
+	FedAt         *time.Time `db:"fed_at" json:"fed_at" validate:"required"`
+	BreastSide    *string    `db:"breast_side" json:"breast_side,omitempty" validate:"omitempty,oneof=left right both"`
+	Duration      *int64     `db:"duration" json:"duration,omitempty" validate:"range=0~600"`
+	BottleVolume  *int64     `db:"bottle_volume" json:"bottle_volume,omitempty" validate:"range=0~1000"`
+	BottleContent *string    `db:"bottle_content" json:"bottle_content,omitempty" validate:"omitempty,oneof=formula expressed"`
+	FoodName      *string    `db:"food_name" json:"food_name,omitempty" validate:"max=50"`
+}
+
+// TableName return table name about Feeding model
+func (_ Feeding) TableName() string {
+	return "feeding"
+}
+
+// Schema return rdbms schema about Feeding model
+func (_ Feeding) Schema() string {
+	return `CREATE TABLE feeding (
+		uuid           CHAR(11)    NOT NULL,
+		children_uuid  CHAR(11)    NOT NULL,
+		feeding_type   VARCHAR(10) NOT NULL,
+		fed_at         DATETIME    NOT NULL,
+		breast_side    VARCHAR(10),
+		duration       INT(4),
+		bottle_volume  INT(5),
+		bottle_content VARCHAR(10),
+		food_name      VARCHAR(50),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, fed_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (f Feeding) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("f%s", string(random))
+}
+
+// FeedingSummary is daily totals of feeding with time since the last feeding
+type FeedingSummary struct {
+	Date            string     `json:"date"`
+	TotalCount      int64      `json:"total_count"`
+	BreastCount     int64      `json:"breast_count"`
+	BreastDuration  int64      `json:"breast_duration"`
+	BottleCount     int64      `json:"bottle_count"`
+	FormulaVolume   int64      `json:"formula_volume"`
+	ExpressedVolume int64      `json:"expressed_volume"`
+	SolidCount      int64      `json:"solid_count"`
+	LastFedAt       *time.Time `json:"last_fed_at"`
+	MinutesSinceFed *int64     `json:"minutes_since_last_feeding"`
+}
+
+// Add method add feeding to daily totals of summary
+func (fs *FeedingSummary) Add(f Feeding) {
+	fs.TotalCount++
+	switch StringValue(f.FeedingType) {
+	case FeedingTypeBreast:
+		fs.BreastCount++
+		fs.BreastDuration += Int64Value(f.Duration)
+	case FeedingTypeBottle:
+		fs.BottleCount++
+		switch StringValue(f.BottleContent) {
+		case BottleContentFormula:
+			fs.FormulaVolume += Int64Value(f.BottleVolume)
+		case BottleContentExpressed:
+			fs.ExpressedVolume += Int64Value(f.BottleVolume)
+		}
+	case FeedingTypeSolid:
+		fs.SolidCount++
+	}
+}
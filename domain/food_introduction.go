@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// FoodIntroductionUsecase is interface about usecase layer using in delivery layer
+type FoodIntroductionUsecase interface {
+	// CreateFoodIntroduction method store new food introduced to children
+	CreateFoodIntroduction(ctx context.Context, parentUUID string, fi *FoodIntroduction) (uuid string, err error)
+
+	// GetFoodIntroductions method return foods introduced to children
+	GetFoodIntroductions(ctx context.Context, parentUUID, childrenUUID string) (introductions []FoodIntroduction, err error)
+
+	// GetAllergenSuggestions method return common allergens not introduced yet to children
+	// allergens in standing allergy list of children are excluded
+	GetAllergenSuggestions(ctx context.Context, parentUUID, childrenUUID string) (suggestions []AllergenSuggestion, err error)
+}
+
+// FoodIntroductionRepository is repository interface about FoodIntroduction model
+type FoodIntroductionRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (FoodIntroduction, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]FoodIntroduction, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, fi *FoodIntroduction) error
+}
+
+// AllergenDefinition is common allergen with month of age it can be introduced from
+type AllergenDefinition struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	MinMonth int    `json:"min_month"`
+}
+
+// AllergenCatalog is common allergens based on allergen labeling list of Korea food standard
+// most allergens are recommended to be introduced from 6 months without delay, one at a time
+var AllergenCatalog = []AllergenDefinition{
+	{Code: "egg", Name: "달걀", MinMonth: 6},
+	{Code: "milk", Name: "우유 (요거트, 치즈)", MinMonth: 6},
+	{Code: "wheat", Name: "밀", MinMonth: 6},
+	{Code: "soybean", Name: "대두", MinMonth: 6},
+	{Code: "peanut", Name: "땅콩 (페이스트)", MinMonth: 6},
+	{Code: "tree_nut", Name: "견과류 (호두, 잣)", MinMonth: 6},
+	{Code: "sesame", Name: "참깨", MinMonth: 6},
+	{Code: "buckwheat", Name: "메밀", MinMonth: 6},
+	{Code: "beef", Name: "쇠고기", MinMonth: 6},
+	{Code: "chicken", Name: "닭고기", MinMonth: 6},
+	{Code: "pork", Name: "돼지고기", MinMonth: 7},
+	{Code: "fish", Name: "생선 (고등어)", MinMonth: 7},
+	{Code: "peach", Name: "복숭아", MinMonth: 7},
+	{Code: "tomato", Name: "토마토", MinMonth: 7},
+	{Code: "crustacean", Name: "갑각류 (새우, 게)", MinMonth: 9},
+	{Code: "shellfish", Name: "조개류 (굴, 홍합, 전복)", MinMonth: 9},
+	{Code: "squid", Name: "오징어", MinMonth: 9},
+}
+
+// FindAllergen function return allergen definition with code in AllergenCatalog
+func FindAllergen(code string) (ad AllergenDefinition, ok bool) {
+	for _, ad = range AllergenCatalog {
+		if ad.Code == code {
+			return ad, true
+		}
+	}
+	return AllergenDefinition{}, false
+}
+
+// status value of AllergenSuggestion
+const (
+	AllergenRecommended = "recommended"
+	AllergenTooEarly    = "too_early"
+)
+
+// AllergenSuggestion is allergen not introduced yet to children with status according to age
+type AllergenSuggestion struct {
+	AllergenDefinition
+	Status string `json:"status"`
+}
+
+// reaction severity value of FoodIntroduction
+const (
+	ReactionSeverityNone     = "none"
+	ReactionSeverityMild     = "mild"
+	ReactionSeverityModerate = "moderate"
+	ReactionSeveritySevere   = "severe"
+)
+
+// FoodIntroduction is model represent food introduced to children using in food introduction domain
+// AllergenCategory is code in AllergenCatalog if food contain allergen
+type FoodIntroduction struct {
+	UUID             *string    `db:"uuid" json:"uuid" validate:"required,uuid=food_introduction"`
+	ChildrenUUID     *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	FoodName         *string    `db:"food_name" json:"food_name" validate:"required,min=1,max=30"`
+	AllergenCategory *string    `db:"allergen_category" json:"allergen_category,omitempty" validate:"max=30"`
+	IntroducedAt     *time.Time `db:"introduced_at" json:"introduced_at" validate:"required"`
+	ReactionSeverity *string    `db:"reaction_severity" json:"reaction_severity" validate:"required,oneof=none mild moderate severe"`
+	ReactionSymptom  *string    `db:"reaction_symptom" json:"reaction_symptom,omitempty" validate:"max=200"`
+}
+
+// TableName return table name about FoodIntroduction model
+func (_ FoodIntroduction) TableName() string {
+	return "food_introduction"
+}
+
+// Schema return rdbms schema about FoodIntroduction model
+func (_ FoodIntroduction) Schema() string {
+	return `CREATE TABLE food_introduction (
+		uuid              CHAR(11)     NOT NULL,
+		children_uuid     CHAR(11)     NOT NULL,
+		food_name         VARCHAR(30)  NOT NULL,
+		allergen_category VARCHAR(30),
+		introduced_at     DATETIME     NOT NULL,
+		reaction_severity VARCHAR(10)  NOT NULL,
+		reaction_symptom  VARCHAR(200),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, introduced_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (fi FoodIntroduction) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("i%s", string(random))
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// GrowthUsecase is interface about usecase layer using in delivery layer
+type GrowthUsecase interface {
+	// CreateGrowthMeasurement method store new height, weight & head circumference measurement of children
+	CreateGrowthMeasurement(ctx context.Context, parentUUID string, gm *GrowthMeasurement) (uuid string, err error)
+
+	// GetGrowthChart method return measurements of children with percentiles on growth standard in chronological order
+	GetGrowthChart(ctx context.Context, parentUUID, childrenUUID string) (points []GrowthPoint, err error)
+}
+
+// GrowthMeasurementRepository is repository interface about GrowthMeasurement model
+type GrowthMeasurementRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (GrowthMeasurement, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]GrowthMeasurement, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]GrowthMeasurement, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, gm *GrowthMeasurement) error
+}
+
+// indicator value of GrowthCurve, same with json name of measured value in GrowthMeasurement
+const (
+	GrowthIndicatorHeight            = "height"
+	GrowthIndicatorWeight            = "weight"
+	GrowthIndicatorHeadCircumference = "head_circumference"
+)
+
+// GrowthMeasurement is model represent measurement of children body using in growth domain
+// Height & HeadCircumference is in cm and Weight is in kg, and at least one of them is measured
+type GrowthMeasurement struct {
+	UUID              *string    `db:"uuid" json:"uuid" validate:"required,uuid=growth_measurement"`
+	ChildrenUUID      *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	MeasuredAt        *time.Time `db:"measured_at" json:"measured_at" validate:"required"`
+	Height            *float64   `db:"height" json:"height,omitempty" validate:"omitempty,min=30,max=130"`
+	Weight            *float64   `db:"weight" json:"weight,omitempty" validate:"omitempty,min=0.5,max=40"`
+	HeadCircumference *float64   `db:"head_circumference" json:"head_circumference,omitempty" validate:"omitempty,min=20,max=60"`
+}
+
+// TableName return table name about GrowthMeasurement model
+func (_ GrowthMeasurement) TableName() string {
+	return "growth_measurement"
+}
+
+// Schema return rdbms schema about GrowthMeasurement model
+func (_ GrowthMeasurement) Schema() string {
+	return `CREATE TABLE growth_measurement (
+		uuid               CHAR(11)     NOT NULL,
+		children_uuid      CHAR(11)     NOT NULL,
+		measured_at        DATETIME     NOT NULL,
+		height             DECIMAL(4,1),
+		weight             DECIMAL(5,3),
+		head_circumference DECIMAL(3,1),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, measured_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (gm GrowthMeasurement) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("j%s", string(random))
+}
+
+// GrowthStandard is growth standard having LMS curve of each indicator & sex (ex. WHO Child Growth Standards)
+type GrowthStandard struct {
+	Source string        `json:"source"`
+	Curves []GrowthCurve `json:"curves"`
+}
+
+// GrowthCurve is LMS parameters of one indicator & sex by age in months, LMS is ordered by month
+type GrowthCurve struct {
+	Indicator string      `json:"indicator"`
+	Sex       string      `json:"sex"`
+	LMS       []GrowthLMS `json:"lms"`
+}
+
+// GrowthLMS is Box-Cox power (L), median (M) & coefficient of variation (S) at age in months
+type GrowthLMS struct {
+	Month int     `json:"month"`
+	L     float64 `json:"l"`
+	M     float64 `json:"m"`
+	S     float64 `json:"s"`
+}
+
+// daysPerMonth is average days in a month used for age in months of growth standard
+const daysPerMonth = 30.4375
+
+// GrowthAgeMonths function return age in months at t of children born at birth, with fraction of month
+func GrowthAgeMonths(birth, t time.Time) float64 {
+	return t.Sub(birth).Hours() / 24 / daysPerMonth
+}
+
+// Percentile method return percentile (0 ~ 100) of value measured at age in months for indicator & sex
+// LMS is interpolated between months, and ok is false if there is no curve or age is out of curve
+func (gs GrowthStandard) Percentile(indicator, sex string, ageMonths, value float64) (p float64, ok bool) {
+	for _, gc := range gs.Curves {
+		if gc.Indicator != indicator || gc.Sex != sex {
+			continue
+		}
+
+		for i := 0; i+1 < len(gc.LMS); i++ {
+			lo, hi := gc.LMS[i], gc.LMS[i+1]
+			if ageMonths < float64(lo.Month) || ageMonths > float64(hi.Month) {
+				continue
+			}
+
+			ratio := (ageMonths - float64(lo.Month)) / float64(hi.Month-lo.Month)
+			l := lo.L + (hi.L-lo.L)*ratio
+			m := lo.M + (hi.M-lo.M)*ratio
+			s := lo.S + (hi.S-lo.S)*ratio
+
+			var z float64
+			if l == 0 {
+				z = math.Log(value/m) / s
+			} else {
+				z = (math.Pow(value/m, l) - 1) / (l * s)
+			}
+			return math.Round(50*math.Erfc(-z/math.Sqrt2)*10) / 10, true
+		}
+	}
+	return
+}
+
+// GrowthPoint is growth measurement with age in months & percentile of each measured value in growth chart
+// percentile is nil if value is not measured or out of growth standard (ex. over 24 months)
+type GrowthPoint struct {
+	GrowthMeasurement
+	AgeMonths                   float64  `json:"age_months"`
+	HeightPercentile            *float64 `json:"height_percentile"`
+	WeightPercentile            *float64 `json:"weight_percentile"`
+	HeadCircumferencePercentile *float64 `json:"head_circumference_percentile"`
+}
+
+// NewGrowthPoints function return growth points of measurements of children born at birth with sex on growth standard
+func NewGrowthPoints(gs GrowthStandard, birth time.Time, sex string, measurements []GrowthMeasurement) (points []GrowthPoint) {
+	points = make([]GrowthPoint, 0, len(measurements))
+	for _, gm := range measurements {
+		gp := GrowthPoint{
+			GrowthMeasurement: gm,
+			AgeMonths:         math.Round(GrowthAgeMonths(birth, TimeValue(gm.MeasuredAt))*10) / 10,
+		}
+		percentile := func(indicator string, value *float64) *float64 {
+			if value == nil {
+				return nil
+			}
+			if p, ok := gs.Percentile(indicator, sex, GrowthAgeMonths(birth, TimeValue(gm.MeasuredAt)), *value); ok {
+				return Float64(p)
+			}
+			return nil
+		}
+		gp.HeightPercentile = percentile(GrowthIndicatorHeight, gm.Height)
+		gp.WeightPercentile = percentile(GrowthIndicatorWeight, gm.Weight)
+		gp.HeadCircumferencePercentile = percentile(GrowthIndicatorHeadCircumference, gm.HeadCircumference)
+		points = append(points, gp)
+	}
+	return
+}
@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// HealthUsecase is interface about usecase layer using in delivery layer
+type HealthUsecase interface {
+	// CreateHospitalVisit method store new hospital visit of children
+	CreateHospitalVisit(ctx context.Context, parentUUID string, hv *HospitalVisit) (uuid string, err error)
+
+	// GetHospitalVisits method return hospital visits of children with documents having download url
+	GetHospitalVisits(ctx context.Context, parentUUID, childrenUUID string) (visits []HospitalVisit, err error)
+
+	// AttachVisitDocument method stream document in body to s3 & attach it to hospital visit
+	AttachVisitDocument(ctx context.Context, parentUUID, childrenUUID string, hd *HospitalVisitDocument, body io.Reader) (uri string, err error)
+
+	// CreateMedication method store new prescribed medication of children
+	CreateMedication(ctx context.Context, parentUUID string, m *Medication) (uuid string, err error)
+
+	// GetMedications method return prescribed medications of children
+	GetMedications(ctx context.Context, parentUUID, childrenUUID string) (medications []Medication, err error)
+
+	// LogMedicationDose method store dose given of medication
+	LogMedicationDose(ctx context.Context, parentUUID, childrenUUID string, md *MedicationDose) (uuid string, err error)
+
+	// GetMedicationDoses method return doses given of medication
+	GetMedicationDoses(ctx context.Context, parentUUID, childrenUUID, medicationUUID string) (doses []MedicationDose, err error)
+
+	// GetHealthTimeline method return hospital visits, medications & doses of children in chronological order
+	GetHealthTimeline(ctx context.Context, parentUUID, childrenUUID string) (items []HealthTimelineItem, err error)
+}
+
+// HospitalVisitRepository is repository interface about HospitalVisit & HospitalVisitDocument model
+type HospitalVisitRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (HospitalVisit, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]HospitalVisit, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, hv *HospitalVisit) error
+	StoreDocument(ctx tx.Context, hd *HospitalVisitDocument) error
+}
+
+// MedicationRepository is repository interface about Medication & MedicationDose model
+type MedicationRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Medication, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]Medication, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, m *Medication) error
+
+	GetDoseByUUID(ctx tx.Context, uuid string) (MedicationDose, error)
+	GetDosesByMedicationUUID(ctx tx.Context, medicationUUID string) ([]MedicationDose, error)
+	GetDosesByChildrenUUID(ctx tx.Context, childrenUUID string) ([]MedicationDose, error)
+	GetAvailableDoseUUID(ctx tx.Context) (*string, error)
+	StoreDose(ctx tx.Context, md *MedicationDose) error
+}
+
+// HospitalVisit is model represent hospital visit of children using in health domain
+type HospitalVisit struct {
+	UUID         *string                 `db:"uuid" json:"uuid" validate:"required,uuid=hospital_visit"`
+	ChildrenUUID *string                 `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	VisitedAt    *time.Time              `db:"visited_at" json:"visited_at" validate:"required"`
+	Clinic       *string                 `db:"clinic" json:"clinic" validate:"required,min=1,max=50"`
+	Diagnosis    *string                 `db:"diagnosis" json:"diagnosis,omitempty" validate:"max=100"`
+	Note         *string                 `db:"note" json:"note,omitempty" validate:"max=1000"`
+	Documents    []HospitalVisitDocument `db:"-" json:"documents"`
+}
+
+// TableName return table name about HospitalVisit model
+func (_ HospitalVisit) TableName() string {
+	return "hospital_visit"
+}
+
+// Schema return rdbms schema about HospitalVisit model
+func (_ HospitalVisit) Schema() string {
+	return `CREATE TABLE hospital_visit (
+		uuid          CHAR(11)      NOT NULL,
+		children_uuid CHAR(11)      NOT NULL,
+		visited_at    DATETIME      NOT NULL,
+		clinic        VARCHAR(50)   NOT NULL,
+		diagnosis     VARCHAR(100),
+		note          VARCHAR(1000),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, visited_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (hv HospitalVisit) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("h%s", string(random))
+}
+
+// GenerateDocumentUri method return random document uri of hospital visit
+// uri is not made of sequence, so that document uploaded before its sequence is decided doesn't overwrite other one
+func (hv HospitalVisit) GenerateDocumentUri() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("/health/children/uuid/%s/visits/%s/%s", StringValue(hv.ChildrenUUID), StringValue(hv.UUID), string(random))
+}
+
+// HospitalVisitDocument is model represent document (prescription, receipt, etc) attached to HospitalVisit
+// document is private in s3, so DownloadUrl is presigned url set when document is returned
+type HospitalVisitDocument struct {
+	VisitUUID   *string `db:"visit_uuid" json:"-" validate:"required,uuid=hospital_visit"`
+	Seq         *int64  `db:"seq" json:"seq" validate:"range=1~50"`
+	FileName    *string `db:"file_name" json:"file_name" validate:"required,max=100"`
+	ContentType *string `db:"content_type" json:"content_type" validate:"required,max=50"`
+	DocumentUri *string `db:"document_uri" json:"document_uri" validate:"required,max=100"`
+	DownloadUrl string  `db:"-" json:"download_url"`
+}
+
+// TableName return table name about HospitalVisitDocument model
+func (_ HospitalVisitDocument) TableName() string {
+	return "hospital_visit_document"
+}
+
+// Schema return rdbms schema about HospitalVisitDocument model
+func (_ HospitalVisitDocument) Schema() string {
+	return `CREATE TABLE hospital_visit_document (
+		visit_uuid    CHAR(11)     NOT NULL,
+		seq           INT(2)       NOT NULL,
+		file_name     VARCHAR(100) NOT NULL,
+		content_type  VARCHAR(50)  NOT NULL,
+		document_uri  VARCHAR(100) NOT NULL,
+		PRIMARY KEY (visit_uuid, seq),
+		FOREIGN KEY (visit_uuid)
+			REFERENCES hospital_visit (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// Medication is model represent medication prescribed to children using in health domain
+// EndDate is nil if medication is ongoing
+type Medication struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=medication"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	VisitUUID    *string    `db:"visit_uuid" json:"visit_uuid,omitempty" validate:"omitempty,uuid=hospital_visit"`
+	Name         *string    `db:"name" json:"name" validate:"required,min=1,max=50"`
+	Dose         *string    `db:"dose" json:"dose" validate:"required,min=1,max=30"`
+	Frequency    *string    `db:"frequency" json:"frequency" validate:"required,min=1,max=30"`
+	StartDate    *time.Time `db:"start_date" json:"start_date" validate:"required"`
+	EndDate      *time.Time `db:"end_date" json:"end_date,omitempty"`
+}
+
+// TableName return table name about Medication model
+func (_ Medication) TableName() string {
+	return "medication"
+}
+
+// Schema return rdbms schema about Medication model
+func (_ Medication) Schema() string {
+	return `CREATE TABLE medication (
+		uuid          CHAR(11)    NOT NULL,
+		children_uuid CHAR(11)    NOT NULL,
+		visit_uuid    CHAR(11),
+		name          VARCHAR(50) NOT NULL,
+		dose          VARCHAR(30) NOT NULL,
+		frequency     VARCHAR(30) NOT NULL,
+		start_date    DATETIME    NOT NULL,
+		end_date      DATETIME,
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, start_date),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE,
+		FOREIGN KEY (visit_uuid)
+			REFERENCES hospital_visit (uuid)
+			ON DELETE SET NULL
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (m Medication) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("r%s", string(random))
+}
+
+// MedicationDose is model represent one dose given of Medication
+type MedicationDose struct {
+	UUID           *string    `db:"uuid" json:"uuid" validate:"required,uuid=medication_dose"`
+	MedicationUUID *string    `db:"medication_uuid" json:"medication_uuid" validate:"required,uuid=medication"`
+	GivenAt        *time.Time `db:"given_at" json:"given_at" validate:"required"`
+	Amount         *string    `db:"amount" json:"amount,omitempty" validate:"max=30"`
+	Note           *string    `db:"note" json:"note,omitempty" validate:"max=200"`
+}
+
+// TableName return table name about MedicationDose model
+func (_ MedicationDose) TableName() string {
+	return "medication_dose"
+}
+
+// Schema return rdbms schema about MedicationDose model
+func (_ MedicationDose) Schema() string {
+	return `CREATE TABLE medication_dose (
+		uuid            CHAR(11)     NOT NULL,
+		medication_uuid CHAR(11)     NOT NULL,
+		given_at        DATETIME     NOT NULL,
+		amount          VARCHAR(30),
+		note            VARCHAR(200),
+		PRIMARY KEY (uuid),
+		INDEX (medication_uuid, given_at),
+		FOREIGN KEY (medication_uuid)
+			REFERENCES medication (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (md MedicationDose) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("g%s", string(random))
+}
+
+// type value of HealthTimelineItem
+const (
+	HealthTimelineHospitalVisit   = "hospital_visit"
+	HealthTimelineMedicationStart = "medication_start"
+	HealthTimelineMedicationEnd   = "medication_end"
+	HealthTimelineMedicationDose  = "medication_dose"
+)
+
+// HealthTimelineItem is one event in health timeline of children
+// only one of HospitalVisit, Medication & MedicationDose is set according to Type
+type HealthTimelineItem struct {
+	Type           string          `json:"type"`
+	At             time.Time       `json:"at"`
+	HospitalVisit  *HospitalVisit  `json:"hospital_visit,omitempty"`
+	Medication     *Medication     `json:"medication,omitempty"`
+	MedicationDose *MedicationDose `json:"medication_dose,omitempty"`
+}
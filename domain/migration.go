@@ -0,0 +1,15 @@
+package domain
+
+// ColumnMigration is migration of column in table created with older schema of model
+// migrator creates only missing table, so column added or changed after table is created is migrated with this
+type ColumnMigration struct {
+	// Column is name of column that migration add or change
+	Column string
+
+	// Nullable is true if migration make existing column nullable, so it is applied while column is NOT NULL
+	// if Nullable is false, migration add column, so it is applied while column is not exist
+	Nullable bool
+
+	// Statements is SQL executed in order to migrate column
+	Statements []string
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// MilestoneUsecase is interface about usecase layer using in delivery layer
+type MilestoneUsecase interface {
+	// GetMilestoneCatalog method return catalog of standard milestones
+	GetMilestoneCatalog(ctx context.Context) (catalog []MilestoneDefinition)
+
+	// CreateMilestoneRecord method store achieved milestone of children with photos
+	CreateMilestoneRecord(ctx context.Context, parentUUID string, mr *MilestoneRecord, photos [][]byte) (uuid string, err error)
+
+	// GetMilestoneRecords method return achieved milestones of children
+	GetMilestoneRecords(ctx context.Context, parentUUID, childrenUUID string) (records []MilestoneRecord, err error)
+
+	// CompareMilestones method compare achieved milestones of children with typical age windows
+	CompareMilestones(ctx context.Context, parentUUID, childrenUUID string) (comparisons []MilestoneComparison, err error)
+}
+
+// MilestoneRecordRepository is repository interface about MilestoneRecord & MilestonePhoto model
+type MilestoneRecordRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (MilestoneRecord, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]MilestoneRecord, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, mr *MilestoneRecord) error
+	StorePhoto(ctx tx.Context, mp *MilestonePhoto) error
+}
+
+// MilestoneDefinition is standard milestone in catalog with typical age window in months
+type MilestoneDefinition struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	StartMonth int    `json:"start_month"`
+	EndMonth   int    `json:"end_month"`
+}
+
+// status value of MilestoneComparison
+const (
+	MilestoneAchievedEarly  = "achieved_early"
+	MilestoneAchievedOnTime = "achieved_on_time"
+	MilestoneAchievedLate   = "achieved_late"
+	MilestoneNotYetExpected = "not_yet_expected"
+	MilestoneInWindow       = "in_window"
+	MilestoneDelayed        = "delayed"
+)
+
+// MilestoneComparison is result comparing children's milestone with typical age window
+type MilestoneComparison struct {
+	MilestoneDefinition
+	Status        string     `json:"status"`
+	AchievedAt    *time.Time `json:"achieved_at,omitempty"`
+	AchievedMonth *int       `json:"achieved_month,omitempty"`
+}
+
+// NewMilestoneComparison function compare milestone definition with record (nil if not achieved)
+func NewMilestoneComparison(md MilestoneDefinition, birth, now time.Time, mr *MilestoneRecord) (mc MilestoneComparison) {
+	mc.MilestoneDefinition = md
+
+	if mr != nil {
+		month := MonthsBetween(birth, TimeValue(mr.AchievedAt))
+		mc.AchievedAt = mr.AchievedAt
+		mc.AchievedMonth = &month
+		switch {
+		case month < md.StartMonth:
+			mc.Status = MilestoneAchievedEarly
+		case month > md.EndMonth:
+			mc.Status = MilestoneAchievedLate
+		default:
+			mc.Status = MilestoneAchievedOnTime
+		}
+		return
+	}
+
+	switch month := MonthsBetween(birth, now); {
+	case month < md.StartMonth:
+		mc.Status = MilestoneNotYetExpected
+	case month > md.EndMonth:
+		mc.Status = MilestoneDelayed
+	default:
+		mc.Status = MilestoneInWindow
+	}
+	return
+}
+
+// MonthsBetween function return count of full months from t1 to t2
+func MonthsBetween(t1, t2 time.Time) int {
+	months := (t2.Year()-t1.Year())*12 + int(t2.Month()-t1.Month())
+	if t2.Day() < t1.Day() {
+		months--
+	}
+	return months
+}
+
+// MilestoneRecord is model represent achieved milestone of children using in milestone domain
+// MilestoneCode is empty for custom milestone
+type MilestoneRecord struct {
+	UUID          *string    `db:"uuid" json:"uuid" validate:"required,uuid=milestone"`
+	ChildrenUUID  *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	MilestoneCode *string    `db:"milestone_code" json:"milestone_code,omitempty" validate:"max=30"`
+	Title         *string    `db:"title" json:"title" validate:"required,min=1,max=50"`
+	AchievedAt    *time.Time `db:"achieved_at" json:"achieved_at" validate:"required"`
+	Note          *string    `db:"note" json:"note,omitempty" validate:"max=500"`
+	PhotoUris     []string   `db:"-" json:"photo_uris"`
+}
+
+// TableName return table name about MilestoneRecord model
+func (_ MilestoneRecord) TableName() string {
+	return "milestone_record"
+}
+
+// Schema return rdbms schema about MilestoneRecord model
+func (_ MilestoneRecord) Schema() string {
+	return `CREATE TABLE milestone_record (
+		uuid           CHAR(11)     NOT NULL,
+		children_uuid  CHAR(11)     NOT NULL,
+		milestone_code VARCHAR(30),
+		title          VARCHAR(50)  NOT NULL,
+		achieved_at    DATETIME     NOT NULL,
+		note           VARCHAR(500),
+		PRIMARY KEY (uuid),
+		UNIQUE (children_uuid, milestone_code),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (mr MilestoneRecord) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("m%s", string(random))
+}
+
+// GeneratePhotoUri method return photo uri of milestone record with sequence
+func (mr MilestoneRecord) GeneratePhotoUri(seq int64) string {
+	return fmt.Sprintf("/milestones/uuid/%s/photos/%d", StringValue(mr.UUID), seq)
+}
+
+// MilestonePhoto is model represent photo attached to MilestoneRecord
+type MilestonePhoto struct {
+	MilestoneUUID *string `db:"milestone_uuid" validate:"required,uuid=milestone"`
+	Seq           *int64  `db:"seq" validate:"range=1~20"`
+	PhotoUri      *string `db:"photo_uri" validate:"required,max=100"`
+}
+
+// TableName return table name about MilestonePhoto model
+func (_ MilestonePhoto) TableName() string {
+	return "milestone_photo"
+}
+
+// Schema return rdbms schema about MilestonePhoto model
+func (_ MilestonePhoto) Schema() string {
+	return `CREATE TABLE milestone_photo (
+		milestone_uuid CHAR(11)     NOT NULL,
+		seq            INT(2)       NOT NULL,
+		photo_uri      VARCHAR(100) NOT NULL,
+		PRIMARY KEY (milestone_uuid, seq),
+		FOREIGN KEY (milestone_uuid)
+			REFERENCES milestone_record (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
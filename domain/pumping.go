@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// PumpingUsecase is interface about usecase layer using in delivery layer
+type PumpingUsecase interface {
+	// CreatePumping method store pumping session of children with milk bags stored from that session
+	CreatePumping(ctx context.Context, parentUUID string, p *Pumping) (uuid string, err error)
+
+	// GetPumpingsByDate method return pumping sessions of children in the date with milk bags
+	GetPumpingsByDate(ctx context.Context, parentUUID, childrenUUID, date string) (pumpings []Pumping, err error)
+
+	// GetStoredMilkBags method return milk bags of children not consumed yet ordered by expiry
+	GetStoredMilkBags(ctx context.Context, parentUUID, childrenUUID string) (bags []MilkBag, err error)
+
+	// GetExpiringMilkBags method return milk bags of children not consumed & expiring within duration
+	// already expired bags are also returned to be discarded
+	GetExpiringMilkBags(ctx context.Context, parentUUID, childrenUUID string, within time.Duration) (bags []MilkBag, err error)
+
+	// ConsumeMilkBag method mark milk bag consumed, linked to bottle feeding if feedingUUID is not empty
+	ConsumeMilkBag(ctx context.Context, parentUUID, childrenUUID, bagUUID, feedingUUID string, consumedAt time.Time) (err error)
+}
+
+// PumpingRepository is repository interface about Pumping model
+type PumpingRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Pumping, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]Pumping, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, p *Pumping) error
+}
+
+// MilkBagRepository is repository interface about MilkBag model
+type MilkBagRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (MilkBag, error)
+	GetByPumpingUUIDs(ctx tx.Context, pumpingUUIDs []string) ([]MilkBag, error)
+	GetStoredByChildrenUUID(ctx tx.Context, childrenUUID string, expiresBefore *time.Time) ([]MilkBag, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, mb *MilkBag) error
+	UpdateConsumed(ctx tx.Context, mb *MilkBag) error
+}
+
+// Pumping is model represent breast milk pumping session for children using in pumping domain
+type Pumping struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=pumping"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	PumpedAt     *time.Time `db:"pumped_at" json:"pumped_at" validate:"required"`
+	LeftVolume   *int64     `db:"left_volume" json:"left_volume" validate:"range=0~1000"`
+	RightVolume  *int64     `db:"right_volume" json:"right_volume" validate:"range=0~1000"`
+	Duration     *int64     `db:"duration" json:"duration,omitempty" validate:"range=0~180"`
+	Bags         []MilkBag  `db:"-" json:"bags"`
+}
+
+// TableName return table name about Pumping model
+func (_ Pumping) TableName() string {
+	return "pumping"
+}
+
+// Schema return rdbms schema about Pumping model
+func (_ Pumping) Schema() string {
+	return `CREATE TABLE pumping (
+		uuid          CHAR(11) NOT NULL,
+		children_uuid CHAR(11) NOT NULL,
+		pumped_at     DATETIME NOT NULL,
+		left_volume   INT(4)   NOT NULL,
+		right_volume  INT(4)   NOT NULL,
+		duration      INT(3),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, pumped_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (p Pumping) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("k%s", string(random))
+}
+
+// TotalVolume method return sum of volume pumped from both side
+func (p Pumping) TotalVolume() int64 {
+	return Int64Value(p.LeftVolume) + Int64Value(p.RightVolume)
+}
+
+// storage value of MilkBag
+const (
+	MilkStorageRoom    = "room"
+	MilkStorageFridge  = "fridge"
+	MilkStorageFreezer = "freezer"
+)
+
+// MilkExpiry function return time when breast milk stored at storedAt in storage expire
+// room temperature 4 hours, fridge 4 days, freezer 6 months
+func MilkExpiry(storage string, storedAt time.Time) time.Time {
+	switch storage {
+	case MilkStorageRoom:
+		return storedAt.Add(4 * time.Hour)
+	case MilkStorageFridge:
+		return storedAt.AddDate(0, 0, 4)
+	default:
+		return storedAt.AddDate(0, 6, 0)
+	}
+}
+
+// MilkBag is model represent bag of breast milk stored from pumping session
+// ConsumedAt & FeedingUUID are set when bag is consumed, FeedingUUID is nil if not linked to feeding
+type MilkBag struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=milk_bag"`
+	PumpingUUID  *string    `db:"pumping_uuid" json:"pumping_uuid" validate:"required,uuid=pumping"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	Volume       *int64     `db:"volume" json:"volume" validate:"required,range=1~1000"`
+	Storage      *string    `db:"storage" json:"storage" validate:"required,oneof=room fridge freezer"`
+	StoredAt     *time.Time `db:"stored_at" json:"stored_at" validate:"required"`
+	ExpiresAt    *time.Time `db:"expires_at" json:"expires_at" validate:"required"`
+	ConsumedAt   *time.Time `db:"consumed_at" json:"consumed_at,omitempty"`
+	FeedingUUID  *string    `db:"feeding_uuid" json:"feeding_uuid,omitempty" validate:"uuid=feeding"`
+}
+
+// TableName return table name about MilkBag model
+func (_ MilkBag) TableName() string {
+	return "milk_bag"
+}
+
+// Schema return rdbms schema about MilkBag model
+func (_ MilkBag) Schema() string {
+	return `CREATE TABLE milk_bag (
+		uuid          CHAR(11)    NOT NULL,
+		pumping_uuid  CHAR(11)    NOT NULL,
+		children_uuid CHAR(11)    NOT NULL,
+		volume        INT(4)      NOT NULL,
+		storage       VARCHAR(10) NOT NULL,
+		stored_at     DATETIME    NOT NULL,
+		expires_at    DATETIME    NOT NULL,
+		consumed_at   DATETIME,
+		feeding_uuid  CHAR(11),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, expires_at),
+		FOREIGN KEY (pumping_uuid)
+			REFERENCES pumping (uuid)
+			ON DELETE CASCADE,
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE,
+		FOREIGN KEY (feeding_uuid)
+			REFERENCES feeding (uuid)
+			ON DELETE SET NULL
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (mb MilkBag) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("b%s", string(random))
+}
+
+// IsExpired method return if milk bag is expired at now
+func (mb MilkBag) IsExpired(now time.Time) bool {
+	return !now.Before(TimeValue(mb.ExpiresAt))
+}
@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// RecurringExpenditureUsecase is interface about usecase layer using in delivery layer
+type RecurringExpenditureUsecase interface {
+	// CreateRecurringExpenditure method create template of parent materialized into expenditure on each occurrence of RRule
+	CreateRecurringExpenditure(ctx context.Context, re *RecurringExpenditure, babyUUIDs []string) (uuid string, err error)
+
+	// GetRecurringExpenditures method return templates of parent with tagged baby uuids
+	GetRecurringExpenditures(ctx context.Context, parentUUID string) (res []RecurringExpenditure, err error)
+
+	// UpdateRecurringExpenditure method update field of template not nil in re, schedule is recalculated if RRule is changed
+	UpdateRecurringExpenditure(ctx context.Context, parentUUID string, re *RecurringExpenditure) (err error)
+
+	// PauseRecurringExpenditure method pause or resume template, occurrences while paused are not materialized
+	PauseRecurringExpenditure(ctx context.Context, parentUUID, uuid string, paused bool) (err error)
+
+	// SkipNextRecurringExpenditure method skip next occurrence of template & return occurrence after that
+	SkipNextRecurringExpenditure(ctx context.Context, parentUUID, uuid string) (nextAt *time.Time, err error)
+
+	// DeleteRecurringExpenditure method delete template, expenditures already materialized are kept
+	DeleteRecurringExpenditure(ctx context.Context, parentUUID, uuid string) (err error)
+
+	// MaterializeDueExpenditures method store expenditure for every occurrence until now of templates not paused
+	// it is called periodically by scheduler & return count of stored expenditures
+	MaterializeDueExpenditures(ctx context.Context, now time.Time) (count int, err error)
+}
+
+// RecurringExpenditureRepository is repository interface about RecurringExpenditure model
+type RecurringExpenditureRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (RecurringExpenditure, error)
+	GetByUUIDForUpdate(ctx tx.Context, uuid string) (RecurringExpenditure, error)
+	GetByParentUUID(ctx tx.Context, parentUUID string) ([]RecurringExpenditure, error)
+	GetDueUUIDs(ctx tx.Context, now time.Time, limit int) ([]string, error)
+	GetBabyTagsByRecurringUUIDs(ctx tx.Context, recurringUUIDs []string) ([]RecurringExpenditureBabyTag, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, re *RecurringExpenditure, babyUUIDs []string) error
+	Update(ctx tx.Context, re *RecurringExpenditure) error
+	Delete(ctx tx.Context, uuid string) error
+}
+
+// MaxMaterializedOccurrences is max count of occurrences of one template materialized at once
+// occurrences missed more than it (ex. long server down) are skipped, so that expenditures are not flooded
+const MaxMaterializedOccurrences = 31
+
+// RecurringExpenditure is model represent template of repeated expenditure (ex. daycare fee) using in childcare expenditure domain
+// NextAt is next occurrence to be materialized, nil if there is no more occurrence until EndAt
+// field of materialized expenditure is copied from template, and SpentAt is time of occurrence
+type RecurringExpenditure struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=recurring_expenditure"`
+	ParentUUID   *string    `db:"parent_uuid" json:"parent_uuid" validate:"required,uuid=parent"`
+	Name         *string    `db:"name" json:"name" validate:"required,max=20"`
+	Amount       *int64     `db:"amount" json:"amount" validate:"required"`
+	Rating       *int64     `db:"rating" json:"rating" validate:"range=0~5"`
+	Link         *string    `db:"link" json:"link,omitempty" validate:"max=100"`
+	CategoryUUID *string    `db:"category_uuid" json:"category_uuid,omitempty" validate:"omitempty,uuid=expenditure_category"`
+	RRule        *string    `db:"rrule" json:"rrule" validate:"required,max=100"`
+	StartAt      *time.Time `db:"start_at" json:"start_at" validate:"required"`
+	EndAt        *time.Time `db:"end_at" json:"end_at,omitempty"`
+	NextAt       *time.Time `db:"next_at" json:"next_at"`
+	Paused       *bool      `db:"paused" json:"paused" validate:"required"`
+	CreatedAt    *time.Time `db:"created_at" json:"created_at" validate:"required"`
+	UpdatedAt    *time.Time `db:"updated_at" json:"updated_at" validate:"required"`
+	BabyUUIDs    []string   `db:"-" json:"baby_uuids"`
+}
+
+// TableName return table name about RecurringExpenditure model
+func (_ RecurringExpenditure) TableName() string {
+	return "recurring_expenditure"
+}
+
+// Schema return schema about RecurringExpenditure model
+func (_ RecurringExpenditure) Schema() string {
+	return `CREATE TABLE recurring_expenditure (
+		uuid          CHAR(11)     NOT NULL,
+		parent_uuid   CHAR(11)     NOT NULL,
+		name          VARCHAR(20)  NOT NULL,
+		amount        INT(15)      NOT NULL,
+		rating        INT(1)       NOT NULL,
+		link          VARCHAR(100),
+		category_uuid CHAR(11),
+		rrule         VARCHAR(100) NOT NULL,
+		start_at      DATETIME     NOT NULL,
+		end_at        DATETIME,
+		next_at       DATETIME,
+		paused        TINYINT(1)   NOT NULL,
+		created_at    DATETIME     NOT NULL,
+		updated_at    DATETIME     NOT NULL,
+		PRIMARY KEY (uuid),
+		INDEX (paused, next_at),
+		FOREIGN KEY (parent_uuid)
+			REFERENCES parent_auth(uuid)
+			ON DELETE CASCADE
+	);`
+}
+
+// GenerateRandomUUID method return random UUID value
+func (_ RecurringExpenditure) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("q%s", string(random))
+}
+
+// Occurrence method return first occurrence of template after t (inclusive if inclusive is true)
+// nil is returned if occurrence is after EndAt or RRule is invalid
+func (re RecurringExpenditure) Occurrence(t time.Time, inclusive bool) *time.Time {
+	rule, err := ParseRRule(StringValue(re.RRule))
+	if err != nil {
+		return nil
+	}
+	if inclusive {
+		t = t.Add(-time.Nanosecond)
+	}
+
+	next, ok := rule.Next(TimeValue(re.StartAt), t)
+	if !ok || (re.EndAt != nil && next.After(*re.EndAt)) {
+		return nil
+	}
+	return &next
+}
+
+// Expenditure method return expenditure materialized from template at occurrence
+func (re RecurringExpenditure) Expenditure(occurrence, now time.Time) Expenditure {
+	return Expenditure{
+		ParentUUID:   re.ParentUUID,
+		Name:         re.Name,
+		Amount:       re.Amount,
+		Rating:       re.Rating,
+		Link:         re.Link,
+		CategoryUUID: re.CategoryUUID,
+		SpentAt:      Time(occurrence),
+		CreatedAt:    Time(now),
+		UpdatedAt:    Time(now),
+	}
+}
+
+// RecurringExpenditureBabyTag is model represent baby tagged in recurring expenditure
+type RecurringExpenditureBabyTag struct {
+	RecurringUUID *string `db:"recurring_uuid" validate:"required,uuid=recurring_expenditure"`
+	BabyUUID      *string `db:"baby_uuid" validate:"required,uuid=children"`
+}
+
+// TableName return table name about RecurringExpenditureBabyTag model
+func (_ RecurringExpenditureBabyTag) TableName() string {
+	return "recurring_expenditure_baby_tag"
+}
+
+// Schema return schema about RecurringExpenditureBabyTag model
+func (_ RecurringExpenditureBabyTag) Schema() string {
+	return `CREATE TABLE recurring_expenditure_baby_tag (
+		recurring_uuid CHAR(11) NOT NULL,
+		baby_uuid      CHAR(11) NOT NULL,
+		PRIMARY KEY (recurring_uuid, baby_uuid),
+		FOREIGN KEY (recurring_uuid)
+			REFERENCES recurring_expenditure(uuid)
+			ON DELETE CASCADE
+	);`
+}
+
+// frequency value of RRule
+const (
+	RRuleDaily   = "DAILY"
+	RRuleWeekly  = "WEEKLY"
+	RRuleMonthly = "MONTHLY"
+	RRuleYearly  = "YEARLY"
+)
+
+// rruleWeekdays is weekday of each BYDAY value in RRule
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// maxRRuleInterval is max value of INTERVAL in RRule
+const maxRRuleInterval = 12
+
+// RRule is subset of recurrence rule in RFC 5545 (ex. FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=25)
+// supported part are FREQ, INTERVAL, BYDAY (only in WEEKLY) & BYMONTHDAY (only in MONTHLY, negative is from last day)
+// end of recurrence is not in rule but EndAt of RecurringExpenditure, and day of start is used if BYDAY & BYMONTHDAY are empty
+type RRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+// ParseRRule function parse rule string & return RRule, with error if rule has unsupported or invalid part
+func ParseRRule(rule string) (r RRule, err error) {
+	r.Interval = 1
+	for _, part := range strings.Split(strings.TrimPrefix(strings.ToUpper(rule), "RRULE:"), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return r, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch key, value := kv[0], kv[1]; key {
+		case "FREQ":
+			switch value {
+			case RRuleDaily, RRuleWeekly, RRuleMonthly, RRuleYearly:
+				r.Freq = value
+			default:
+				return r, fmt.Errorf("unsupported rrule FREQ %q", value)
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(value); err != nil || r.Interval < 1 || r.Interval > maxRRuleInterval {
+				return r, fmt.Errorf("rrule INTERVAL must be 1 ~ %d", maxRRuleInterval)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return r, fmt.Errorf("invalid rrule BYDAY %q", day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				d, aErr := strconv.Atoi(day)
+				if aErr != nil || d == 0 || d < -31 || d > 31 {
+					return r, fmt.Errorf("invalid rrule BYMONTHDAY %q", day)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, d)
+			}
+		default:
+			return r, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	switch {
+	case r.Freq == "":
+		err = errors.New("rrule FREQ is required")
+	case len(r.ByDay) != 0 && r.Freq != RRuleWeekly:
+		err = errors.New("rrule BYDAY is only supported with FREQ=WEEKLY")
+	case len(r.ByMonthDay) != 0 && r.Freq != RRuleMonthly:
+		err = errors.New("rrule BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return
+}
+
+// maxRRuleSearchDays is max count of days searched to find next occurrence (yearly rule on feb 29 is the longest)
+const maxRRuleSearchDays = 366 * 4 * maxRRuleInterval
+
+// Next method return first occurrence of rule started at start after t (exclusive)
+// occurrences are at time of day of start in ServiceLocation, and ok is false if there is no occurrence
+func (r RRule) Next(start, t time.Time) (next time.Time, ok bool) {
+	start = start.In(ServiceLocation)
+	day := start
+	if t.After(start) {
+		// move to day of t at time of day of start, which may be before t
+		tt := t.In(ServiceLocation)
+		day = time.Date(tt.Year(), tt.Month(), tt.Day(), start.Hour(), start.Minute(), start.Second(), 0, ServiceLocation)
+	}
+
+	for i := 0; i < maxRRuleSearchDays; i, day = i+1, day.AddDate(0, 0, 1) {
+		if day.After(t) && !day.Before(start) && r.matches(start, day) {
+			return day, true
+		}
+	}
+	return
+}
+
+// matches method return if day is occurrence of rule started at start
+func (r RRule) matches(start, day time.Time) bool {
+	switch r.Freq {
+	case RRuleDaily:
+		return daysBetween(start, day)%r.Interval == 0
+	case RRuleWeekly:
+		weekStart := start.AddDate(0, 0, -int((start.Weekday()+6)%7))
+		if (daysBetween(weekStart, day)/7)%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		for _, weekday := range r.ByDay {
+			if day.Weekday() == weekday {
+				return true
+			}
+		}
+		return false
+	case RRuleMonthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()-start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonthDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, ServiceLocation).Day()
+		for _, d := range r.ByMonthDay {
+			if d == day.Day() || (d < 0 && last+d+1 == day.Day()) {
+				return true
+			}
+		}
+		return false
+	case RRuleYearly:
+		return (day.Year()-start.Year())%r.Interval == 0 && day.Month() == start.Month() && day.Day() == start.Day()
+	}
+	return false
+}
+
+// daysBetween function return count of calendar days from a to b in ServiceLocation
+func daysBetween(a, b time.Time) int {
+	a, b = a.In(ServiceLocation), b.In(ServiceLocation)
+	ad := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	bd := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bd.Sub(ad).Hours() / 24)
+}
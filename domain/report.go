@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReportUsecase is interface about usecase layer using in delivery layer
+type ReportUsecase interface {
+	// GenerateHealthReport method render health report PDF of children in date range, store it to s3 & return download link
+	GenerateHealthReport(ctx context.Context, parentUUID, childrenUUID, startDate, endDate string) (link string, expiresAt time.Time, err error)
+}
+
+// HealthReport is summary of children for pediatrician in date range rendered as PDF
+// From is start of first date and To is end of last date in ServiceLocation
+// GrowthSource is name of growth standard that percentiles in Growth are calculated on
+type HealthReport struct {
+	Children     Children
+	From         time.Time
+	To           time.Time
+	Growth       []GrowthPoint
+	GrowthSource string
+	Vaccinations []HealthReportVaccination
+	Medications  []Medication
+	Feeding      FeedingAverage
+	Sleep        SleepAverage
+	GeneratedAt  time.Time
+}
+
+// HealthReportVaccination is administered vaccination dose with vaccine name in HealthReport
+type HealthReportVaccination struct {
+	VaccineName    string
+	DoseNumber     int64
+	AdministeredAt time.Time
+	Hospital       string
+}
+
+// FeedingAverage is daily average of feedings in date range
+type FeedingAverage struct {
+	CountPerDay         float64
+	BreastMinutesPerDay float64
+	BottleVolumePerDay  float64
+	SolidCountPerDay    float64
+}
+
+// SleepAverage is daily average of sleeps in date range
+type SleepAverage struct {
+	HoursPerDay      float64
+	NightHoursPerDay float64
+	NapCountPerDay   float64
+}
+
+// GenerateReportUri function return s3 object uri of health report generated at now
+func GenerateReportUri(childrenUUID string, now time.Time) string {
+	return fmt.Sprintf("/reports/children/uuid/%s/%d.pdf", childrenUUID, now.UnixNano())
+}
+
+// NewFeedingAverage function return daily average of feedings for days
+func NewFeedingAverage(feedings []Feeding, days int) (fa FeedingAverage) {
+	if days <= 0 {
+		return
+	}
+
+	for _, f := range feedings {
+		fa.CountPerDay++
+		switch StringValue(f.FeedingType) {
+		case FeedingTypeBreast:
+			fa.BreastMinutesPerDay += float64(Int64Value(f.Duration))
+		case FeedingTypeBottle:
+			fa.BottleVolumePerDay += float64(Int64Value(f.BottleVolume))
+		case FeedingTypeSolid:
+			fa.SolidCountPerDay++
+		}
+	}
+
+	fa.CountPerDay /= float64(days)
+	fa.BreastMinutesPerDay /= float64(days)
+	fa.BottleVolumePerDay /= float64(days)
+	fa.SolidCountPerDay /= float64(days)
+	return
+}
+
+// NewSleepAverage function return daily average of sleeps for days
+// only part of sleep between from & to is counted
+func NewSleepAverage(sleeps []Sleep, from, to time.Time, days int) (sa SleepAverage) {
+	if days <= 0 {
+		return
+	}
+
+	for _, s := range sleeps {
+		start, end := TimeValue(s.StartedAt), TimeValue(s.EndedAt)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		hours := end.Sub(start).Hours()
+		sa.HoursPerDay += hours
+		switch StringValue(s.SleepType) {
+		case SleepTypeNight:
+			sa.NightHoursPerDay += hours
+		case SleepTypeNap:
+			sa.NapCountPerDay++
+		}
+	}
+
+	sa.HoursPerDay /= float64(days)
+	sa.NightHoursPerDay /= float64(days)
+	sa.NapCountPerDay /= float64(days)
+	return
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// SleepUsecase is interface about usecase layer using in delivery layer
+type SleepUsecase interface {
+	// CreateSleep method store new sleep session of children not overlapped with other session
+	CreateSleep(ctx context.Context, parentUUID string, s *Sleep) (uuid string, err error)
+
+	// GetSleepsByDate method return sleep sessions of children overlapped with the date
+	GetSleepsByDate(ctx context.Context, parentUUID, childrenUUID, date string) (sleeps []Sleep, err error)
+
+	// GetDailySleepSummary method return sleep summary of children in the date
+	GetDailySleepSummary(ctx context.Context, parentUUID, childrenUUID, date string) (summary SleepDailySummary, err error)
+
+	// GetWeeklySleepSummary method return sleep summary of children in 7 days from start date
+	GetWeeklySleepSummary(ctx context.Context, parentUUID, childrenUUID, startDate string) (summary SleepWeeklySummary, err error)
+}
+
+// SleepRepository is repository interface about Sleep model
+type SleepRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Sleep, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]Sleep, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, s *Sleep) error
+}
+
+// type value of Sleep
+const (
+	SleepTypeNap   = "nap"
+	SleepTypeNight = "night"
+)
+
+// Sleep is model represent sleep session of children using in sleep domain
+type Sleep struct {
+	UUID         *string    `db:"uuid" json:"uuid" validate:"required,uuid=sleep"`
+	ChildrenUUID *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	SleepType    *string    `db:"sleep_type" json:"sleep_type" validate:"required,oneof=nap night"`
+	StartedAt    *time.Time `db:"started_at" json:"started_at" validate:"required"`
+	EndedAt      *time.Time `db:"ended_at" json:"ended_at" validate:"required"`
+	Location     *string    `db:"location" json:"location,omitempty" validate:"max=20"`
+}
+
+// TableName return table name about Sleep model
+func (_ Sleep) TableName() string {
+	return "sleep"
+}
+
+// Schema return rdbms schema about Sleep model
+func (_ Sleep) Schema() string {
+	return `CREATE TABLE sleep (
+		uuid          CHAR(11)    NOT NULL,
+		children_uuid CHAR(11)    NOT NULL,
+		sleep_type    VARCHAR(10) NOT NULL,
+		started_at    DATETIME    NOT NULL,
+		ended_at      DATETIME    NOT NULL,
+		location      VARCHAR(20),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, started_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (s Sleep) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("s%s", string(random))
+}
+
+// minutesIn method return minutes of sleep session clipped to from ~ to
+func (s Sleep) minutesIn(from, to time.Time) int64 {
+	start, end := TimeValue(s.StartedAt), TimeValue(s.EndedAt)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return int64(end.Sub(start).Minutes())
+}
+
+// SleepDailySummary is sleep summary of one day
+type SleepDailySummary struct {
+	Date           string `json:"date"`
+	SessionCount   int64  `json:"session_count"`
+	TotalMinutes   int64  `json:"total_minutes"`
+	NapMinutes     int64  `json:"nap_minutes"`
+	NightMinutes   int64  `json:"night_minutes"`
+	LongestStretch int64  `json:"longest_stretch"`
+	WakeUps        int64  `json:"wake_ups"`
+}
+
+// NewSleepDailySummary function return summary of sleeps (sorted by StartedAt) clipped to from ~ to
+// wake up is counted as gap between night sleep sessions
+func NewSleepDailySummary(date string, from, to time.Time, sleeps []Sleep) (summary SleepDailySummary) {
+	summary.Date = date
+
+	var nightSessions int64
+	for _, s := range sleeps {
+		minutes := s.minutesIn(from, to)
+		if minutes == 0 {
+			continue
+		}
+
+		summary.SessionCount++
+		summary.TotalMinutes += minutes
+		if minutes > summary.LongestStretch {
+			summary.LongestStretch = minutes
+		}
+
+		switch StringValue(s.SleepType) {
+		case SleepTypeNap:
+			summary.NapMinutes += minutes
+		case SleepTypeNight:
+			summary.NightMinutes += minutes
+			nightSessions++
+		}
+	}
+
+	if nightSessions > 1 {
+		summary.WakeUps = nightSessions - 1
+	}
+	return
+}
+
+// SleepWeeklySummary is sleep summary of 7 days with daily average
+type SleepWeeklySummary struct {
+	StartDate             string              `json:"start_date"`
+	Days                  []SleepDailySummary `json:"days"`
+	AverageTotalMinutes   float64             `json:"average_total_minutes"`
+	AverageNapMinutes     float64             `json:"average_nap_minutes"`
+	AverageNightMinutes   float64             `json:"average_night_minutes"`
+	AverageLongestStretch float64             `json:"average_longest_stretch"`
+	AverageWakeUps        float64             `json:"average_wake_ups"`
+}
+
+// NewSleepWeeklySummary function return weekly summary with averages of daily summaries
+func NewSleepWeeklySummary(startDate string, days []SleepDailySummary) (summary SleepWeeklySummary) {
+	summary.StartDate = startDate
+	summary.Days = days
+	if len(days) == 0 {
+		return
+	}
+
+	for _, d := range days {
+		summary.AverageTotalMinutes += float64(d.TotalMinutes)
+		summary.AverageNapMinutes += float64(d.NapMinutes)
+		summary.AverageNightMinutes += float64(d.NightMinutes)
+		summary.AverageLongestStretch += float64(d.LongestStretch)
+		summary.AverageWakeUps += float64(d.WakeUps)
+	}
+
+	n := float64(len(days))
+	summary.AverageTotalMinutes /= n
+	summary.AverageNapMinutes /= n
+	summary.AverageNightMinutes /= n
+	summary.AverageLongestStretch /= n
+	summary.AverageWakeUps /= n
+	return
+}
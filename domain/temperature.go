@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// TemperatureUsecase is interface about usecase layer using in delivery layer
+type TemperatureUsecase interface {
+	// CreateTemperature method store new temperature reading of children
+	// parent owning children is notified by SMS if reading cross the fever threshold
+	// children is linked to only one parent, so there is no other family member to notify
+	CreateTemperature(ctx context.Context, parentUUID string, t *Temperature) (uuid string, fever bool, err error)
+
+	// GetTemperaturesByDate method return temperature readings of children in the date
+	GetTemperaturesByDate(ctx context.Context, parentUUID, childrenUUID, date string) (temperatures []Temperature, err error)
+
+	// GetFeverEpisodes method return fever episodes of children from start date to end date
+	GetFeverEpisodes(ctx context.Context, parentUUID, childrenUUID, startDate, endDate string) (episodes []FeverEpisode, err error)
+}
+
+// TemperatureRepository is repository interface about Temperature model
+type TemperatureRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (Temperature, error)
+	GetByChildrenUUIDInRange(ctx tx.Context, childrenUUID string, from, to time.Time) ([]Temperature, error)
+	GetLastByChildrenUUIDBefore(ctx tx.Context, childrenUUID string, before time.Time) (Temperature, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, t *Temperature) error
+}
+
+// measurement method value of Temperature
+const (
+	TemperatureMethodRectal   = "rectal"
+	TemperatureMethodOral     = "oral"
+	TemperatureMethodAxillary = "axillary"
+	TemperatureMethodEar      = "ear"
+	TemperatureMethodForehead = "forehead"
+)
+
+// Temperature is model represent body temperature reading of children using in temperature domain
+type Temperature struct {
+	UUID            *string    `db:"uuid" json:"uuid" validate:"required,uuid=temperature"`
+	ChildrenUUID    *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	Value           *float64   `db:"value" json:"value" validate:"required,min=30,max=45"`
+	Method          *string    `db:"method" json:"method" validate:"required,oneof=rectal oral axillary ear forehead"`
+	MeasuredAt      *time.Time `db:"measured_at" json:"measured_at" validate:"required"`
+	AntipyreticName *string    `db:"antipyretic_name" json:"antipyretic_name,omitempty" validate:"max=30"`
+	AntipyreticDose *string    `db:"antipyretic_dose" json:"antipyretic_dose,omitempty" validate:"max=20"`
+}
+
+// TableName return table name about Temperature model
+func (_ Temperature) TableName() string {
+	return "temperature"
+}
+
+// Schema return rdbms schema about Temperature model
+func (_ Temperature) Schema() string {
+	return `CREATE TABLE temperature (
+		uuid             CHAR(11)     NOT NULL,
+		children_uuid    CHAR(11)     NOT NULL,
+		value            DECIMAL(3,1) NOT NULL,
+		method           VARCHAR(10)  NOT NULL,
+		measured_at      DATETIME     NOT NULL,
+		antipyretic_name VARCHAR(30),
+		antipyretic_dose VARCHAR(20),
+		PRIMARY KEY (uuid),
+		INDEX (children_uuid, measured_at),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (t Temperature) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("t%s", string(random))
+}
+
+// FeverThreshold function return temperature (°C) from which reading is fever by age in months & measurement method
+// axillary reading is lower than core temperature, and oral reading is reliable only from 4 years old
+func FeverThreshold(ageMonths int, method string) float64 {
+	switch method {
+	case TemperatureMethodAxillary:
+		return 37.5
+	case TemperatureMethodOral:
+		if ageMonths >= 48 {
+			return 37.8
+		}
+		return 37.5
+	default:
+		return 38.0
+	}
+}
+
+// IsFever method return if temperature reading is fever for children at birth
+func (t Temperature) IsFever(birth time.Time) bool {
+	age := MonthsBetween(birth, TimeValue(t.MeasuredAt))
+	return Float64Value(t.Value) >= FeverThreshold(age, StringValue(t.Method))
+}
+
+// IsUrgentFever method return if temperature reading is fever needing to see doctor right away
+// any fever under 3 months old or 40°C and over is urgent
+func (t Temperature) IsUrgentFever(birth time.Time) bool {
+	if !t.IsFever(birth) {
+		return false
+	}
+	return MonthsBetween(birth, TimeValue(t.MeasuredAt)) < 3 || Float64Value(t.Value) >= 40.0
+}
+
+// feverEpisodeGap is max gap between fever readings in one episode without normal reading
+const feverEpisodeGap = 24 * time.Hour
+
+// FeverEpisode is period of fever started from fever reading until normal reading
+// EndedAt is nil if episode is ongoing
+type FeverEpisode struct {
+	StartedAt        time.Time     `json:"started_at"`
+	EndedAt          *time.Time    `json:"ended_at"`
+	PeakValue        float64       `json:"peak_value"`
+	PeakMethod       string        `json:"peak_method"`
+	Urgent           bool          `json:"urgent"`
+	AntipyreticCount int64         `json:"antipyretic_count"`
+	Readings         []Temperature `json:"readings"`
+}
+
+// NewFeverEpisodes function group temperatures ordered by measured_at into fever episodes
+// episode is closed by first normal reading, or at last fever reading if next reading is more than a day later
+func NewFeverEpisodes(birth time.Time, temperatures []Temperature) (episodes []FeverEpisode) {
+	episodes = []FeverEpisode{}
+	var cur *FeverEpisode
+	closeAt := func(t time.Time) {
+		cur.EndedAt = Time(t)
+		episodes = append(episodes, *cur)
+		cur = nil
+	}
+
+	for _, t := range temperatures {
+		at := TimeValue(t.MeasuredAt)
+		if cur != nil {
+			last := TimeValue(cur.Readings[len(cur.Readings)-1].MeasuredAt)
+			if at.Sub(last) > feverEpisodeGap {
+				closeAt(last)
+			}
+		}
+
+		if !t.IsFever(birth) {
+			if cur != nil {
+				cur.Readings = append(cur.Readings, t)
+				closeAt(at)
+			}
+			continue
+		}
+
+		if cur == nil {
+			cur = &FeverEpisode{StartedAt: at, Readings: []Temperature{}}
+		}
+		cur.Readings = append(cur.Readings, t)
+		if v := Float64Value(t.Value); v > cur.PeakValue {
+			cur.PeakValue, cur.PeakMethod = v, StringValue(t.Method)
+		}
+		if t.IsUrgentFever(birth) {
+			cur.Urgent = true
+		}
+		if t.AntipyreticName != nil {
+			cur.AntipyreticCount++
+		}
+	}
+
+	if cur != nil {
+		episodes = append(episodes, *cur)
+	}
+	return
+}
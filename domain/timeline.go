@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TimelineUsecase is interface about usecase layer using in delivery layer
+type TimelineUsecase interface {
+	// GetDailyTimeline method return events of children in the date merged from every log ordered by occurred time
+	// events are filtered by types if types is not empty, and next page starts after event with cursor uuid
+	GetDailyTimeline(ctx context.Context, parentUUID, childrenUUID, date string, types []string, cursor string, limit int) (events []TimelineEvent, nextCursor string, err error)
+}
+
+// type value of TimelineEvent
+const (
+	TimelineEventFeeding     = "feeding"
+	TimelineEventSleep       = "sleep"
+	TimelineEventDiaper      = "diaper"
+	TimelineEventTemperature = "temperature"
+	TimelineEventMilestone   = "milestone"
+	TimelineEventExpenditure = "expenditure"
+)
+
+// TimelineEvent is uniform envelope of any child log in timeline
+// Data is original model of log (ex. Feeding, Sleep) and EndedAt is set only for log having period
+type TimelineEvent struct {
+	Type         string      `json:"type"`
+	UUID         string      `json:"uuid"`
+	ChildrenUUID string      `json:"children_uuid"`
+	OccurredAt   time.Time   `json:"occurred_at"`
+	EndedAt      *time.Time  `json:"ended_at,omitempty"`
+	Data         interface{} `json:"data"`
+}
+
+// NewFeedingTimelineEvent function return TimelineEvent wrapping Feeding
+func NewFeedingTimelineEvent(f Feeding) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventFeeding,
+		UUID:         StringValue(f.UUID),
+		ChildrenUUID: StringValue(f.ChildrenUUID),
+		OccurredAt:   TimeValue(f.FedAt),
+		Data:         f,
+	}
+}
+
+// NewSleepTimelineEvent function return TimelineEvent wrapping Sleep
+func NewSleepTimelineEvent(s Sleep) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventSleep,
+		UUID:         StringValue(s.UUID),
+		ChildrenUUID: StringValue(s.ChildrenUUID),
+		OccurredAt:   TimeValue(s.StartedAt),
+		EndedAt:      s.EndedAt,
+		Data:         s,
+	}
+}
+
+// NewDiaperTimelineEvent function return TimelineEvent wrapping Diaper
+func NewDiaperTimelineEvent(d Diaper) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventDiaper,
+		UUID:         StringValue(d.UUID),
+		ChildrenUUID: StringValue(d.ChildrenUUID),
+		OccurredAt:   TimeValue(d.ChangedAt),
+		Data:         d,
+	}
+}
+
+// NewTemperatureTimelineEvent function return TimelineEvent wrapping Temperature
+func NewTemperatureTimelineEvent(t Temperature) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventTemperature,
+		UUID:         StringValue(t.UUID),
+		ChildrenUUID: StringValue(t.ChildrenUUID),
+		OccurredAt:   TimeValue(t.MeasuredAt),
+		Data:         t,
+	}
+}
+
+// NewMilestoneTimelineEvent function return TimelineEvent wrapping MilestoneRecord
+func NewMilestoneTimelineEvent(mr MilestoneRecord) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventMilestone,
+		UUID:         StringValue(mr.UUID),
+		ChildrenUUID: StringValue(mr.ChildrenUUID),
+		OccurredAt:   TimeValue(mr.AchievedAt),
+		Data:         mr,
+	}
+}
+
+// NewExpenditureTimelineEvent function return TimelineEvent wrapping Expenditure tagged with children
+// expenditure shared by babies is in timeline of every tagged children, so ChildrenUUID is given
+func NewExpenditureTimelineEvent(e Expenditure, childrenUUID string) TimelineEvent {
+	return TimelineEvent{
+		Type:         TimelineEventExpenditure,
+		UUID:         StringValue(e.UUID),
+		ChildrenUUID: childrenUUID,
+		OccurredAt:   TimeValue(e.SpentAt),
+		Data:         e,
+	}
+}
+
+// SortTimelineEvents function sort events by occurred time, and by uuid for events occurred at same time
+func SortTimelineEvents(events []TimelineEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].OccurredAt.Before(events[j].OccurredAt)
+		}
+		return events[i].UUID < events[j].UUID
+	})
+}
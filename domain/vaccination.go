@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// VaccinationUsecase is interface about usecase layer using in delivery layer
+type VaccinationUsecase interface {
+	// GetVaccinationSchedule method return doses of children's schedule filtered by status (all status if empty)
+	GetVaccinationSchedule(ctx context.Context, parentUUID, childrenUUID, status string) (doses []VaccinationDose, err error)
+
+	// MarkDoseAdministered method store record that a dose of children's schedule is administered
+	MarkDoseAdministered(ctx context.Context, parentUUID string, vr *VaccinationRecord) (uuid string, err error)
+}
+
+// VaccinationRecordRepository is repository interface about VaccinationRecord model
+type VaccinationRecordRepository interface {
+	GetByUUID(ctx tx.Context, uuid string) (VaccinationRecord, error)
+	GetByChildrenUUID(ctx tx.Context, childrenUUID string) ([]VaccinationRecord, error)
+	GetAvailableUUID(ctx tx.Context) (*string, error)
+	Store(ctx tx.Context, vr *VaccinationRecord) error
+}
+
+// status value of VaccinationDose
+const (
+	VaccinationDoseUpcoming  = "upcoming"
+	VaccinationDoseOverdue   = "overdue"
+	VaccinationDoseCompleted = "completed"
+)
+
+// VaccinationSchedule is versioned national immunization table that children schedule is built from
+type VaccinationSchedule struct {
+	Version  string               `json:"version"`
+	Country  string               `json:"country"`
+	Vaccines []VaccinationVaccine `json:"vaccines"`
+}
+
+// VaccinationVaccine is vaccine & its doses in VaccinationSchedule
+type VaccinationVaccine struct {
+	Code    string                    `json:"code"`
+	Name    string                    `json:"name"`
+	Disease string                    `json:"disease"`
+	Sex     string                    `json:"sex,omitempty"`
+	Doses   []VaccinationScheduleDose `json:"doses"`
+}
+
+// VaccinationScheduleDose is recommended age window of one dose (ex. 0d, 4w, 2m, 6y)
+type VaccinationScheduleDose struct {
+	Number int64  `json:"number"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+// GetVaccine method return vaccine with code in schedule
+func (vs VaccinationSchedule) GetVaccine(code string) (VaccinationVaccine, bool) {
+	for _, v := range vs.Vaccines {
+		if v.Code == code {
+			return v, true
+		}
+	}
+	return VaccinationVaccine{}, false
+}
+
+// HasDose method return if vaccine have dose with number
+func (vv VaccinationVaccine) HasDose(number int64) bool {
+	for _, d := range vv.Doses {
+		if d.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+// AddScheduleAge function return t added age string (ex. 0d, 4w, 2m, 6y)
+func AddScheduleAge(t time.Time, age string) (time.Time, error) {
+	if len(age) < 2 {
+		return t, fmt.Errorf("invalid schedule age %q", age)
+	}
+
+	n, err := strconv.Atoi(age[:len(age)-1])
+	if err != nil {
+		return t, fmt.Errorf("invalid schedule age %q", age)
+	}
+
+	switch age[len(age)-1] {
+	case 'd':
+		return t.AddDate(0, 0, n), nil
+	case 'w':
+		return t.AddDate(0, 0, n*7), nil
+	case 'm':
+		return t.AddDate(0, n, 0), nil
+	case 'y':
+		return t.AddDate(n, 0, 0), nil
+	}
+	return t, fmt.Errorf("invalid schedule age unit %q", age)
+}
+
+// VaccinationDose is dose of children's schedule built from VaccinationSchedule & VaccinationRecord
+type VaccinationDose struct {
+	VaccineCode string             `json:"vaccine_code"`
+	VaccineName string             `json:"vaccine_name"`
+	Disease     string             `json:"disease"`
+	DoseNumber  int64              `json:"dose_number"`
+	StartDate   time.Time          `json:"start_date"`
+	EndDate     time.Time          `json:"end_date"`
+	Status      string             `json:"status"`
+	Record      *VaccinationRecord `json:"record,omitempty"`
+}
+
+// VaccinationRecord is model represent administered vaccination dose using in vaccination domain
+type VaccinationRecord struct {
+	UUID            *string    `db:"uuid" json:"uuid" validate:"required,uuid=vaccination"`
+	ChildrenUUID    *string    `db:"children_uuid" json:"children_uuid" validate:"required,uuid=children"`
+	VaccineCode     *string    `db:"vaccine_code" json:"vaccine_code" validate:"required,min=1,max=20"`
+	DoseNumber      *int64     `db:"dose_number" json:"dose_number" validate:"range=1~20"`
+	ScheduleVersion *string    `db:"schedule_version" json:"schedule_version" validate:"required,max=20"`
+	AdministeredAt  *time.Time `db:"administered_at" json:"administered_at" validate:"required"`
+	Hospital        *string    `db:"hospital" json:"hospital" validate:"max=50"`
+	LotNumber       *string    `db:"lot_number" json:"lot_number" validate:"max=30"`
+}
+
+// TableName return table name about VaccinationRecord model
+func (_ VaccinationRecord) TableName() string {
+	return "vaccination_record"
+}
+
+// Schema return rdbms schema about VaccinationRecord model
+func (_ VaccinationRecord) Schema() string {
+	return `CREATE TABLE vaccination_record (
+		uuid             CHAR(11)    NOT NULL,
+		children_uuid    CHAR(11)    NOT NULL,
+		vaccine_code     VARCHAR(20) NOT NULL,
+		dose_number      INT(2)      NOT NULL,
+		schedule_version VARCHAR(20) NOT NULL,
+		administered_at  DATETIME    NOT NULL,
+		hospital         VARCHAR(50),
+		lot_number       VARCHAR(30),
+		PRIMARY KEY (uuid),
+		UNIQUE (children_uuid, vaccine_code, dose_number),
+		FOREIGN KEY (children_uuid)
+			REFERENCES children (uuid)
+			ON DELETE CASCADE
+	)
+`
+}
+
+// GenerateRandomUUID generate & return random uuid value
+func (vr VaccinationRecord) GenerateRandomUUID() string {
+	rand.Seed(time.Now().UnixNano())
+	is := []rune("0123456789")
+	random := make([]rune, 10)
+	for i := range random {
+		random[i] = is[rand.Intn(len(is))]
+	}
+	return fmt.Sprintf("v%s", string(random))
+}
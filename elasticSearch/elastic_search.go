@@ -2,10 +2,13 @@ package elasticSearch
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/pkg/errors"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"strings"
 )
 
@@ -29,13 +32,138 @@ func New(esEndPoint string) *elasticSearch {
 	}
 }
 
-func (es *elasticSearch) Create(ctx context.Context, index string, s string) (err error) {
+// Create method index document s with id in index
+func (es *elasticSearch) Create(ctx context.Context, index, id, s string) (err error) {
 	req := esapi.IndexRequest{
-		Index: index,
+		Index:      index,
+		DocumentID: id,
+		Body:       strings.NewReader(s),
+	}
+
+	res, err := req.Do(ctx, es.es)
+	if err != nil {
+		return errors.Wrap(err, "failed to request index API")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		err = errors.Errorf("index API return error response, %s", res.String())
+	}
+	return
+}
+
+// Update method replace document with id in index to document s
+func (es *elasticSearch) Update(ctx context.Context, index, id, s string) (err error) {
+	// document is always sent as a whole, so index API is used to replace it
+	return es.Create(ctx, index, id, s)
+}
+
+// Delete method delete document with id in index, document not exist is not treated as error
+func (es *elasticSearch) Delete(ctx context.Context, index, id string) (err error) {
+	req := esapi.DeleteRequest{
+		Index:      index,
+		DocumentID: id,
+	}
+
+	res, err := req.Do(ctx, es.es)
+	if err != nil {
+		return errors.Wrap(err, "failed to request delete API")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		err = errors.Errorf("delete API return error response, %s", res.String())
+	}
+	return
+}
+
+// Search method search index with query body s & return raw response body
+func (es *elasticSearch) Search(ctx context.Context, index, s string) (body []byte, err error) {
+	req := esapi.SearchRequest{
+		Index: []string{index},
 		Body:  strings.NewReader(s),
 	}
 
-	_, err = req.Do(ctx, es.es)
+	res, err := req.Do(ctx, es.es)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request search API")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("search API return error response, %s", res.String())
+	}
+
+	if body, err = ioutil.ReadAll(res.Body); err != nil {
+		err = errors.Wrap(err, "failed to read search API response")
+	}
+	return
+}
+
+// BulkIndex method index documents in docs (document id to document) in index with one bulk API request
+func (es *elasticSearch) BulkIndex(ctx context.Context, index string, docs map[string]string) (err error) {
+	var body strings.Builder
+	for id, doc := range docs {
+		meta, _ := json.Marshal(map[string]interface{}{"index": map[string]string{"_index": index, "_id": id}})
+		body.Write(meta)
+		body.WriteString("\n" + doc + "\n")
+	}
+	return es.bulk(ctx, body.String())
+}
+
+// BulkDelete method delete documents with ids in index with one bulk API request, document not exist is not treated as error
+func (es *elasticSearch) BulkDelete(ctx context.Context, index string, ids []string) (err error) {
+	var body strings.Builder
+	for _, id := range ids {
+		meta, _ := json.Marshal(map[string]interface{}{"delete": map[string]string{"_index": index, "_id": id}})
+		body.Write(meta)
+		body.WriteString("\n")
+	}
+	return es.bulk(ctx, body.String())
+}
+
+// bulk method request bulk API with ndjson body s, error of any action in it is returned as error
+func (es *elasticSearch) bulk(ctx context.Context, s string) (err error) {
+	if s == "" {
+		return
+	}
+
+	req := esapi.BulkRequest{
+		Body: strings.NewReader(s),
+	}
+
+	res, err := req.Do(ctx, es.es)
+	if err != nil {
+		return errors.Wrap(err, "failed to request bulk API")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("bulk API return error response, %s", res.String())
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return errors.Wrap(err, "failed to decode bulk API response")
+	}
+	if !resp.Errors {
+		return
+	}
 
+	for _, item := range resp.Items {
+		for action, result := range item {
+			if result.Status >= http.StatusBadRequest && !(action == "delete" && result.Status == http.StatusNotFound) {
+				return errors.Errorf("bulk API failed to %s document, %s", action, result.Error.Reason)
+			}
+		}
+	}
 	return
 }
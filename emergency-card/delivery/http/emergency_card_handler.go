@@ -0,0 +1,252 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// defaultShareHours is hours until share token expire if not requested
+const defaultShareHours = 24
+
+// emergencyCardHandler represent the http handler for emergency card
+type emergencyCardHandler struct {
+	eUsecase   domain.EmergencyCardUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewEmergencyCardHandler will initialize the emergency card resources endpoint
+func NewEmergencyCardHandler(r *gin.Engine, eu domain.EmergencyCardUsecase, v validator, jh jwtHandler) {
+	h := &emergencyCardHandler{
+		eUsecase:   eu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.PUT("children/uuid/:children_uuid/emergency-profile", h.jwtHandler.ParseUUIDFromToken, h.SetEmergencyProfile)
+	r.GET("children/uuid/:children_uuid/emergency-card", h.jwtHandler.ParseUUIDFromToken, h.GetEmergencyCard)
+	r.POST("children/uuid/:children_uuid/emergency-card/shares", h.jwtHandler.ParseUUIDFromToken, h.CreateEmergencyShare)
+	r.GET("children/uuid/:children_uuid/emergency-card/shares", h.jwtHandler.ParseUUIDFromToken, h.GetEmergencyShares)
+	r.DELETE("children/uuid/:children_uuid/emergency-card/shares/token/:token", h.jwtHandler.ParseUUIDFromToken, h.RevokeEmergencyShare)
+
+	// shared card is opened by daycare staff or babysitter without account
+	r.GET("emergency-cards/token/:token", h.GetSharedEmergencyCard)
+}
+
+// SetEmergencyProfile deliver data to SetEmergencyProfile of domain.EmergencyCardUsecase
+func (eh *emergencyCardHandler) SetEmergencyProfile(c *gin.Context) {
+	req := new(setEmergencyProfileRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	ep := &domain.EmergencyProfile{
+		ChildrenUUID:      domain.String(req.ChildrenUUID),
+		ChronicConditions: domain.String(req.ChronicConditions),
+		ClinicName:        domain.String(req.ClinicName),
+		ClinicPhone:       domain.String(req.ClinicPhone),
+		Note:              domain.String(req.Note),
+		Contacts:          []domain.EmergencyContact{},
+	}
+	if req.BloodType != "" {
+		ep.BloodType = domain.String(req.BloodType)
+	}
+	for _, contact := range req.Contacts {
+		ec := domain.EmergencyContact{
+			Name:        domain.String(contact.Name),
+			Relation:    domain.String(contact.Relation),
+			PhoneNumber: domain.String(contact.PhoneNumber),
+		}
+		if contact.Priority != 0 {
+			ec.Priority = domain.Int64(contact.Priority)
+		}
+		ep.Contacts = append(ep.Contacts, ec)
+	}
+
+	switch err := eh.eUsecase.SetEmergencyProfile(c.Request.Context(), c.GetString("uuid"), ep); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to set emergency profile"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "SetEmergencyProfile return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetEmergencyCard deliver data to GetEmergencyCard of domain.EmergencyCardUsecase
+func (eh *emergencyCardHandler) GetEmergencyCard(c *gin.Context) {
+	req := new(getEmergencyCardRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	card, err := eh.eUsecase.GetEmergencyCard(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get emergency card")
+		resp["card"] = card
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetEmergencyCard return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// CreateEmergencyShare deliver data to CreateEmergencyShare of domain.EmergencyCardUsecase
+func (eh *emergencyCardHandler) CreateEmergencyShare(c *gin.Context) {
+	req := new(createEmergencyShareRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	if req.ExpiresInHours == 0 {
+		req.ExpiresInHours = defaultShareHours
+	}
+
+	share, err := eh.eUsecase.CreateEmergencyShare(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, time.Duration(req.ExpiresInHours)*time.Hour)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create emergency card share")
+		resp["token"] = domain.StringValue(share.Token)
+		resp["share_uri"] = fmt.Sprintf("/emergency-cards/token/%s", domain.StringValue(share.Token))
+		resp["expires_at"] = share.ExpiresAt
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateEmergencyShare return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetEmergencyShares deliver data to GetEmergencyShares of domain.EmergencyCardUsecase
+func (eh *emergencyCardHandler) GetEmergencyShares(c *gin.Context) {
+	req := new(getEmergencyCardRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	shares, err := eh.eUsecase.GetEmergencyShares(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get emergency card shares")
+		resp["shares"] = shares
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetEmergencyShares return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// RevokeEmergencyShare deliver data to RevokeEmergencyShare of domain.EmergencyCardUsecase
+func (eh *emergencyCardHandler) RevokeEmergencyShare(c *gin.Context) {
+	req := new(revokeEmergencyShareRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := eh.eUsecase.RevokeEmergencyShare(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Token); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to revoke emergency card share"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "RevokeEmergencyShare return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetSharedEmergencyCard deliver data to GetSharedEmergencyCard of domain.EmergencyCardUsecase
+// card is rendered as html page if format is html, and as json otherwise
+func (eh *emergencyCardHandler) GetSharedEmergencyCard(c *gin.Context) {
+	req := new(getSharedEmergencyCardRequest)
+	if err := eh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	card, err := eh.eUsecase.GetSharedEmergencyCard(c.Request.Context(), req.Token)
+	switch tErr := err.(type) {
+	case nil:
+		break
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+		return
+	default:
+		msg := errors.Wrap(err, "GetSharedEmergencyCard return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+		return
+	}
+
+	// shared card must not be cached by browser or proxy after revoke
+	c.Header("Cache-Control", "no-store")
+	if req.Format != "html" {
+		resp := defaultResp(http.StatusOK, 0, "succeed to get shared emergency card")
+		resp["card"] = card
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if err := emergencyCardTemplate.Execute(buf, card); err != nil {
+		msg := errors.Wrap(err, "failed to render emergency card").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (eh *emergencyCardHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := eh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
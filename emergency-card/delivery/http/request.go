@@ -0,0 +1,79 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// setEmergencyProfileRequest is request for emergencyCardHandler.SetEmergencyProfile
+// emergency contacts of children are replaced with Contacts, ordered by index if priority is empty
+type setEmergencyProfileRequest struct {
+	ChildrenUUID      string `uri:"children_uuid" validate:"required,uuid=children"`
+	BloodType         string `json:"blood_type" validate:"omitempty,oneof=A+ A- B+ B- O+ O- AB+ AB-"`
+	ChronicConditions string `json:"chronic_conditions" validate:"max=500"`
+	ClinicName        string `json:"clinic_name" validate:"max=50"`
+	ClinicPhone       string `json:"clinic_phone" validate:"max=20"`
+	Note              string `json:"note" validate:"max=500"`
+	Contacts          []struct {
+		Name        string `json:"name" validate:"required,min=1,max=20"`
+		Relation    string `json:"relation" validate:"required,min=1,max=20"`
+		PhoneNumber string `json:"phone_number" validate:"required,min=1,max=20"`
+		Priority    int64  `json:"priority" validate:"range=0~10"`
+	} `json:"contacts" validate:"max=10,dive"`
+}
+
+func (r *setEmergencyProfileRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getEmergencyCardRequest is request for emergencyCardHandler.GetEmergencyCard & GetEmergencyShares
+type getEmergencyCardRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getEmergencyCardRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// createEmergencyShareRequest is request for emergencyCardHandler.CreateEmergencyShare
+// share token expire after ExpiresInHours (24 if empty)
+type createEmergencyShareRequest struct {
+	ChildrenUUID   string `uri:"children_uuid" validate:"required,uuid=children"`
+	ExpiresInHours int64  `json:"expires_in_hours" validate:"range=0~720"`
+}
+
+func (r *createEmergencyShareRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	if c.Request.ContentLength == 0 {
+		return nil
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// revokeEmergencyShareRequest is request for emergencyCardHandler.RevokeEmergencyShare
+type revokeEmergencyShareRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Token        string `uri:"token" validate:"required,len=64,hexadecimal"`
+}
+
+func (r *revokeEmergencyShareRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// getSharedEmergencyCardRequest is request for emergencyCardHandler.GetSharedEmergencyCard
+type getSharedEmergencyCardRequest struct {
+	Token  string `uri:"token" validate:"required,len=64,hexadecimal"`
+	Format string `form:"format" validate:"omitempty,oneof=json html"`
+}
+
+func (r *getSharedEmergencyCardRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"html/template"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// emergencyCardTemplate is html template rendering domain.EmergencyCard for shared link
+var emergencyCardTemplate = template.Must(template.New("emergency_card").Funcs(template.FuncMap{
+	"date": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.In(domain.ServiceLocation).Format("2006-01-02")
+	},
+	"datetime": func(t time.Time) string {
+		return t.In(domain.ServiceLocation).Format("2006-01-02 15:04")
+	},
+	"str": domain.StringValue,
+}).Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.ChildrenName}} 응급 카드</title>
+<style>
+body { font-family: sans-serif; margin: 16px; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.1em; margin-top: 20px; border-bottom: 1px solid #ddd; }
+.warn { color: #c0392b; font-weight: bold; }
+small { color: #888; }
+</style>
+</head>
+<body>
+<h1>{{.ChildrenName}} 응급 카드</h1>
+<p>{{with date .Birth}}생년월일 {{.}}{{end}}{{with .Sex}} · {{.}}{{end}}{{with .BloodType}} · 혈액형 {{.}}{{end}}</p>
+
+<h2>알레르기</h2>
+{{if .Allergies}}<ul>{{range .Allergies}}<li class="warn">{{str .Allergen}} ({{str .Severity}}){{with str .Note}} - {{.}}{{end}}</li>{{end}}</ul>{{else}}<p>없음</p>{{end}}
+
+<h2>기저 질환</h2>
+<p>{{if .ChronicConditions}}{{.ChronicConditions}}{{else}}없음{{end}}</p>
+
+<h2>복용 중인 약</h2>
+{{if .Medications}}<ul>{{range .Medications}}<li>{{str .Name}} {{str .Dose}} ({{str .Frequency}})</li>{{end}}</ul>{{else}}<p>없음</p>{{end}}
+
+<h2>비상 연락처</h2>
+{{if .Contacts}}<ul>{{range .Contacts}}<li>{{str .Name}} ({{str .Relation}}) <a href="tel:{{str .PhoneNumber}}">{{str .PhoneNumber}}</a></li>{{end}}</ul>{{else}}<p>없음</p>{{end}}
+
+<h2>소아과</h2>
+<p>{{if .ClinicName}}{{.ClinicName}}{{with .ClinicPhone}} <a href="tel:{{.}}">{{.}}</a>{{end}}{{else}}없음{{end}}</p>
+{{with .Note}}
+<h2>메모</h2>
+<p>{{.}}</p>
+{{end}}
+<p><small>{{datetime .GeneratedAt}} 기준</small></p>
+</body>
+</html>
+`))
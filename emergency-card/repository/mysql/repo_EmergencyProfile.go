@@ -0,0 +1,201 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// emergencyProfileRepository is implementation of domain.EmergencyProfileRepository using mysql
+type emergencyProfileRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// EmergencyProfileRepository return implementation of domain.EmergencyProfileRepository using mysql
+func EmergencyProfileRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.EmergencyProfileRepository {
+	repo := &emergencyProfileRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.EmergencyProfile{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate emergency profile model").Error())
+	}
+	if err := repo.migrator.MigrateModel(repo.db, domain.EmergencyContact{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate emergency contact model").Error())
+	}
+	return repo
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.EmergencyProfileRepository interface
+func (er *emergencyProfileRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (ep domain.EmergencyProfile, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("emergency_profile").Where("children_uuid = ?", childrenUUID).ToSql()
+
+	switch err = _tx.Get(&ep, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select emergency profile")}
+	default:
+		err = errors.Wrap(err, "select emergency profile return unexpected error")
+	}
+	return
+}
+
+// Upsert is implement Upsert method of domain.EmergencyProfileRepository interface
+// profile columns are overwritten if profile of that children already exist
+func (er *emergencyProfileRepository) Upsert(ctx tx.Context, ep *domain.EmergencyProfile) (err error) {
+	if err = er.validator.ValidateStruct(ep); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.EmergencyProfile")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("emergency_profile").
+		Columns("children_uuid", "blood_type", "chronic_conditions", "clinic_name", "clinic_phone", "note", "updated_at").
+		Values(ep.ChildrenUUID, ep.BloodType, ep.ChronicConditions, ep.ClinicName, ep.ClinicPhone, ep.Note, ep.UpdatedAt).
+		Suffix("ON DUPLICATE KEY UPDATE blood_type = VALUES(blood_type), chronic_conditions = VALUES(chronic_conditions), " +
+			"clinic_name = VALUES(clinic_name), clinic_phone = VALUES(clinic_phone), note = VALUES(note), updated_at = VALUES(updated_at)").
+		ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to upsert emergency profile")
+			fk := er.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "upsert emergency profile return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "upsert emergency profile return unexpected error type")
+	}
+	return
+}
+
+// StoreContact is implement StoreContact method of domain.EmergencyProfileRepository interface
+func (er *emergencyProfileRepository) StoreContact(ctx tx.Context, ec *domain.EmergencyContact) (err error) {
+	if domain.StringValue(ec.UUID) == "" {
+		if ec.UUID, err = er.GetAvailableContactUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableContactUUID")
+		}
+	}
+
+	if err = er.validator.ValidateStruct(ec); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.EmergencyContact")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("emergency_contact").
+		Columns("uuid", "children_uuid", "name", "relation", "phone_number", "priority").
+		Values(ec.UUID, ec.ChildrenUUID, ec.Name, ec.Relation, ec.PhoneNumber, ec.Priority).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert emergency contact")
+			_, key := er.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert emergency contact")
+			fk := er.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert emergency contact return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert emergency contact return unexpected error type")
+	}
+	return
+}
+
+// GetContactByUUID is implement GetContactByUUID method of domain.EmergencyProfileRepository interface
+func (er *emergencyProfileRepository) GetContactByUUID(ctx tx.Context, uuid string) (ec domain.EmergencyContact, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("emergency_contact").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&ec, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select emergency contact")}
+	default:
+		err = errors.Wrap(err, "select emergency contact return unexpected error")
+	}
+	return
+}
+
+// GetContactsByChildrenUUID is implement GetContactsByChildrenUUID method of domain.EmergencyProfileRepository interface
+func (er *emergencyProfileRepository) GetContactsByChildrenUUID(ctx tx.Context, childrenUUID string) (ecs []domain.EmergencyContact, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("emergency_contact").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("priority").ToSql()
+
+	ecs = []domain.EmergencyContact{}
+	if err = _tx.Select(&ecs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select emergency contacts return unexpected error")
+	}
+	return
+}
+
+// DeleteContactsByChildrenUUID is implement DeleteContactsByChildrenUUID method of domain.EmergencyProfileRepository interface
+func (er *emergencyProfileRepository) DeleteContactsByChildrenUUID(ctx tx.Context, childrenUUID string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("emergency_contact").Where("children_uuid = ?", childrenUUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "delete emergency contacts return unexpected error")
+	}
+	return
+}
+
+// GetAvailableContactUUID method return available uuid of emergency contact table
+func (er *emergencyProfileRepository) GetAvailableContactUUID(ctx tx.Context) (*string, error) {
+	ec := new(domain.EmergencyContact)
+
+	for {
+		uuid := ec.GenerateRandomUUID()
+		_, err := er.GetContactByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetContactByUUID")
+		}
+	}
+}
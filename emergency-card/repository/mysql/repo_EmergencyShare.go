@@ -0,0 +1,133 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// emergencyShareRepository is implementation of domain.EmergencyShareRepository using mysql
+type emergencyShareRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// EmergencyShareRepository return implementation of domain.EmergencyShareRepository using mysql
+func EmergencyShareRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.EmergencyShareRepository {
+	repo := &emergencyShareRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.EmergencyShare{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate emergency share model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.EmergencyShareRepository interface
+func (er *emergencyShareRepository) Store(ctx tx.Context, es *domain.EmergencyShare) (err error) {
+	if domain.StringValue(es.Token) == "" {
+		token, tErr := es.GenerateRandomToken()
+		if tErr != nil {
+			return errors.Wrap(tErr, "failed to GenerateRandomToken")
+		}
+		es.Token = domain.String(token)
+	}
+
+	if err = er.validator.ValidateStruct(es); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.EmergencyShare")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("emergency_share").
+		Columns("token", "children_uuid", "created_at", "expires_at", "revoked_at").
+		Values(es.Token, es.ChildrenUUID, es.CreatedAt, es.ExpiresAt, es.RevokedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert emergency share")
+			_, key := er.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert emergency share")
+			fk := er.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert emergency share return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert emergency share return unexpected error type")
+	}
+	return
+}
+
+// GetByToken is implement GetByToken method of domain.EmergencyShareRepository interface
+func (er *emergencyShareRepository) GetByToken(ctx tx.Context, token string) (es domain.EmergencyShare, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("emergency_share").Where("token = ?", token).ToSql()
+
+	switch err = _tx.Get(&es, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select emergency share")}
+	default:
+		err = errors.Wrap(err, "select emergency share return unexpected error")
+	}
+	return
+}
+
+// GetAvailableByChildrenUUID is implement GetAvailableByChildrenUUID method of domain.EmergencyShareRepository interface
+func (er *emergencyShareRepository) GetAvailableByChildrenUUID(ctx tx.Context, childrenUUID string, now time.Time) (ess []domain.EmergencyShare, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("emergency_share").
+		Where("children_uuid = ?", childrenUUID).
+		Where("expires_at > ?", now).
+		Where("revoked_at IS NULL").
+		OrderBy("expires_at").ToSql()
+
+	ess = []domain.EmergencyShare{}
+	if err = _tx.Select(&ess, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select emergency shares return unexpected error")
+	}
+	return
+}
+
+// Revoke is implement Revoke method of domain.EmergencyShareRepository interface
+func (er *emergencyShareRepository) Revoke(ctx tx.Context, token string, revokedAt time.Time) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("emergency_share").
+		Set("revoked_at", revokedAt).
+		Where("token = ?", token).
+		Where("revoked_at IS NULL").ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "update emergency share return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("emergency share with that token is not exist or already revoked")}
+	}
+	return
+}
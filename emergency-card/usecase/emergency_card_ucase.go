@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// emergencyCardUsecase is used for usecase layer which implement domain.EmergencyCardUsecase interface
+type emergencyCardUsecase struct {
+	// emergencyProfileRepository is repository interface about domain.EmergencyProfile & domain.EmergencyContact model
+	emergencyProfileRepository domain.EmergencyProfileRepository
+
+	// emergencyShareRepository is repository interface about domain.EmergencyShare model
+	emergencyShareRepository domain.EmergencyShareRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// childrenAllergyRepository is repository interface about domain.ChildrenAllergy model
+	childrenAllergyRepository domain.ChildrenAllergyRepository
+
+	// medicationRepository is repository interface about domain.Medication model
+	medicationRepository domain.MedicationRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// EmergencyCardUsecase return implementation of domain.EmergencyCardUsecase
+func EmergencyCardUsecase(
+	epr domain.EmergencyProfileRepository,
+	esr domain.EmergencyShareRepository,
+	cr domain.ChildrenRepository,
+	car domain.ChildrenAllergyRepository,
+	mr domain.MedicationRepository,
+	th txHandler,
+) domain.EmergencyCardUsecase {
+	return &emergencyCardUsecase{
+		emergencyProfileRepository: epr,
+		emergencyShareRepository:   esr,
+		childrenRepository:         cr,
+		childrenAllergyRepository:  car,
+		medicationRepository:       mr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// SetEmergencyProfile implement SetEmergencyProfile method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) SetEmergencyProfile(ctx context.Context, parentUUID string, ep *domain.EmergencyProfile) (err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnChildren(_tx, parentUUID, domain.StringValue(ep.ChildrenUUID)); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	ep.UpdatedAt = domain.Time(time.Now())
+	switch err = eu.emergencyProfileRepository.Upsert(_tx, ep); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "emergency profile Upsert return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "emergency profile Upsert return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = eu.emergencyProfileRepository.DeleteContactsByChildrenUUID(_tx, domain.StringValue(ep.ChildrenUUID)); err != nil {
+		err = errors.Wrap(err, "emergency profile DeleteContactsByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	for i := range ep.Contacts {
+		ec := &ep.Contacts[i]
+		ec.ChildrenUUID = ep.ChildrenUUID
+		if ec.Priority == nil {
+			ec.Priority = domain.Int64(int64(i + 1))
+		}
+
+		switch err = eu.emergencyProfileRepository.StoreContact(_tx, ec); err.(type) {
+		case nil:
+			break
+		case domain.ErrInvalidModel:
+			err = errors.Wrap(err, "emergency profile StoreContact return invalid model")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		default:
+			err = errors.Wrap(err, "emergency profile StoreContact return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// GetEmergencyCard implement GetEmergencyCard method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) GetEmergencyCard(ctx context.Context, parentUUID, childrenUUID string) (card domain.EmergencyCard, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := eu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if card, err = eu.buildEmergencyCard(_tx, c); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// CreateEmergencyShare implement CreateEmergencyShare method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) CreateEmergencyShare(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	ttl time.Duration,
+) (share domain.EmergencyShare, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	now := time.Now()
+	share = domain.EmergencyShare{
+		ChildrenUUID: domain.String(childrenUUID),
+		CreatedAt:    domain.Time(now),
+		ExpiresAt:    domain.Time(now.Add(ttl)),
+	}
+	switch err = eu.emergencyShareRepository.Store(_tx, &share); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "emergency share Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "emergency share Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// GetEmergencyShares implement GetEmergencyShares method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) GetEmergencyShares(ctx context.Context, parentUUID, childrenUUID string) (shares []domain.EmergencyShare, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if shares, err = eu.emergencyShareRepository.GetAvailableByChildrenUUID(_tx, childrenUUID, time.Now()); err != nil {
+		err = errors.Wrap(err, "emergency share GetAvailableByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// RevokeEmergencyShare implement RevokeEmergencyShare method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) RevokeEmergencyShare(ctx context.Context, parentUUID, childrenUUID, token string) (err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = eu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch es, sErr := eu.emergencyShareRepository.GetByToken(_tx, token); sErr.(type) {
+	case nil:
+		if domain.StringValue(es.ChildrenUUID) != childrenUUID {
+			err = errors.New("that share token is not of that children")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = eu.txHandler.Rollback(_tx)
+			return
+		}
+	case domain.ErrRowNotExist:
+		err = errors.New("share token is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(sErr, "emergency share GetByToken return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = eu.emergencyShareRepository.Revoke(_tx, token, time.Now()); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("share token is already revoked")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "emergency share Revoke return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// GetSharedEmergencyCard implement GetSharedEmergencyCard method of domain.EmergencyCardUsecase interface
+func (eu *emergencyCardUsecase) GetSharedEmergencyCard(ctx context.Context, token string) (card domain.EmergencyCard, err error) {
+	_tx, err := eu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	es, err := eu.emergencyShareRepository.GetByToken(_tx, token)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("share token is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "emergency share GetByToken return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !es.IsAvailable(time.Now()) {
+		err = errors.New("share token is expired or revoked")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusGone}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	c, err := eu.childrenRepository.GetByUUID(_tx, domain.StringValue(es.ChildrenUUID))
+	if err != nil {
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if card, err = eu.buildEmergencyCard(_tx, c); err != nil {
+		_ = eu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = eu.txHandler.Commit(_tx)
+	return
+}
+
+// buildEmergencyCard method return emergency card of children with profile, allergies & medications
+// card without profile is returned if parent didn't set emergency profile yet
+func (eu *emergencyCardUsecase) buildEmergencyCard(_tx tx.Context, c domain.Children) (card domain.EmergencyCard, err error) {
+	childrenUUID := domain.StringValue(c.UUID)
+
+	ep, err := eu.emergencyProfileRepository.GetByChildrenUUID(_tx, childrenUUID)
+	switch err.(type) {
+	case nil, domain.ErrRowNotExist:
+		err = nil
+	default:
+		err = errors.Wrap(err, "emergency profile GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if ep.Contacts, err = eu.emergencyProfileRepository.GetContactsByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "emergency profile GetContactsByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	allergies, err := eu.childrenAllergyRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "children allergy GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	medications, err := eu.medicationRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "medication GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	card = domain.NewEmergencyCard(c, ep, allergies, medications, time.Now())
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (eu *emergencyCardUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = eu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// expenditureBudgetHandler represent the http handler for expenditure budget
+type expenditureBudgetHandler struct {
+	ebUsecase  domain.ExpenditureBudgetUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewExpenditureBudgetHandler will initialize the expenditure budget resources endpoint
+func NewExpenditureBudgetHandler(r *gin.Engine, ebu domain.ExpenditureBudgetUsecase, v validator, jh jwtHandler) {
+	h := &expenditureBudgetHandler{
+		ebUsecase:  ebu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.PUT("expenditure-budgets", h.jwtHandler.ParseUUIDFromToken, h.SetExpenditureBudget)
+	r.GET("expenditure-budgets", h.jwtHandler.ParseUUIDFromToken, h.GetExpenditureBudgetStatuses)
+	r.DELETE("expenditure-budgets/uuid/:budget_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteExpenditureBudget)
+}
+
+// SetExpenditureBudget deliver data to SetExpenditureBudget of domain.ExpenditureBudgetUsecase
+func (ebh *expenditureBudgetHandler) SetExpenditureBudget(c *gin.Context) {
+	req := new(setExpenditureBudgetRequest)
+	if err := ebh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	eb := &domain.ExpenditureBudget{
+		ParentUUID: domain.String(c.GetString("uuid")),
+		Amount:     domain.Int64(req.Amount),
+	}
+	if req.CategoryUUID != "" {
+		eb.CategoryUUID = domain.String(req.CategoryUUID)
+	}
+
+	uuid, err := ebh.ebUsecase.SetExpenditureBudget(c.Request.Context(), eb)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to set expenditure budget")
+		resp["budget_uuid"] = uuid
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "SetExpenditureBudget return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetExpenditureBudgetStatuses deliver data to GetExpenditureBudgetStatuses of domain.ExpenditureBudgetUsecase
+func (ebh *expenditureBudgetHandler) GetExpenditureBudgetStatuses(c *gin.Context) {
+	req := new(getExpenditureBudgetStatusesRequest)
+	if err := ebh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	statuses, err := ebh.ebUsecase.GetExpenditureBudgetStatuses(c.Request.Context(), c.GetString("uuid"), req.Month)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure budget statuses")
+		resp["budgets"] = statuses
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpenditureBudgetStatuses return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteExpenditureBudget deliver data to DeleteExpenditureBudget of domain.ExpenditureBudgetUsecase
+func (ebh *expenditureBudgetHandler) DeleteExpenditureBudget(c *gin.Context) {
+	req := new(deleteExpenditureBudgetRequest)
+	if err := ebh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ebh.ebUsecase.DeleteExpenditureBudget(c.Request.Context(), c.GetString("uuid"), req.BudgetUUID); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete expenditure budget"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteExpenditureBudget return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (ebh *expenditureBudgetHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := ebh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// setExpenditureBudgetRequest is request for expenditureBudgetHandler.SetExpenditureBudget
+// budget is overall budget if CategoryUUID is not set
+type setExpenditureBudgetRequest struct {
+	CategoryUUID string `json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	Amount       int64  `json:"amount" validate:"required,range=1~1000000000"`
+}
+
+func (r *setExpenditureBudgetRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getExpenditureBudgetStatusesRequest is request for expenditureBudgetHandler.GetExpenditureBudgetStatuses
+// Month is yyyy-mm, current month if not set
+type getExpenditureBudgetStatusesRequest struct {
+	Month string `form:"month" validate:"omitempty,len=7"`
+}
+
+func (r *getExpenditureBudgetStatusesRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// deleteExpenditureBudgetRequest is request for expenditureBudgetHandler.DeleteExpenditureBudget
+type deleteExpenditureBudgetRequest struct {
+	BudgetUUID string `uri:"budget_uuid" validate:"required,uuid=expenditure_budget"`
+}
+
+func (r *deleteExpenditureBudgetRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
@@ -0,0 +1,185 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureBudgetRepository is implementation of domain.ExpenditureBudgetRepository using mysql
+type expenditureBudgetRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// ExpenditureBudgetRepository return implementation of domain.ExpenditureBudgetRepository using mysql
+func ExpenditureBudgetRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.ExpenditureBudgetRepository {
+	repo := &expenditureBudgetRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.ExpenditureBudget{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate expenditure budget model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.ExpenditureBudgetRepository interface
+func (ebr *expenditureBudgetRepository) Store(ctx tx.Context, eb *domain.ExpenditureBudget) (err error) {
+	if domain.StringValue(eb.UUID) == "" {
+		if eb.UUID, err = ebr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = ebr.validator.ValidateStruct(eb); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ExpenditureBudget")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("expenditure_budget").
+		Columns("uuid", "parent_uuid", "category_uuid", "amount", "created_at", "updated_at").
+		Values(eb.UUID, eb.ParentUUID, eb.CategoryUUID, eb.Amount, eb.CreatedAt, eb.UpdatedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert expenditure budget")
+			fk := ebr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert expenditure budget return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert expenditure budget return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.ExpenditureBudgetRepository interface
+func (ebr *expenditureBudgetRepository) GetByUUID(ctx tx.Context, uuid string) (eb domain.ExpenditureBudget, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_budget").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&eb, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select expenditure budget")}
+	default:
+		err = errors.Wrap(err, "select expenditure budget return unexpected error")
+	}
+	return
+}
+
+// GetByParentUUID is implement GetByParentUUID method of domain.ExpenditureBudgetRepository interface
+func (ebr *expenditureBudgetRepository) GetByParentUUID(ctx tx.Context, parentUUID string) (ebs []domain.ExpenditureBudget, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_budget").
+		Where("parent_uuid = ?", parentUUID).
+		OrderBy("created_at", "uuid").ToSql()
+
+	ebs = []domain.ExpenditureBudget{}
+	if err = _tx.Select(&ebs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditure budgets return unexpected error")
+	}
+	return
+}
+
+// Update is implement Update method of domain.ExpenditureBudgetRepository interface
+// only amount of budget can be updated
+func (ebr *expenditureBudgetRepository) Update(ctx tx.Context, eb *domain.ExpenditureBudget) (err error) {
+	if domain.StringValue(eb.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = ebr.validator.ValidateStruct(eb); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ExpenditureBudget")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("expenditure_budget").
+		Set("amount", eb.Amount).
+		Set("updated_at", eb.UpdatedAt).
+		Where("uuid = ?", eb.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "update expenditure budget return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ExpenditureBudgetRepository interface
+func (ebr *expenditureBudgetRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure_budget").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete expenditure budget return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("expenditure budget with that uuid is not exist")}
+	}
+	return
+}
+
+// DeleteByCategoryUUID is implement DeleteByCategoryUUID method of domain.ExpenditureBudgetRepository interface
+func (ebr *expenditureBudgetRepository) DeleteByCategoryUUID(ctx tx.Context, categoryUUID string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure_budget").Where("category_uuid = ?", categoryUUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "delete expenditure budgets return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of expenditure budget table
+func (ebr *expenditureBudgetRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	eb := new(domain.ExpenditureBudget)
+
+	for {
+		uuid := eb.GenerateRandomUUID()
+		_, err := ebr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
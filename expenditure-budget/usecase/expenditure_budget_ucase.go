@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureBudgetUsecase is used for usecase layer which implement domain.ExpenditureBudgetUsecase interface
+type expenditureBudgetUsecase struct {
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
+	// expenditureBudgetRepository is repository interface about domain.ExpenditureBudget model
+	expenditureBudgetRepository domain.ExpenditureBudgetRepository
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// expenditureRepository is repository interface about domain.Expenditure model
+	expenditureRepository domain.ExpenditureRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// ExpenditureBudgetUsecase return implementation of domain.ExpenditureBudgetUsecase
+func ExpenditureBudgetUsecase(
+	dc []domain.ExpenditureCategory,
+	ebr domain.ExpenditureBudgetRepository,
+	ecr domain.ExpenditureCategoryRepository,
+	er domain.ExpenditureRepository,
+	th txHandler,
+) domain.ExpenditureBudgetUsecase {
+	return &expenditureBudgetUsecase{
+		defaultCategories:             dc,
+		expenditureBudgetRepository:   ebr,
+		expenditureCategoryRepository: ecr,
+		expenditureRepository:         er,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// SetExpenditureBudget implement SetExpenditureBudget method of domain.ExpenditureBudgetUsecase interface
+func (ebu *expenditureBudgetUsecase) SetExpenditureBudget(ctx context.Context, eb *domain.ExpenditureBudget) (uuid string, err error) {
+	_tx, err := ebu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	parentUUID := domain.StringValue(eb.ParentUUID)
+	if eb.CategoryUUID != nil {
+		categories, cErr := ebu.getCategories(_tx, parentUUID)
+		if cErr != nil {
+			err = cErr
+			_ = ebu.txHandler.Rollback(_tx)
+			return
+		}
+		if _, ok := categories.Find(*eb.CategoryUUID); !ok {
+			err = errors.New("category with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = ebu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	budgets, err := ebu.expenditureBudgetRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure budget GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	now := time.Now()
+	eb.UpdatedAt = domain.Time(now)
+	for _, budget := range budgets {
+		if domain.StringValue(budget.CategoryUUID) == domain.StringValue(eb.CategoryUUID) {
+			eb.UUID, eb.CreatedAt = budget.UUID, budget.CreatedAt
+			break
+		}
+	}
+
+	if eb.UUID != nil {
+		err = ebu.expenditureBudgetRepository.Update(_tx, eb)
+	} else {
+		eb.CreatedAt = domain.Time(now)
+		err = ebu.expenditureBudgetRepository.Store(_tx, eb)
+	}
+
+	switch tErr := err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "expenditure budget Store or Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrNoReferencedRow:
+		switch tErr.ForeignKey {
+		case "parent_uuid":
+			err = errors.New("parent with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		default:
+			err = errors.Wrap(err, "expenditure budget Store return unexpected no referenced error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure budget Store or Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(eb.UUID)
+	_ = ebu.txHandler.Commit(_tx)
+	return
+}
+
+// GetExpenditureBudgetStatuses implement GetExpenditureBudgetStatuses method of domain.ExpenditureBudgetUsecase interface
+// month is current month if it is empty
+func (ebu *expenditureBudgetUsecase) GetExpenditureBudgetStatuses(
+	ctx context.Context,
+	parentUUID, month string,
+) (statuses []domain.ExpenditureBudgetStatus, err error) {
+	if month == "" {
+		month = domain.MonthOf(time.Now())
+	}
+	from, to, err := domain.MonthRange(month)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse month"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := ebu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	budgets, err := ebu.expenditureBudgetRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure budget GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	categories, err := ebu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	sums, err := ebu.expenditureRepository.SumAmountByCategory(_tx, parentUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure SumAmountByCategory return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	statuses = make([]domain.ExpenditureBudgetStatus, 0, len(budgets))
+	for _, eb := range budgets {
+		statuses = append(statuses, domain.NewExpenditureBudgetStatus(eb, categories, sums, month))
+	}
+
+	_ = ebu.txHandler.Commit(_tx)
+	return
+}
+
+// DeleteExpenditureBudget implement DeleteExpenditureBudget method of domain.ExpenditureBudgetUsecase interface
+func (ebu *expenditureBudgetUsecase) DeleteExpenditureBudget(ctx context.Context, parentUUID, uuid string) (err error) {
+	_tx, err := ebu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	switch eb, gErr := ebu.expenditureBudgetRepository.GetByUUID(_tx, uuid); gErr.(type) {
+	case nil:
+		if domain.StringValue(eb.ParentUUID) != parentUUID {
+			err = errors.New("you can't access to that budget")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+			_ = ebu.txHandler.Rollback(_tx)
+			return
+		}
+	case domain.ErrRowNotExist:
+		err = errors.New("budget with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(gErr, "expenditure budget GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = ebu.expenditureBudgetRepository.Delete(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "expenditure budget Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ebu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = ebu.txHandler.Commit(_tx)
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (ebu *expenditureBudgetUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := ebu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, ebu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// catalogFile is name of embedded default expenditure category catalog file
+const catalogFile = "data/categories.json"
+
+// defaultUUIDPrefix is prefix of default category uuid, custom category uuid never start with it
+const defaultUUIDPrefix = "x0"
+
+//go:embed data/*.json
+var embedded embed.FS
+
+// catalogCategory is category written in catalog file
+type catalogCategory struct {
+	UUID      string `json:"uuid"`
+	UpperUUID string `json:"upper_uuid"`
+	Name      string `json:"name"`
+}
+
+// Load function return default expenditure categories read from embedded catalog file
+// upper category is always placed before its subcategories in catalog
+func Load() (catalog []domain.ExpenditureCategory, err error) {
+	b, err := embedded.ReadFile(catalogFile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read expenditure category catalog file")
+		return
+	}
+
+	var ccs []catalogCategory
+	if err = json.Unmarshal(b, &ccs); err != nil {
+		err = errors.Wrap(err, "failed to unmarshal expenditure category catalog")
+		return
+	}
+
+	catalog = make([]domain.ExpenditureCategory, 0, len(ccs))
+	for _, cc := range ccs {
+		if !strings.HasPrefix(cc.UUID, defaultUUIDPrefix) || cc.Name == "" {
+			err = errors.Errorf("invalid expenditure category in catalog, uuid: %q", cc.UUID)
+			return
+		}
+		if _, ok := domain.ExpenditureCategories(catalog).Find(cc.UUID); ok {
+			err = errors.Errorf("duplicated expenditure category in catalog, uuid: %q", cc.UUID)
+			return
+		}
+
+		ec := domain.ExpenditureCategory{
+			UUID: domain.String(cc.UUID),
+			Name: domain.String(cc.Name),
+		}
+		if cc.UpperUUID != "" {
+			upper, ok := domain.ExpenditureCategories(catalog).Find(cc.UpperUUID)
+			if !ok || upper.UpperUUID != nil {
+				err = errors.Errorf("invalid upper category of expenditure category in catalog, uuid: %q", cc.UUID)
+				return
+			}
+			ec.UpperUUID = domain.String(cc.UpperUUID)
+		}
+		catalog = append(catalog, ec)
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// expenditureCategoryHandler represent the http handler for expenditure category
+type expenditureCategoryHandler struct {
+	ecUsecase  domain.ExpenditureCategoryUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewExpenditureCategoryHandler will initialize the expenditure category resources endpoint
+func NewExpenditureCategoryHandler(r *gin.Engine, ecu domain.ExpenditureCategoryUsecase, v validator, jh jwtHandler) {
+	h := &expenditureCategoryHandler{
+		ecUsecase:  ecu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("expenditure-categories", h.jwtHandler.ParseUUIDFromToken, h.GetExpenditureCategories)
+	r.POST("expenditure-categories", h.jwtHandler.ParseUUIDFromToken, h.CreateExpenditureCategory)
+	r.PATCH("expenditure-categories/uuid/:category_uuid", h.jwtHandler.ParseUUIDFromToken, h.UpdateExpenditureCategory)
+	r.DELETE("expenditure-categories/uuid/:category_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteExpenditureCategory)
+}
+
+// GetExpenditureCategories deliver data to GetExpenditureCategories of domain.ExpenditureCategoryUsecase
+func (ech *expenditureCategoryHandler) GetExpenditureCategories(c *gin.Context) {
+	categories, err := ech.ecUsecase.GetExpenditureCategories(c.Request.Context(), c.GetString("uuid"))
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure categories")
+		resp["categories"] = categories
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpenditureCategories return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// CreateExpenditureCategory deliver data to CreateExpenditureCategory of domain.ExpenditureCategoryUsecase
+func (ech *expenditureCategoryHandler) CreateExpenditureCategory(c *gin.Context) {
+	req := new(createExpenditureCategoryRequest)
+	if err := ech.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	ec := &domain.ExpenditureCategory{
+		ParentUUID: domain.String(c.GetString("uuid")),
+		Name:       domain.String(req.Name),
+	}
+	if req.UpperUUID != "" {
+		ec.UpperUUID = domain.String(req.UpperUUID)
+	}
+
+	uuid, err := ech.ecUsecase.CreateExpenditureCategory(c.Request.Context(), ec)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create expenditure category")
+		resp["category_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateExpenditureCategory return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// UpdateExpenditureCategory deliver data to UpdateExpenditureCategory of domain.ExpenditureCategoryUsecase
+func (ech *expenditureCategoryHandler) UpdateExpenditureCategory(c *gin.Context) {
+	req := new(updateExpenditureCategoryRequest)
+	if err := ech.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ech.ecUsecase.UpdateExpenditureCategory(c.Request.Context(), &domain.ExpenditureCategory{
+		UUID:       domain.String(req.CategoryUUID),
+		ParentUUID: domain.String(c.GetString("uuid")),
+		Name:       domain.String(req.Name),
+	}); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to update expenditure category"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "UpdateExpenditureCategory return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteExpenditureCategory deliver data to DeleteExpenditureCategory of domain.ExpenditureCategoryUsecase
+func (ech *expenditureCategoryHandler) DeleteExpenditureCategory(c *gin.Context) {
+	req := new(deleteExpenditureCategoryRequest)
+	if err := ech.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ech.ecUsecase.DeleteExpenditureCategory(c.Request.Context(), c.GetString("uuid"), req.CategoryUUID); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete expenditure category"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteExpenditureCategory return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (ech *expenditureCategoryHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := ech.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createExpenditureCategoryRequest is request for expenditureCategoryHandler.CreateExpenditureCategory
+// category is created as subcategory of UpperUUID if it is set
+type createExpenditureCategoryRequest struct {
+	Name      string `json:"name" validate:"required,min=1,max=20"`
+	UpperUUID string `json:"upper_uuid" validate:"omitempty,uuid=expenditure_category"`
+}
+
+func (r *createExpenditureCategoryRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// updateExpenditureCategoryRequest is request for expenditureCategoryHandler.UpdateExpenditureCategory
+type updateExpenditureCategoryRequest struct {
+	CategoryUUID string `uri:"category_uuid" validate:"required,uuid=expenditure_category"`
+	Name         string `json:"name" validate:"required,min=1,max=20"`
+}
+
+func (r *updateExpenditureCategoryRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindJSON(r); err != nil {
+		return errors.Wrap(err, "failed to BindJSON")
+	}
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// deleteExpenditureCategoryRequest is request for expenditureCategoryHandler.DeleteExpenditureCategory
+type deleteExpenditureCategoryRequest struct {
+	CategoryUUID string `uri:"category_uuid" validate:"required,uuid=expenditure_category"`
+}
+
+func (r *deleteExpenditureCategoryRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
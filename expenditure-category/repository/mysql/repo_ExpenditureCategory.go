@@ -0,0 +1,173 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureCategoryRepository is implementation of domain.ExpenditureCategoryRepository using mysql
+type expenditureCategoryRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// ExpenditureCategoryRepository return implementation of domain.ExpenditureCategoryRepository using mysql
+func ExpenditureCategoryRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.ExpenditureCategoryRepository {
+	repo := &expenditureCategoryRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.ExpenditureCategory{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate expenditure category model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.ExpenditureCategoryRepository interface
+func (ecr *expenditureCategoryRepository) Store(ctx tx.Context, ec *domain.ExpenditureCategory) (err error) {
+	if domain.StringValue(ec.UUID) == "" {
+		if ec.UUID, err = ecr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = ecr.validator.ValidateStruct(ec); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ExpenditureCategory")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("expenditure_category").
+		Columns("uuid", "parent_uuid", "upper_uuid", "name", "created_at").
+		Values(ec.UUID, ec.ParentUUID, ec.UpperUUID, ec.Name, ec.CreatedAt).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert expenditure category")
+			fk := ecr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert expenditure category return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert expenditure category return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.ExpenditureCategoryRepository interface
+func (ecr *expenditureCategoryRepository) GetByUUID(ctx tx.Context, uuid string) (ec domain.ExpenditureCategory, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_category").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&ec, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select expenditure category")}
+	default:
+		err = errors.Wrap(err, "select expenditure category return unexpected error")
+	}
+	return
+}
+
+// GetByParentUUID is implement GetByParentUUID method of domain.ExpenditureCategoryRepository interface
+func (ecr *expenditureCategoryRepository) GetByParentUUID(ctx tx.Context, parentUUID string) (ecs []domain.ExpenditureCategory, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("expenditure_category").
+		Where("parent_uuid = ?", parentUUID).
+		OrderBy("created_at", "uuid").ToSql()
+
+	ecs = []domain.ExpenditureCategory{}
+	if err = _tx.Select(&ecs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select expenditure categories return unexpected error")
+	}
+	return
+}
+
+// Update is implement Update method of domain.ExpenditureCategoryRepository interface
+// only name of category can be updated
+func (ecr *expenditureCategoryRepository) Update(ctx tx.Context, ec *domain.ExpenditureCategory) (err error) {
+	if domain.StringValue(ec.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = ecr.validator.ValidateStruct(ec); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.ExpenditureCategory")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("expenditure_category").
+		Set("name", ec.Name).
+		Where("uuid = ?", ec.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "update expenditure category return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.ExpenditureCategoryRepository interface
+func (ecr *expenditureCategoryRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("expenditure_category").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete expenditure category return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("expenditure category with that uuid is not exist")}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of expenditure category table
+func (ecr *expenditureCategoryRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	ec := new(domain.ExpenditureCategory)
+
+	for {
+		uuid := ec.GenerateRandomUUID()
+		_, err := ecr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
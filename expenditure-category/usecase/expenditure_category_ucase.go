@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureCategoryUsecase is used for usecase layer which implement domain.ExpenditureCategoryUsecase interface
+type expenditureCategoryUsecase struct {
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// expenditureRepository is repository interface about domain.Expenditure model
+	expenditureRepository domain.ExpenditureRepository
+
+	// expenditureBudgetRepository is repository interface about domain.ExpenditureBudget model
+	expenditureBudgetRepository domain.ExpenditureBudgetRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// ExpenditureCategoryUsecase return implementation of domain.ExpenditureCategoryUsecase
+func ExpenditureCategoryUsecase(
+	dc []domain.ExpenditureCategory,
+	ecr domain.ExpenditureCategoryRepository,
+	er domain.ExpenditureRepository,
+	ebr domain.ExpenditureBudgetRepository,
+	th txHandler,
+) domain.ExpenditureCategoryUsecase {
+	return &expenditureCategoryUsecase{
+		defaultCategories:             dc,
+		expenditureCategoryRepository: ecr,
+		expenditureRepository:         er,
+		expenditureBudgetRepository:   ebr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// GetExpenditureCategories implement GetExpenditureCategories method of domain.ExpenditureCategoryUsecase interface
+func (ecu *expenditureCategoryUsecase) GetExpenditureCategories(ctx context.Context, parentUUID string) (categories []domain.ExpenditureCategory, err error) {
+	_tx, err := ecu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	all, err := ecu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	categories = all.Tree()
+	_ = ecu.txHandler.Commit(_tx)
+	return
+}
+
+// CreateExpenditureCategory implement CreateExpenditureCategory method of domain.ExpenditureCategoryUsecase interface
+func (ecu *expenditureCategoryUsecase) CreateExpenditureCategory(ctx context.Context, ec *domain.ExpenditureCategory) (uuid string, err error) {
+	_tx, err := ecu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	all, err := ecu.getCategories(_tx, domain.StringValue(ec.ParentUUID))
+	if err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if ec.UpperUUID != nil {
+		upper, ok := all.Find(*ec.UpperUUID)
+		if !ok {
+			err = errors.New("upper category with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = ecu.txHandler.Rollback(_tx)
+			return
+		}
+		if upper.UpperUUID != nil {
+			err = errors.New("subcategory can't have subcategory")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = ecu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	if _, ok := all.FindByName(domain.StringValue(ec.UpperUUID), domain.StringValue(ec.Name)); ok {
+		err = errors.New("category with that name already exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ExpenditureCategoryAlreadyExist}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	ec.CreatedAt = domain.Time(time.Now())
+	switch err = ecu.expenditureCategoryRepository.Store(_tx, ec); tErr := err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "expenditure category Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrNoReferencedRow:
+		switch tErr.ForeignKey {
+		case "parent_uuid":
+			err = errors.New("parent with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		default:
+			err = errors.Wrap(err, "expenditure category Store return unexpected no referenced error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure category Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(ec.UUID)
+	_ = ecu.txHandler.Commit(_tx)
+	return
+}
+
+// UpdateExpenditureCategory implement UpdateExpenditureCategory method of domain.ExpenditureCategoryUsecase interface
+func (ecu *expenditureCategoryUsecase) UpdateExpenditureCategory(ctx context.Context, ec *domain.ExpenditureCategory) (err error) {
+	_tx, err := ecu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	parentUUID := domain.StringValue(ec.ParentUUID)
+	cur, err := ecu.getOwnCustomCategory(_tx, parentUUID, domain.StringValue(ec.UUID))
+	if err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	all, err := ecu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+	if same, ok := all.FindByName(domain.StringValue(cur.UpperUUID), domain.StringValue(ec.Name)); ok && domain.StringValue(same.UUID) != domain.StringValue(cur.UUID) {
+		err = errors.New("category with that name already exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ExpenditureCategoryAlreadyExist}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	cur.Name = ec.Name
+	switch err = ecu.expenditureCategoryRepository.Update(_tx, &cur); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "expenditure category Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "expenditure category Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = ecu.txHandler.Commit(_tx)
+	return
+}
+
+// DeleteExpenditureCategory implement DeleteExpenditureCategory method of domain.ExpenditureCategoryUsecase interface
+// category in use is not deleted, so that expenditure & search document never point deleted category
+// budget of deleted category is deleted together
+func (ecu *expenditureCategoryUsecase) DeleteExpenditureCategory(ctx context.Context, parentUUID, uuid string) (err error) {
+	_tx, err := ecu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = ecu.getOwnCustomCategory(_tx, parentUUID, uuid); err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	all, err := ecu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+	if len(all.WithSubcategories(uuid)) > 1 {
+		err = errors.New("category having subcategory can't be deleted")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ExpenditureCategoryInUse}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	expenditures, err := ecu.expenditureRepository.GetByParentUUID(_tx, parentUUID, domain.ExpenditureFilter{
+		CategoryUUIDs: []string{uuid},
+		Sort:          domain.ExpenditureSortName,
+		Limit:         1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "expenditure GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+	if len(expenditures) != 0 {
+		err = errors.New("category used by expenditure can't be deleted")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ExpenditureCategoryInUse}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = ecu.expenditureBudgetRepository.DeleteByCategoryUUID(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "expenditure budget DeleteByCategoryUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = ecu.expenditureCategoryRepository.Delete(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "expenditure category Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = ecu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = ecu.txHandler.Commit(_tx)
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (ecu *expenditureCategoryUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := ecu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, ecu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
+
+// getOwnCustomCategory method return custom category with uuid if parent with parentUUID own that category
+func (ecu *expenditureCategoryUsecase) getOwnCustomCategory(_tx tx.Context, parentUUID, uuid string) (ec domain.ExpenditureCategory, err error) {
+	if _, ok := domain.ExpenditureCategories(ecu.defaultCategories).Find(uuid); ok {
+		err = errors.New("default category can't be changed")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+		return
+	}
+
+	switch ec, err = ecu.expenditureCategoryRepository.GetByUUID(_tx, uuid); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("category with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "expenditure category GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(ec.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that category")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
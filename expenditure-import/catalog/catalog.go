@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// catalogFile is name of embedded expenditure import profile catalog file
+const catalogFile = "data/profiles.json"
+
+//go:embed data/*.json
+var embedded embed.FS
+
+// Load function return expenditure import profiles read from embedded catalog file
+// profile of new card company or bank is added by adding it to catalog file
+func Load() (catalog []domain.ExpenditureImportProfile, err error) {
+	b, err := embedded.ReadFile(catalogFile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read expenditure import profile catalog file")
+		return
+	}
+
+	if err = json.Unmarshal(b, &catalog); err != nil {
+		err = errors.Wrap(err, "failed to unmarshal expenditure import profile catalog")
+		return
+	}
+
+	for i, p := range catalog {
+		if p.Code == "" || p.Name == "" || p.DateColumn == "" || p.NameColumn == "" || p.AmountColumn == "" {
+			err = errors.Errorf("invalid expenditure import profile in catalog, code: %q", p.Code)
+			return
+		}
+		if p.Encoding != domain.ExpenditureImportEncodingUTF8 && p.Encoding != domain.ExpenditureImportEncodingEUCKR {
+			err = errors.Errorf("unsupported encoding of expenditure import profile in catalog, code: %q", p.Code)
+			return
+		}
+		if _, ok := domain.ExpenditureImportProfiles(catalog[:i]).Find(p.Code); ok {
+			err = errors.Errorf("duplicated expenditure import profile in catalog, code: %q", p.Code)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// expenditureImportHandler represent the http handler for expenditure import
+type expenditureImportHandler struct {
+	eiUsecase  domain.ExpenditureImportUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// maxImportFileSize is max size of csv file that can be previewed
+const maxImportFileSize = 5 << 20
+
+// NewExpenditureImportHandler will initialize the expenditure import resources endpoint
+func NewExpenditureImportHandler(r *gin.Engine, eiu domain.ExpenditureImportUsecase, v validator, jh jwtHandler) {
+	h := &expenditureImportHandler{
+		eiUsecase:  eiu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("expenditures/import-profiles", h.GetImportProfiles)
+	r.POST("expenditures/imports/preview", h.jwtHandler.ParseUUIDFromToken, h.PreviewImport)
+	r.POST("expenditures/imports", h.jwtHandler.ParseUUIDFromToken, h.CommitImport)
+}
+
+// GetImportProfiles deliver data to GetImportProfiles of domain.ExpenditureImportUsecase
+func (eih *expenditureImportHandler) GetImportProfiles(c *gin.Context) {
+	resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure import profiles")
+	resp["profiles"] = eih.eiUsecase.GetImportProfiles(c.Request.Context())
+	c.JSON(http.StatusOK, resp)
+}
+
+// PreviewImport deliver data to PreviewImport of domain.ExpenditureImportUsecase
+func (eih *expenditureImportHandler) PreviewImport(c *gin.Context) {
+	req := new(previewImportRequest)
+	if err := eih.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	if req.File.Size > maxImportFileSize {
+		msg := errors.Errorf("file size can't be over %d bytes", maxImportFileSize).Error()
+		c.JSON(http.StatusRequestEntityTooLarge, defaultResp(http.StatusRequestEntityTooLarge, 0, msg))
+		return
+	}
+	f, err := req.File.Open()
+	if err != nil {
+		err = errors.Wrap(err, "failed to open csv file")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+	file, err := ioutil.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		err = errors.Wrap(err, "failed to read csv file")
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, err.Error()))
+		return
+	}
+
+	rows, err := eih.eiUsecase.PreviewImport(c.Request.Context(), c.GetString("uuid"), req.Profile, file)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to preview expenditure import")
+		resp["rows"] = rows
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "PreviewImport return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// CommitImport deliver data to CommitImport of domain.ExpenditureImportUsecase
+func (eih *expenditureImportHandler) CommitImport(c *gin.Context) {
+	req := new(commitImportRequest)
+	if err := eih.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	ei := domain.ExpenditureImport{
+		Items:        make([]domain.ExpenditureImportItem, 0, len(req.Items)),
+		CategoryUUID: req.CategoryUUID,
+		BabyUUIDs:    req.BabyUUIDs,
+	}
+	for _, item := range req.Items {
+		ei.Items = append(ei.Items, domain.ExpenditureImportItem{
+			SpentAt: item.SpentAt,
+			Name:    item.Name,
+			Amount:  item.Amount,
+		})
+	}
+
+	uuids, err := eih.eiUsecase.CommitImport(c.Request.Context(), c.GetString("uuid"), ei)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to import expenditures")
+		resp["expenditure_uuids"] = uuids
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CommitImport return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (eih *expenditureImportHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := eih.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"mime/multipart"
+	"time"
+)
+
+// previewImportRequest is request for expenditureImportHandler.PreviewImport
+// Profile is code of import profile returned by expenditureImportHandler.GetImportProfiles
+type previewImportRequest struct {
+	Profile string                `form:"profile" validate:"required,max=30"`
+	File    *multipart.FileHeader `form:"file" validate:"required"`
+}
+
+func (r *previewImportRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.Bind(r), "failed to Bind")
+}
+
+// commitImportRequest is request for expenditureImportHandler.CommitImport
+// CategoryUUID & BabyUUIDs are set to every imported expenditure
+type commitImportRequest struct {
+	CategoryUUID *string            `json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	BabyUUIDs    []string           `json:"baby_uuids" validate:"omitempty,dive,uuid=children"`
+	Items        []importItemFields `json:"items" validate:"required,min=1,max=1000,dive"`
+}
+
+// importItemFields is one expenditure to import in commitImportRequest
+type importItemFields struct {
+	SpentAt time.Time `json:"spent_at" validate:"required"`
+	Name    string    `json:"name" validate:"required,max=20"`
+	Amount  int64     `json:"amount" validate:"required,range=1~1000000000"`
+}
+
+func (r *commitImportRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
@@ -0,0 +1,232 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/transform"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// utf8BOM is byte order mark that csv file saved by excel start with
+const utf8BOM = "\xEF\xBB\xBF"
+
+// maxNameLength is max length of expenditure name, longer name in statement is cut
+const maxNameLength = 20
+
+// dateLayouts is layouts of date (with time) written in statement of card companies & banks
+var dateLayouts = []string{
+	"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02",
+	"2006.01.02 15:04:05", "2006.01.02 15:04", "2006.01.02",
+	"2006/01/02 15:04:05", "2006/01/02 15:04", "2006/01/02",
+	"20060102150405", "20060102",
+	"2006년 01월 02일",
+}
+
+// timeLayouts is layouts of time written in separated column
+var timeLayouts = []string{"15:04:05", "15:04", "150405", "1504"}
+
+// parser is struct that parse csv statement of card company or bank with domain.ExpenditureImportProfile
+type parser struct{}
+
+// New return parser of csv statement
+func New() *parser {
+	return &parser{}
+}
+
+// Parse method parse csv file read from r with profile & return rows after header row
+// header row is first row having every column of profile, so lines before it (ex. title, account) are ignored
+// row that can't be imported has reason in Error field instead of failing whole parsing
+func (p *parser) Parse(r io.Reader, profile domain.ExpenditureImportProfile) (rows []domain.ExpenditureImportRow, err error) {
+	b, err := decode(r, profile.Encoding)
+	if err != nil {
+		return
+	}
+
+	cr := csv.NewReader(bytes.NewReader(b))
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		err = errors.Wrap(err, "failed to read csv")
+		return
+	}
+
+	header := -1
+	var columns map[string]int
+	for i, record := range records {
+		if columns = columnIndexes(record, profile); columns != nil {
+			header = i
+			break
+		}
+	}
+	if header == -1 {
+		err = errors.Errorf("header row having columns of %s is not found", profile.Name)
+		return
+	}
+
+	rows = []domain.ExpenditureImportRow{}
+	for i := header + 1; i < len(records); i++ {
+		record := records[i]
+		if isBlank(record) {
+			continue
+		}
+
+		row, ok := parseRow(record, columns, profile)
+		if !ok {
+			continue
+		}
+		row.Line = i + 1
+		rows = append(rows, row)
+	}
+	return
+}
+
+// decode function read r & return it decoded from encoding to UTF-8 without BOM
+func decode(r io.Reader, encoding string) (b []byte, err error) {
+	switch encoding {
+	case domain.ExpenditureImportEncodingEUCKR:
+		r = transform.NewReader(r, korean.EUCKR.NewDecoder())
+	case domain.ExpenditureImportEncodingUTF8:
+		break
+	default:
+		err = errors.Errorf("unsupported encoding %q", encoding)
+		return
+	}
+
+	if b, err = ioutil.ReadAll(r); err != nil {
+		err = errors.Wrapf(err, "failed to read csv as %s", encoding)
+		return
+	}
+	if !utf8.Valid(b) {
+		err = errors.Errorf("csv is not encoded in %s", encoding)
+		return
+	}
+	b = bytes.TrimPrefix(b, []byte(utf8BOM))
+	return
+}
+
+// columnIndexes function return index of each column in profile if record is header row, or nil if it is not
+func columnIndexes(record []string, profile domain.ExpenditureImportProfile) map[string]int {
+	indexes := map[string]int{}
+	for i, field := range record {
+		field = strings.TrimSpace(field)
+		if _, ok := indexes[field]; !ok {
+			indexes[field] = i
+		}
+	}
+
+	columns := map[string]int{}
+	for _, column := range []string{profile.DateColumn, profile.TimeColumn, profile.NameColumn, profile.AmountColumn} {
+		if column == "" {
+			continue
+		}
+		i, ok := indexes[column]
+		if !ok {
+			return nil
+		}
+		columns[column] = i
+	}
+	return columns
+}
+
+// parseRow function parse record into row, ok is false if record is not expenditure (ex. deposit)
+// fields are parsed as much as possible even if row has error, so that it can be found in preview
+func parseRow(record []string, columns map[string]int, profile domain.ExpenditureImportProfile) (row domain.ExpenditureImportRow, ok bool) {
+	field := func(column string) string {
+		if i, exist := columns[column]; exist && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var errs []string
+
+	amount, err := parseAmount(field(profile.AmountColumn))
+	if profile.NegativeAmount {
+		amount = -amount
+	}
+	switch {
+	case err != nil:
+		errs = append(errs, err.Error())
+	case amount == 0 || (profile.NegativeAmount && amount < 0):
+		return row, false
+	case amount < 0:
+		errs = append(errs, "amount is negative, canceled or refunded")
+	default:
+		row.Amount = amount
+	}
+
+	if row.Name = field(profile.NameColumn); row.Name == "" {
+		errs = append(errs, "name is empty")
+	}
+	if name := []rune(row.Name); len(name) > maxNameLength {
+		row.Name = string(name[:maxNameLength])
+	}
+
+	date := field(profile.DateColumn)
+	if profile.TimeColumn != "" {
+		if t := field(profile.TimeColumn); t != "" {
+			date += " " + normalizeTime(t)
+		}
+	}
+	if spentAt, err := parseDate(date); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		row.SpentAt = domain.Time(spentAt)
+	}
+
+	row.Error = strings.Join(errs, ", ")
+	return row, true
+}
+
+// parseAmount function parse amount written with comma, currency unit or sign (ex. "-12,300원"), empty amount is 0
+func parseAmount(s string) (amount int64, err error) {
+	s = strings.NewReplacer(",", "", "원", "", "₩", "", " ", "").Replace(s)
+	if s == "" {
+		return
+	}
+	if amount, err = strconv.ParseInt(s, 10, 64); err != nil {
+		err = errors.Errorf("invalid amount %q", s)
+	}
+	return
+}
+
+// parseDate function parse date (with time) in one of dateLayouts, in service location
+func parseDate(s string) (t time.Time, err error) {
+	for _, layout := range dateLayouts {
+		if t, err = time.ParseInLocation(layout, s, domain.ServiceLocation); err == nil {
+			return
+		}
+	}
+	err = errors.Errorf("invalid date %q", s)
+	return
+}
+
+// normalizeTime function return time in separated column formatted as 15:04:05
+func normalizeTime(s string) string {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("15:04:05")
+		}
+	}
+	return s
+}
+
+// isBlank function return true if every field of record is empty
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
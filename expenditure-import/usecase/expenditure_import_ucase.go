@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureImportUsecase is used for usecase layer which implement domain.ExpenditureImportUsecase interface
+type expenditureImportUsecase struct {
+	// profiles is column mapping profiles of csv file loaded from catalog
+	profiles domain.ExpenditureImportProfiles
+
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
+	// statementParser is used for parsing csv statement with profile
+	statementParser statementParser
+
+	// expenditureRepository is repository interface about domain.Expenditure model
+	expenditureRepository domain.ExpenditureRepository
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// elasticSearch is used for indexing imported expenditures at once
+	elasticSearch elasticSearch
+}
+
+// ExpenditureImportUsecase return implementation of domain.ExpenditureImportUsecase
+func ExpenditureImportUsecase(
+	ps []domain.ExpenditureImportProfile,
+	dc []domain.ExpenditureCategory,
+	sp statementParser,
+	er domain.ExpenditureRepository,
+	ecr domain.ExpenditureCategoryRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+	es elasticSearch,
+) domain.ExpenditureImportUsecase {
+	return &expenditureImportUsecase{
+		profiles:                      ps,
+		defaultCategories:             dc,
+		statementParser:               sp,
+		expenditureRepository:         er,
+		expenditureCategoryRepository: ecr,
+		childrenRepository:            cr,
+
+		txHandler:     th,
+		elasticSearch: es,
+	}
+}
+
+// statementParser is interface used for parsing csv statement of card company or bank
+type statementParser interface {
+	// Parse method parse csv file read from r with profile & return rows after header row
+	Parse(r io.Reader, profile domain.ExpenditureImportProfile) (rows []domain.ExpenditureImportRow, err error)
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// elasticSearch is interface used for indexing documents in elasticsearch with bulk API
+type elasticSearch interface {
+	// BulkIndex method index documents in docs (document id to document) in index
+	BulkIndex(ctx context.Context, index string, docs map[string]string) (err error)
+
+	// BulkDelete method delete documents with ids in index
+	BulkDelete(ctx context.Context, index string, ids []string) (err error)
+}
+
+// GetImportProfiles implement GetImportProfiles method of domain.ExpenditureImportUsecase interface
+func (eiu *expenditureImportUsecase) GetImportProfiles(ctx context.Context) []domain.ExpenditureImportProfile {
+	return eiu.profiles
+}
+
+// PreviewImport implement PreviewImport method of domain.ExpenditureImportUsecase interface
+// row is duplicated if expenditure of parent or previous row is spent in same day with same name & amount
+// row having error is not compared, as it can't be imported
+func (eiu *expenditureImportUsecase) PreviewImport(
+	ctx context.Context,
+	parentUUID, profileCode string,
+	file []byte,
+) (rows []domain.ExpenditureImportRow, err error) {
+	profile, ok := eiu.profiles.Find(profileCode)
+	if !ok {
+		err = errors.New("import profile with that code is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	}
+
+	if rows, err = eiu.statementParser.Parse(bytes.NewReader(file), profile); err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse csv"), Status: http.StatusBadRequest}
+		return
+	}
+	if len(rows) > domain.MaxExpenditureImportRows {
+		err = errors.Errorf("rows can't be more than %d at once", domain.MaxExpenditureImportRows)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	var from, to time.Time
+	for _, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		if from.IsZero() || row.SpentAt.Before(from) {
+			from = *row.SpentAt
+		}
+		if to.IsZero() || row.SpentAt.After(to) {
+			to = *row.SpentAt
+		}
+	}
+	if from.IsZero() {
+		return
+	}
+
+	_tx, err := eiu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	// expenditures in days of rows are compared, as duplicated expenditure is spent in same day
+	from, _, _ = domain.DayRange(from.In(domain.ServiceLocation).Format("2006-01-02"))
+	_, to, _ = domain.DayRange(to.In(domain.ServiceLocation).Format("2006-01-02"))
+	expenditures, err := eiu.expenditureRepository.GetByParentUUID(_tx, parentUUID, domain.ExpenditureFilter{
+		SpentFrom: domain.Time(from),
+		SpentTo:   domain.Time(to),
+		Sort:      domain.ExpenditureSortSpentAt,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "expenditure GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eiu.txHandler.Rollback(_tx)
+		return
+	}
+
+	keys := map[string]bool{}
+	for _, e := range expenditures {
+		keys[domain.ExpenditureDuplicateKey(domain.TimeValue(e.SpentAt), domain.StringValue(e.Name), domain.Int64Value(e.Amount))] = true
+	}
+	for i, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		key := domain.ExpenditureDuplicateKey(*row.SpentAt, row.Name, row.Amount)
+		rows[i].Duplicate = keys[key]
+		keys[key] = true
+	}
+
+	_ = eiu.txHandler.Commit(_tx)
+	return
+}
+
+// CommitImport implement CommitImport method of domain.ExpenditureImportUsecase interface
+// every expenditure is stored through expenditure repository in one transaction & indexed with one bulk request
+// budget alert is not checked, as imported expenditures are already spent in the past
+func (eiu *expenditureImportUsecase) CommitImport(
+	ctx context.Context,
+	parentUUID string,
+	ei domain.ExpenditureImport,
+) (uuids []string, err error) {
+	if len(ei.Items) == 0 || len(ei.Items) > domain.MaxExpenditureImportRows {
+		err = errors.Errorf("count of items must be 1 ~ %d", domain.MaxExpenditureImportRows)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := eiu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	categories, err := eiu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = eiu.txHandler.Rollback(_tx)
+		return
+	}
+	if ei.CategoryUUID != nil {
+		if _, ok := categories.Find(*ei.CategoryUUID); !ok {
+			err = errors.New("category with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	for _, babyUUID := range ei.BabyUUIDs {
+		if err = eiu.checkOwnChildren(_tx, parentUUID, babyUUID); err != nil {
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	now := time.Now()
+	path := categories.Path(domain.StringValue(ei.CategoryUUID))
+	docs := make(map[string]string, len(ei.Items))
+	uuids = make([]string, 0, len(ei.Items))
+	for i, item := range ei.Items {
+		e := &domain.Expenditure{
+			ParentUUID:   domain.String(parentUUID),
+			Name:         domain.String(item.Name),
+			Amount:       domain.Int64(item.Amount),
+			Rating:       domain.Int64(0),
+			CategoryUUID: ei.CategoryUUID,
+			SpentAt:      domain.Time(item.SpentAt),
+			CreatedAt:    domain.Time(now),
+			UpdatedAt:    domain.Time(now),
+		}
+
+		switch err = eiu.expenditureRepository.Store(_tx, e, ei.BabyUUIDs); tErr := err.(type) {
+		case nil:
+			break
+		case domain.ErrInvalidModel:
+			err = errors.Wrapf(err, "item %d is not valid expenditure", i)
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		case domain.ErrNoReferencedRow:
+			switch tErr.ForeignKey {
+			case "parent_uuid":
+				err = errors.New("parent with that uuid is not exist")
+				err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			default:
+				err = errors.Wrap(err, "expenditure Store return unexpected no referenced error")
+				err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			}
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		case domain.ErrEntryDuplicate:
+			err = errors.New("same baby is tagged more than once")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		default:
+			err = errors.Wrap(err, "expenditure Store return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = eiu.txHandler.Rollback(_tx)
+			return
+		}
+
+		uuid := domain.StringValue(e.UUID)
+		docs[uuid], _ = domain.ExpenditureSearchDocument(e, ei.BabyUUIDs, path)
+		uuids = append(uuids, uuid)
+	}
+
+	// documents indexed before failure of bulk request are deleted, as expenditures of them are rolled back
+	if err = eiu.elasticSearch.BulkIndex(ctx, domain.ExpenditureSearchIndex, docs); err != nil {
+		err = errors.Wrap(err, "elasticsearch BulkIndex return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = eiu.elasticSearch.BulkDelete(ctx, domain.ExpenditureSearchIndex, uuids)
+		_ = eiu.txHandler.Rollback(_tx)
+		uuids = nil
+		return
+	}
+
+	_ = eiu.txHandler.Commit(_tx)
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (eiu *expenditureImportUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := eiu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, eiu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
+
+// checkOwnChildren method return usecase error if children with uuid is not exist or not owned by parent
+func (eiu *expenditureImportUsecase) checkOwnChildren(_tx tx.Context, parentUUID, uuid string) (err error) {
+	c, err := eiu.childrenRepository.GetByUUID(_tx, uuid)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,45 @@
+package config
+
+import "github.com/spf13/viper"
+
+// App is the application config about expenditure statistics domain
+var App *expenditureStatisticsConfig
+
+// init function initialize App global variable
+func init() {
+	App = &expenditureStatisticsConfig{}
+}
+
+// expenditureStatisticsConfig have config value and implement various interface about expenditure statistics config
+type expenditureStatisticsConfig struct {
+	// aggregationBackend represent where expenditures are aggregated in, mysql or elasticsearch
+	aggregationBackend *string
+}
+
+// aggregation backend value of expenditureStatisticsConfig
+const (
+	AggregationBackendMysql         = "mysql"
+	AggregationBackendElasticsearch = "elasticsearch"
+)
+
+// default const value about expenditureStatisticsConfig field
+const (
+	defaultAggregationBackend = AggregationBackendMysql
+)
+
+// AggregationBackend return where expenditures are aggregated in, mysql if value is unknown
+func (esc *expenditureStatisticsConfig) AggregationBackend() string {
+	var key = "expenditureStatistics.aggregationBackend"
+	if esc.aggregationBackend == nil {
+		switch viper.GetString(key) {
+		case AggregationBackendMysql, AggregationBackendElasticsearch:
+			break
+		default:
+			viper.Set(key, defaultAggregationBackend)
+		}
+		esc.aggregationBackend = _string(viper.GetString(key))
+	}
+	return *esc.aggregationBackend
+}
+
+func _string(s string) *string { return &s }
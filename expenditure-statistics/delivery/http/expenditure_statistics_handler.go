@@ -0,0 +1,149 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// expenditureStatisticsHandler represent the http handler for expenditure statistics
+type expenditureStatisticsHandler struct {
+	esUsecase  domain.ExpenditureStatisticsUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewExpenditureStatisticsHandler will initialize the expenditure statistics resources endpoint
+func NewExpenditureStatisticsHandler(r *gin.Engine, esu domain.ExpenditureStatisticsUsecase, v validator, jh jwtHandler) {
+	h := &expenditureStatisticsHandler{
+		esUsecase:  esu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("expenditure-statistics/monthly", h.jwtHandler.ParseUUIDFromToken, h.GetMonthlyTotals)
+	r.GET("expenditure-statistics/categories", h.jwtHandler.ParseUUIDFromToken, h.GetCategoryTotals)
+	r.GET("expenditure-statistics/children", h.jwtHandler.ParseUUIDFromToken, h.GetChildrenTotals)
+	r.GET("expenditure-statistics/age-months", h.jwtHandler.ParseUUIDFromToken, h.GetAgeMonthlyAverages)
+}
+
+// GetMonthlyTotals deliver data to GetMonthlyTotals of domain.ExpenditureStatisticsUsecase
+func (esh *expenditureStatisticsHandler) GetMonthlyTotals(c *gin.Context) {
+	req := new(monthRangeRequest)
+	if err := esh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	totals, err := esh.esUsecase.GetMonthlyTotals(c.Request.Context(), c.GetString("uuid"), req.StartMonth, req.EndMonth)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get monthly expenditure totals")
+		resp["months"] = totals
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetMonthlyTotals return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetCategoryTotals deliver data to GetCategoryTotals of domain.ExpenditureStatisticsUsecase
+func (esh *expenditureStatisticsHandler) GetCategoryTotals(c *gin.Context) {
+	req := new(monthRangeRequest)
+	if err := esh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	totals, err := esh.esUsecase.GetCategoryTotals(c.Request.Context(), c.GetString("uuid"), req.StartMonth, req.EndMonth)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure totals by category")
+		resp["categories"] = totals
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetCategoryTotals return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetChildrenTotals deliver data to GetChildrenTotals of domain.ExpenditureStatisticsUsecase
+func (esh *expenditureStatisticsHandler) GetChildrenTotals(c *gin.Context) {
+	req := new(monthRangeRequest)
+	if err := esh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	totals, err := esh.esUsecase.GetChildrenTotals(c.Request.Context(), c.GetString("uuid"), req.StartMonth, req.EndMonth)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure totals by children")
+		resp["children"] = totals
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetChildrenTotals return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetAgeMonthlyAverages deliver data to GetAgeMonthlyAverages of domain.ExpenditureStatisticsUsecase
+func (esh *expenditureStatisticsHandler) GetAgeMonthlyAverages(c *gin.Context) {
+	averages, err := esh.esUsecase.GetAgeMonthlyAverages(c.Request.Context(), c.GetString("uuid"))
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expenditure averages by month of age")
+		resp["children"] = averages
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetAgeMonthlyAverages return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (esh *expenditureStatisticsHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := esh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// monthRangeRequest is request for expenditureStatisticsHandler aggregating expenditures in months
+// StartMonth & EndMonth is yyyy-mm, and both of them are inclusive
+type monthRangeRequest struct {
+	StartMonth string `form:"start_month" validate:"required,len=7"`
+	EndMonth   string `form:"end_month" validate:"required,len=7"`
+}
+
+func (r *monthRangeRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
@@ -0,0 +1,214 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureAggregator is used for aggregating expenditures with elasticsearch
+// it implement domain.ExpenditureAggregator interface, reading expenditure document indexed by expenditure usecase
+type expenditureAggregator struct {
+	searcher searcher
+}
+
+// ExpenditureAggregator return implementation of domain.ExpenditureAggregator using elasticsearch
+func ExpenditureAggregator(s searcher) domain.ExpenditureAggregator {
+	return &expenditureAggregator{
+		searcher: s,
+	}
+}
+
+// searcher is interface used for searching documents in elasticsearch
+type searcher interface {
+	// Search method search index with query body s & return raw response body
+	Search(ctx context.Context, index, s string) (body []byte, err error)
+}
+
+// missingCategory is key of bucket collecting expenditures not categorized
+const missingCategory = "-"
+
+// aggregationSize is max count of bucket in terms aggregation
+const aggregationSize = 1000
+
+// bucket is one bucket of aggregation in search response
+type bucket struct {
+	Key      interface{} `json:"key"`
+	DocCount int64       `json:"doc_count"`
+	Amount   struct {
+		Value float64 `json:"value"`
+	} `json:"amount"`
+}
+
+// SumByRanges is implement SumByRanges method of domain.ExpenditureAggregator interface
+func (ea *expenditureAggregator) SumByRanges(
+	ctx tx.Context,
+	parentUUID, babyUUID string,
+	boundaries []time.Time,
+) (sums []domain.ExpenditureSum, err error) {
+	sums = []domain.ExpenditureSum{}
+	if len(boundaries) < 2 {
+		return
+	}
+
+	ranges := make([]map[string]interface{}, 0, len(boundaries)-1)
+	for i := 0; i < len(boundaries)-1; i++ {
+		ranges = append(ranges, map[string]interface{}{
+			"key":  strconv.Itoa(i),
+			"from": esTime(boundaries[i]),
+			"to":   esTime(boundaries[i+1]),
+		})
+	}
+
+	agg := map[string]interface{}{
+		"date_range": map[string]interface{}{"field": "SpentAt", "ranges": ranges, "keyed": true},
+		"aggs":       amountAggregation(),
+	}
+	query := boolQuery(parentUUID, babyUUID, boundaries[0], boundaries[len(boundaries)-1])
+
+	var resp struct {
+		Aggregations struct {
+			Sums struct {
+				Buckets map[string]bucket `json:"buckets"`
+			} `json:"sums"`
+		} `json:"aggregations"`
+	}
+	if err = ea.search(ctx, query, agg, &resp); err != nil {
+		return
+	}
+
+	for i := 0; i < len(boundaries)-1; i++ {
+		b := resp.Aggregations.Sums.Buckets[strconv.Itoa(i)]
+		sums = append(sums, domain.ExpenditureSum{Amount: b.amount(), Count: b.DocCount})
+	}
+	return
+}
+
+// SumByCategory is implement SumByCategory method of domain.ExpenditureAggregator interface
+func (ea *expenditureAggregator) SumByCategory(
+	ctx tx.Context,
+	parentUUID string,
+	from, to time.Time,
+) (sums []domain.ExpenditureCategorySum, err error) {
+	agg := map[string]interface{}{
+		"terms": map[string]interface{}{"field": "CategoryUUID.keyword", "missing": missingCategory, "size": aggregationSize},
+		"aggs":  amountAggregation(),
+	}
+
+	buckets, err := ea.searchTerms(ctx, boolQuery(parentUUID, "", from, to), agg)
+	if err != nil {
+		return
+	}
+
+	sums = make([]domain.ExpenditureCategorySum, 0, len(buckets))
+	for _, b := range buckets {
+		sum := domain.ExpenditureCategorySum{Amount: b.amount()}
+		if key, _ := b.Key.(string); key != missingCategory {
+			sum.CategoryUUID = &key
+		}
+		sums = append(sums, sum)
+	}
+	return
+}
+
+// SumByBaby is implement SumByBaby method of domain.ExpenditureAggregator interface
+func (ea *expenditureAggregator) SumByBaby(
+	ctx tx.Context,
+	parentUUID string,
+	from, to time.Time,
+) (sums []domain.ExpenditureBabySum, err error) {
+	agg := map[string]interface{}{
+		"terms": map[string]interface{}{
+			"field": "baby.keyword",
+			"size":  aggregationSize,
+			"order": map[string]interface{}{"amount": "desc"},
+		},
+		"aggs": amountAggregation(),
+	}
+
+	buckets, err := ea.searchTerms(ctx, boolQuery(parentUUID, "", from, to), agg)
+	if err != nil {
+		return
+	}
+
+	sums = make([]domain.ExpenditureBabySum, 0, len(buckets))
+	for _, b := range buckets {
+		key, _ := b.Key.(string)
+		sums = append(sums, domain.ExpenditureBabySum{BabyUUID: key, Amount: b.amount(), Count: b.DocCount})
+	}
+	return
+}
+
+// searchTerms method search with terms aggregation agg & return its buckets
+func (ea *expenditureAggregator) searchTerms(ctx context.Context, query, agg map[string]interface{}) (buckets []bucket, err error) {
+	var resp struct {
+		Aggregations struct {
+			Sums struct {
+				Buckets []bucket `json:"buckets"`
+			} `json:"sums"`
+		} `json:"aggregations"`
+	}
+	if err = ea.search(ctx, query, agg, &resp); err != nil {
+		return
+	}
+	buckets = resp.Aggregations.Sums.Buckets
+	return
+}
+
+// search method search expenditure index with query & aggregation named sums, and decode response into resp
+func (ea *expenditureAggregator) search(ctx context.Context, query, agg map[string]interface{}, resp interface{}) (err error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"size":  0,
+		"query": query,
+		"aggs":  map[string]interface{}{"sums": agg},
+	})
+
+	result, err := ea.searcher.Search(ctx, domain.ExpenditureSearchIndex, string(body))
+	if err != nil {
+		err = errors.Wrap(err, "failed to search expenditure aggregation")
+		return
+	}
+
+	if err = json.Unmarshal(result, resp); err != nil {
+		err = errors.Wrap(err, "failed to decode expenditure aggregation response")
+	}
+	return
+}
+
+// boolQuery function return query filtering expenditures of parent spent in from ~ to, tagged with babyUUID if it is not empty
+func boolQuery(parentUUID, babyUUID string, from, to time.Time) map[string]interface{} {
+	filter := []interface{}{
+		map[string]interface{}{"term": map[string]interface{}{"ParentUUID.keyword": parentUUID}},
+		map[string]interface{}{"range": map[string]interface{}{
+			"SpentAt": map[string]interface{}{"gte": esTime(from), "lt": esTime(to)},
+		}},
+	}
+	if babyUUID != "" {
+		filter = append(filter, map[string]interface{}{"term": map[string]interface{}{"baby.keyword": babyUUID}})
+	}
+	return map[string]interface{}{"bool": map[string]interface{}{"filter": filter}}
+}
+
+// amountAggregation function return sub aggregation summing Amount of expenditures in bucket
+func amountAggregation() map[string]interface{} {
+	return map[string]interface{}{
+		"amount": map[string]interface{}{"sum": map[string]interface{}{"field": "Amount"}},
+	}
+}
+
+// esTime function return t formatted in date format of elasticsearch
+func esTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// amount method return sum of amount in bucket as integer
+func (b bucket) amount() int64 {
+	return int64(math.Round(b.Amount.Value))
+}
@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureAggregator is used for aggregating expenditures with mysql
+// it implement domain.ExpenditureAggregator interface, reading expenditure & expenditure_baby_tag table
+type expenditureAggregator struct {
+	db *sqlx.DB
+}
+
+// ExpenditureAggregator return implementation of domain.ExpenditureAggregator using mysql
+func ExpenditureAggregator(db *sqlx.DB) domain.ExpenditureAggregator {
+	return &expenditureAggregator{
+		db: db,
+	}
+}
+
+// SumByRanges is implement SumByRanges method of domain.ExpenditureAggregator interface
+// ranges are joined as derived table, so that every range is summed in one query (range without expenditure is 0)
+func (ea *expenditureAggregator) SumByRanges(
+	ctx tx.Context,
+	parentUUID, babyUUID string,
+	boundaries []time.Time,
+) (sums []domain.ExpenditureSum, err error) {
+	sums = []domain.ExpenditureSum{}
+	if len(boundaries) < 2 {
+		return
+	}
+
+	ranges := make([]string, 0, len(boundaries)-1)
+	var args []interface{}
+	for i := 0; i < len(boundaries)-1; i++ {
+		ranges = append(ranges, "SELECT ? AS idx, ? AS from_at, ? AS to_at")
+		args = append(args, i, boundaries[i], boundaries[i+1])
+	}
+
+	on := squirrel.And{squirrel.Expr("e.parent_uuid = ? AND e.spent_at >= r.from_at AND e.spent_at < r.to_at", parentUUID)}
+	if babyUUID != "" {
+		on = append(on, squirrel.Expr("e.uuid IN (SELECT expenditure_uuid FROM expenditure_baby_tag WHERE baby_uuid = ?)", babyUUID))
+	}
+	onSQL, onArgs, _ := on.ToSql()
+
+	_sql, _, _ := squirrel.Select("r.idx", "COALESCE(SUM(e.amount), 0) AS amount", "COUNT(e.uuid) AS count").
+		From("(" + strings.Join(ranges, " UNION ALL ") + ") AS r").
+		LeftJoin("expenditure AS e ON " + onSQL).
+		GroupBy("r.idx").OrderBy("r.idx").ToSql()
+	args = append(args, onArgs...)
+
+	rows := []struct {
+		Idx int `db:"idx"`
+		domain.ExpenditureSum
+	}{}
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	if err = _tx.Select(&rows, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select sum of expenditure amount by range return unexpected error")
+		return
+	}
+
+	for _, row := range rows {
+		sums = append(sums, row.ExpenditureSum)
+	}
+	return
+}
+
+// SumByCategory is implement SumByCategory method of domain.ExpenditureAggregator interface
+func (ea *expenditureAggregator) SumByCategory(
+	ctx tx.Context,
+	parentUUID string,
+	from, to time.Time,
+) (sums []domain.ExpenditureCategorySum, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("category_uuid", "SUM(amount) AS amount").From("expenditure").
+		Where("parent_uuid = ? AND spent_at >= ? AND spent_at < ?", parentUUID, from, to).
+		GroupBy("category_uuid").ToSql()
+
+	sums = []domain.ExpenditureCategorySum{}
+	if err = _tx.Select(&sums, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select sum of expenditure amount by category return unexpected error")
+	}
+	return
+}
+
+// SumByBaby is implement SumByBaby method of domain.ExpenditureAggregator interface
+func (ea *expenditureAggregator) SumByBaby(
+	ctx tx.Context,
+	parentUUID string,
+	from, to time.Time,
+) (sums []domain.ExpenditureBabySum, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("t.baby_uuid", "SUM(e.amount) AS amount", "COUNT(e.uuid) AS count").
+		From("expenditure AS e").
+		Join("expenditure_baby_tag AS t ON t.expenditure_uuid = e.uuid").
+		Where("e.parent_uuid = ? AND e.spent_at >= ? AND e.spent_at < ?", parentUUID, from, to).
+		GroupBy("t.baby_uuid").OrderBy("amount DESC").ToSql()
+
+	sums = []domain.ExpenditureBabySum{}
+	if err = _tx.Select(&sums, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select sum of expenditure amount by baby return unexpected error")
+	}
+	return
+}
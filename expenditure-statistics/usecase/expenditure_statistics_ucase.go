@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// expenditureStatisticsUsecase is used for usecase layer which implement domain.ExpenditureStatisticsUsecase interface
+type expenditureStatisticsUsecase struct {
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
+	// expenditureAggregator is aggregator interface summing amount of expenditures (mysql or elasticsearch)
+	expenditureAggregator domain.ExpenditureAggregator
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// ExpenditureStatisticsUsecase return implementation of domain.ExpenditureStatisticsUsecase
+func ExpenditureStatisticsUsecase(
+	dc []domain.ExpenditureCategory,
+	ea domain.ExpenditureAggregator,
+	ecr domain.ExpenditureCategoryRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.ExpenditureStatisticsUsecase {
+	return &expenditureStatisticsUsecase{
+		defaultCategories:             dc,
+		expenditureAggregator:         ea,
+		expenditureCategoryRepository: ecr,
+		childrenRepository:            cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// maxStatisticsMonths is max count of months that can be aggregated at once
+const maxStatisticsMonths = 60
+
+// GetMonthlyTotals implement GetMonthlyTotals method of domain.ExpenditureStatisticsUsecase interface
+func (esu *expenditureStatisticsUsecase) GetMonthlyTotals(
+	ctx context.Context,
+	parentUUID, startMonth, endMonth string,
+) (totals []domain.ExpenditureMonthlyTotal, err error) {
+	months, err := listMonths(startMonth, endMonth)
+	if err != nil {
+		return
+	}
+
+	// boundaries start from previous month of startMonth, so that delta of first month can be calculated
+	boundaries := make([]time.Time, 0, len(months)+2)
+	for _, month := range months {
+		from, _, _ := domain.MonthRange(month)
+		boundaries = append(boundaries, from)
+	}
+	_, to, _ := domain.MonthRange(endMonth)
+	boundaries = append([]time.Time{boundaries[0].AddDate(0, -1, 0)}, append(boundaries, to)...)
+
+	_tx, err := esu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	sums, err := esu.expenditureAggregator.SumByRanges(_tx, parentUUID, "", boundaries)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure aggregator SumByRanges return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = esu.txHandler.Rollback(_tx)
+		return
+	}
+
+	totals = domain.NewExpenditureMonthlyTotals(months, sums)
+	_ = esu.txHandler.Commit(_tx)
+	return
+}
+
+// GetCategoryTotals implement GetCategoryTotals method of domain.ExpenditureStatisticsUsecase interface
+func (esu *expenditureStatisticsUsecase) GetCategoryTotals(
+	ctx context.Context,
+	parentUUID, startMonth, endMonth string,
+) (totals []domain.ExpenditureCategoryTotal, err error) {
+	if _, err = listMonths(startMonth, endMonth); err != nil {
+		return
+	}
+	from, _, _ := domain.MonthRange(startMonth)
+	_, to, _ := domain.MonthRange(endMonth)
+
+	_tx, err := esu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	categories, err := esu.getCategories(_tx, parentUUID)
+	if err != nil {
+		_ = esu.txHandler.Rollback(_tx)
+		return
+	}
+
+	sums, err := esu.expenditureAggregator.SumByCategory(_tx, parentUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure aggregator SumByCategory return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = esu.txHandler.Rollback(_tx)
+		return
+	}
+
+	totals = domain.NewExpenditureCategoryTotals(categories, sums)
+	_ = esu.txHandler.Commit(_tx)
+	return
+}
+
+// GetChildrenTotals implement GetChildrenTotals method of domain.ExpenditureStatisticsUsecase interface
+func (esu *expenditureStatisticsUsecase) GetChildrenTotals(
+	ctx context.Context,
+	parentUUID, startMonth, endMonth string,
+) (totals []domain.ExpenditureChildrenTotal, err error) {
+	if _, err = listMonths(startMonth, endMonth); err != nil {
+		return
+	}
+	from, _, _ := domain.MonthRange(startMonth)
+	_, to, _ := domain.MonthRange(endMonth)
+
+	_tx, err := esu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	sums, err := esu.expenditureAggregator.SumByBaby(_tx, parentUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure aggregator SumByBaby return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = esu.txHandler.Rollback(_tx)
+		return
+	}
+
+	totals = make([]domain.ExpenditureChildrenTotal, 0, len(sums))
+	for _, sum := range sums {
+		c, ok, gErr := esu.getOwnChildren(_tx, parentUUID, sum.BabyUUID)
+		if gErr != nil {
+			err = gErr
+			_ = esu.txHandler.Rollback(_tx)
+			return
+		}
+		if !ok {
+			continue
+		}
+		totals = append(totals, domain.ExpenditureChildrenTotal{
+			ChildrenUUID: sum.BabyUUID,
+			Name:         domain.StringValue(c.Name),
+			Amount:       sum.Amount,
+			Count:        sum.Count,
+		})
+	}
+
+	_ = esu.txHandler.Commit(_tx)
+	return
+}
+
+// GetAgeMonthlyAverages implement GetAgeMonthlyAverages method of domain.ExpenditureStatisticsUsecase interface
+// only born children tagged in any expenditure are aggregated
+func (esu *expenditureStatisticsUsecase) GetAgeMonthlyAverages(
+	ctx context.Context,
+	parentUUID string,
+) (averages []domain.ExpenditureAgeMonthlyAverage, err error) {
+	_tx, err := esu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	now := time.Now()
+	sums, err := esu.expenditureAggregator.SumByBaby(_tx, parentUUID, time.Unix(0, 0), now)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure aggregator SumByBaby return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = esu.txHandler.Rollback(_tx)
+		return
+	}
+
+	averages = []domain.ExpenditureAgeMonthlyAverage{}
+	for _, sum := range sums {
+		c, ok, gErr := esu.getOwnChildren(_tx, parentUUID, sum.BabyUUID)
+		if gErr != nil {
+			err = gErr
+			_ = esu.txHandler.Rollback(_tx)
+			return
+		}
+		if !ok || !c.IsBorn() || !c.Birth.Before(now) {
+			continue
+		}
+
+		boundaries := domain.AgeMonthBoundaries(c.Birth.In(domain.ServiceLocation), now)
+		ageSums, sErr := esu.expenditureAggregator.SumByRanges(_tx, parentUUID, sum.BabyUUID, boundaries)
+		if sErr != nil {
+			err = errors.Wrap(sErr, "expenditure aggregator SumByRanges return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = esu.txHandler.Rollback(_tx)
+			return
+		}
+
+		average := domain.ExpenditureAgeMonthlyAverage{
+			ChildrenUUID: sum.BabyUUID,
+			Name:         domain.StringValue(c.Name),
+			AgeMonths:    make([]domain.ExpenditureAgeMonthly, 0, len(ageSums)),
+		}
+		var total int64
+		for m, ageSum := range ageSums {
+			total += ageSum.Amount
+			average.AgeMonths = append(average.AgeMonths, domain.ExpenditureAgeMonthly{
+				AgeMonth: m,
+				Amount:   ageSum.Amount,
+				Count:    ageSum.Count,
+			})
+		}
+		if len(ageSums) != 0 {
+			average.Average = total / int64(len(ageSums))
+		}
+		averages = append(averages, average)
+	}
+
+	_ = esu.txHandler.Commit(_tx)
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (esu *expenditureStatisticsUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := esu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, esu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
+
+// getOwnChildren method return children with uuid, ok is false if children is not exist or not owned by parent
+func (esu *expenditureStatisticsUsecase) getOwnChildren(_tx tx.Context, parentUUID, uuid string) (c domain.Children, ok bool, err error) {
+	switch c, err = esu.childrenRepository.GetByUUID(_tx, uuid); err.(type) {
+	case nil:
+		ok = domain.StringValue(c.ParentUUID) == parentUUID
+	case domain.ErrRowNotExist:
+		err = nil
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// listMonths function return months from startMonth to endMonth, with usecase error if months are not valid
+func listMonths(startMonth, endMonth string) (months []string, err error) {
+	if months, err = domain.ListMonths(startMonth, endMonth); err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse month"), Status: http.StatusBadRequest}
+		return
+	}
+
+	switch {
+	case len(months) == 0:
+		err = errors.New("end month is before start month")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+	case len(months) > maxStatisticsMonths:
+		err = errors.Errorf("months can't be more than %d", maxStatisticsMonths)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// feedingHandler represent the http handler for feeding
+type feedingHandler struct {
+	fUsecase   domain.FeedingUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewFeedingHandler will initialize the feeding resources endpoint
+func NewFeedingHandler(r *gin.Engine, fu domain.FeedingUsecase, v validator, jh jwtHandler) {
+	h := &feedingHandler{
+		fUsecase:   fu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/feedings", h.jwtHandler.ParseUUIDFromToken, h.CreateFeeding)
+	r.GET("children/uuid/:children_uuid/feedings", h.jwtHandler.ParseUUIDFromToken, h.GetFeedingsByDate)
+	r.GET("children/uuid/:children_uuid/feedings/summary", h.jwtHandler.ParseUUIDFromToken, h.GetFeedingSummary)
+}
+
+// CreateFeeding deliver data to CreateFeeding of domain.FeedingUsecase
+func (fh *feedingHandler) CreateFeeding(c *gin.Context) {
+	req := new(createFeedingRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	f := &domain.Feeding{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		FeedingType:  domain.String(req.FeedingType),
+	}
+	switch req.FeedingType {
+	case domain.FeedingTypeBreast:
+		f.BreastSide = domain.String(req.BreastSide)
+		f.Duration = domain.Int64(req.Duration)
+	case domain.FeedingTypeBottle:
+		f.BottleContent = domain.String(req.BottleContent)
+		f.BottleVolume = domain.Int64(req.BottleVolume)
+	case domain.FeedingTypeSolid:
+		f.FoodName = domain.String(req.FoodName)
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.FedAt); err != nil {
+		err = errors.Wrap(err, "failed to parse fed_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		f.FedAt = domain.Time(t)
+	}
+
+	switch uuid, err := fh.fUsecase.CreateFeeding(c.Request.Context(), c.GetString("uuid"), f); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new feeding")
+		resp["feeding_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateFeeding return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetFeedingsByDate deliver data to GetFeedingsByDate of domain.FeedingUsecase
+func (fh *feedingHandler) GetFeedingsByDate(c *gin.Context) {
+	req := new(getFeedingsByDateRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	feedings, err := fh.fUsecase.GetFeedingsByDate(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get feedings by date")
+		resp["feedings"] = feedings
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetFeedingsByDate return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetFeedingSummary deliver data to GetFeedingSummary of domain.FeedingUsecase
+func (fh *feedingHandler) GetFeedingSummary(c *gin.Context) {
+	req := new(getFeedingsByDateRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	summary, err := fh.fUsecase.GetFeedingSummary(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get feeding summary")
+		resp["summary"] = summary
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetFeedingSummary return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (fh *feedingHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := fh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
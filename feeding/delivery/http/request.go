@@ -0,0 +1,57 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createFeedingRequest is request for feedingHandler.CreateFeeding
+type createFeedingRequest struct {
+	ChildrenUUID  string `uri:"children_uuid" validate:"required,uuid=children"`
+	FeedingType   string `json:"feeding_type" validate:"required,oneof=breast bottle solid"`
+	FedAt         string `json:"fed_at" validate:"required,max=30"`
+	BreastSide    string `json:"breast_side" validate:"omitempty,oneof=left right both"`
+	Duration      int64  `json:"duration" validate:"range=0~600"`
+	BottleVolume  int64  `json:"bottle_volume" validate:"range=0~1000"`
+	BottleContent string `json:"bottle_content" validate:"omitempty,oneof=formula expressed"`
+	FoodName      string `json:"food_name" validate:"max=50"`
+}
+
+func (r *createFeedingRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+
+	if err := c.BindJSON(r); err != nil {
+		return errors.Wrap(err, "failed to BindJSON")
+	}
+
+	switch r.FeedingType {
+	case "breast":
+		if r.BreastSide == "" || r.Duration == 0 {
+			return errors.New("breast feeding must have breast_side & duration")
+		}
+	case "bottle":
+		if r.BottleContent == "" || r.BottleVolume == 0 {
+			return errors.New("bottle feeding must have bottle_content & bottle_volume")
+		}
+	case "solid":
+		if r.FoodName == "" {
+			return errors.New("solid feeding must have food_name")
+		}
+	}
+	return nil
+}
+
+// getFeedingsByDateRequest is request for feedingHandler.GetFeedingsByDate & feedingHandler.GetFeedingSummary
+type getFeedingsByDateRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+}
+
+func (r *getFeedingsByDateRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// feedingRepository is implementation of domain.FeedingRepository using mysql
+type feedingRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// FeedingRepository return implementation of domain.FeedingRepository using mysql
+func FeedingRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.FeedingRepository {
+	repo := &feedingRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Feeding{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate feeding model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.FeedingRepository interface
+func (fr *feedingRepository) Store(ctx tx.Context, f *domain.Feeding) (err error) {
+	if domain.StringValue(f.UUID) == "" {
+		if f.UUID, err = fr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = fr.validator.ValidateStruct(f); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Feeding")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("feeding").
+		Columns("uuid", "children_uuid", "feeding_type", "fed_at", "breast_side", "duration", "bottle_volume", "bottle_content", "food_name").
+		Values(f.UUID, f.ChildrenUUID, f.FeedingType, f.FedAt, f.BreastSide, f.Duration, f.BottleVolume, f.BottleContent, f.FoodName).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert feeding")
+			fk := fr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert feeding return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert feeding return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.FeedingRepository interface
+func (fr *feedingRepository) GetByUUID(ctx tx.Context, uuid string) (f domain.Feeding, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("feeding").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&f, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select feeding")}
+	default:
+		err = errors.Wrap(err, "select feeding return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.FeedingRepository interface
+func (fr *feedingRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (feedings []domain.Feeding, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("feeding").
+		Where("children_uuid = ?", childrenUUID).
+		Where("fed_at >= ? AND fed_at < ?", from, to).
+		OrderBy("fed_at").ToSql()
+
+	feedings = []domain.Feeding{}
+	if err = _tx.Select(&feedings, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select feedings return unexpected error")
+	}
+	return
+}
+
+// GetLastByChildrenUUID is implement GetLastByChildrenUUID method of domain.FeedingRepository interface
+func (fr *feedingRepository) GetLastByChildrenUUID(ctx tx.Context, childrenUUID string) (f domain.Feeding, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("feeding").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("fed_at DESC").Limit(1).ToSql()
+
+	switch err = _tx.Get(&f, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select last feeding")}
+	default:
+		err = errors.Wrap(err, "select last feeding return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of feeding table
+func (fr *feedingRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	f := new(domain.Feeding)
+
+	for {
+		uuid := f.GenerateRandomUUID()
+		_, err := fr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// feedingUsecase is used for usecase layer which implement domain.FeedingUsecase interface
+type feedingUsecase struct {
+	// feedingRepository is repository interface about domain.Feeding model
+	feedingRepository domain.FeedingRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// FeedingUsecase return implementation of domain.FeedingUsecase
+func FeedingUsecase(
+	fr domain.FeedingRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.FeedingUsecase {
+	return &feedingUsecase{
+		feedingRepository:  fr,
+		childrenRepository: cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreateFeeding implement CreateFeeding method of domain.FeedingUsecase interface
+func (fu *feedingUsecase) CreateFeeding(ctx context.Context, parentUUID string, f *domain.Feeding) (uuid string, err error) {
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = fu.getOwnChildren(_tx, parentUUID, domain.StringValue(f.ChildrenUUID)); err != nil {
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = fu.feedingRepository.Store(_tx, f); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "feeding Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "feeding Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(f.UUID)
+	_ = fu.txHandler.Commit(_tx)
+	return
+}
+
+// GetFeedingsByDate implement GetFeedingsByDate method of domain.FeedingUsecase interface
+func (fu *feedingUsecase) GetFeedingsByDate(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (feedings []domain.Feeding, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = fu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if feedings, err = fu.feedingRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to); err != nil {
+		err = errors.Wrap(err, "feeding GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = fu.txHandler.Commit(_tx)
+	return
+}
+
+// GetFeedingSummary implement GetFeedingSummary method of domain.FeedingUsecase interface
+func (fu *feedingUsecase) GetFeedingSummary(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (summary domain.FeedingSummary, err error) {
+	feedings, err := fu.GetFeedingsByDate(ctx, parentUUID, childrenUUID, date)
+	if err != nil {
+		return
+	}
+
+	summary.Date = date
+	for _, f := range feedings {
+		summary.Add(f)
+	}
+
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	switch last, err := fu.feedingRepository.GetLastByChildrenUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		summary.LastFedAt = last.FedAt
+		summary.MinutesSinceFed = domain.Int64(int64(time.Since(domain.TimeValue(last.FedAt)).Minutes()))
+	case domain.ErrRowNotExist:
+		break
+	default:
+		err = errors.Wrap(err, "feeding GetLastByChildrenUUID return unexpected error")
+		_ = fu.txHandler.Rollback(_tx)
+		return summary, domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+
+	_ = fu.txHandler.Commit(_tx)
+	return summary, nil
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (fu *feedingUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = fu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// foodIntroductionHandler represent the http handler for food introduction
+type foodIntroductionHandler struct {
+	fUsecase   domain.FoodIntroductionUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewFoodIntroductionHandler will initialize the food introduction resources endpoint
+func NewFoodIntroductionHandler(r *gin.Engine, fu domain.FoodIntroductionUsecase, v validator, jh jwtHandler) {
+	h := &foodIntroductionHandler{
+		fUsecase:   fu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("allergens/catalog", h.GetAllergenCatalog)
+	r.POST("children/uuid/:children_uuid/food-introductions", h.jwtHandler.ParseUUIDFromToken, h.CreateFoodIntroduction)
+	r.GET("children/uuid/:children_uuid/food-introductions", h.jwtHandler.ParseUUIDFromToken, h.GetFoodIntroductions)
+	r.GET("children/uuid/:children_uuid/food-introductions/allergen-suggestions", h.jwtHandler.ParseUUIDFromToken, h.GetAllergenSuggestions)
+}
+
+// GetAllergenCatalog return common allergens in domain.AllergenCatalog
+func (fh *foodIntroductionHandler) GetAllergenCatalog(c *gin.Context) {
+	resp := defaultResp(http.StatusOK, 0, "succeed to get allergen catalog")
+	resp["allergens"] = domain.AllergenCatalog
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateFoodIntroduction deliver data to CreateFoodIntroduction of domain.FoodIntroductionUsecase
+func (fh *foodIntroductionHandler) CreateFoodIntroduction(c *gin.Context) {
+	req := new(createFoodIntroductionRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	fi := &domain.FoodIntroduction{
+		ChildrenUUID:     domain.String(req.ChildrenUUID),
+		FoodName:         domain.String(req.FoodName),
+		ReactionSeverity: domain.String(domain.ReactionSeverityNone),
+	}
+	if req.AllergenCategory != "" {
+		fi.AllergenCategory = domain.String(req.AllergenCategory)
+	}
+	if req.ReactionSeverity != "" {
+		fi.ReactionSeverity = domain.String(req.ReactionSeverity)
+	}
+	if req.ReactionSymptom != "" {
+		fi.ReactionSymptom = domain.String(req.ReactionSymptom)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", req.IntroducedAt, domain.ServiceLocation); err != nil {
+		err = errors.Wrap(err, "failed to parse introduced_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		fi.IntroducedAt = domain.Time(t)
+	}
+
+	switch uuid, err := fh.fUsecase.CreateFoodIntroduction(c.Request.Context(), c.GetString("uuid"), fi); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new food introduction")
+		resp["food_introduction_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateFoodIntroduction return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetFoodIntroductions deliver data to GetFoodIntroductions of domain.FoodIntroductionUsecase
+func (fh *foodIntroductionHandler) GetFoodIntroductions(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	introductions, err := fh.fUsecase.GetFoodIntroductions(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get food introductions")
+		resp["food_introductions"] = introductions
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetFoodIntroductions return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetAllergenSuggestions deliver data to GetAllergenSuggestions of domain.FoodIntroductionUsecase
+func (fh *foodIntroductionHandler) GetAllergenSuggestions(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := fh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	suggestions, err := fh.fUsecase.GetAllergenSuggestions(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get allergen suggestions")
+		resp["suggestions"] = suggestions
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetAllergenSuggestions return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (fh *foodIntroductionHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := fh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createFoodIntroductionRequest is request for foodIntroductionHandler.CreateFoodIntroduction
+type createFoodIntroductionRequest struct {
+	ChildrenUUID     string `uri:"children_uuid" validate:"required,uuid=children"`
+	FoodName         string `json:"food_name" validate:"required,max=30"`
+	AllergenCategory string `json:"allergen_category" validate:"max=30"`
+	IntroducedAt     string `json:"introduced_at" validate:"required,len=10"`
+	ReactionSeverity string `json:"reaction_severity" validate:"omitempty,oneof=none mild moderate severe"`
+	ReactionSymptom  string `json:"reaction_symptom" validate:"max=200"`
+}
+
+func (r *createFoodIntroductionRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// childrenUUIDRequest is request having only children uuid in uri
+// used for foodIntroductionHandler.GetFoodIntroductions & GetAllergenSuggestions
+type childrenUUIDRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *childrenUUIDRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
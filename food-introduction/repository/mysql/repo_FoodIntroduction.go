@@ -0,0 +1,134 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// foodIntroductionRepository is implementation of domain.FoodIntroductionRepository using mysql
+type foodIntroductionRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// FoodIntroductionRepository return implementation of domain.FoodIntroductionRepository using mysql
+func FoodIntroductionRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.FoodIntroductionRepository {
+	repo := &foodIntroductionRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.FoodIntroduction{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate food introduction model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.FoodIntroductionRepository interface
+func (fr *foodIntroductionRepository) Store(ctx tx.Context, fi *domain.FoodIntroduction) (err error) {
+	if domain.StringValue(fi.UUID) == "" {
+		if fi.UUID, err = fr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = fr.validator.ValidateStruct(fi); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.FoodIntroduction")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("food_introduction").
+		Columns("uuid", "children_uuid", "food_name", "allergen_category", "introduced_at", "reaction_severity", "reaction_symptom").
+		Values(fi.UUID, fi.ChildrenUUID, fi.FoodName, fi.AllergenCategory, fi.IntroducedAt, fi.ReactionSeverity, fi.ReactionSymptom).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert food introduction")
+			fk := fr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert food introduction return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert food introduction return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.FoodIntroductionRepository interface
+func (fr *foodIntroductionRepository) GetByUUID(ctx tx.Context, uuid string) (fi domain.FoodIntroduction, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("food_introduction").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&fi, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select food introduction")}
+	default:
+		err = errors.Wrap(err, "select food introduction return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.FoodIntroductionRepository interface
+func (fr *foodIntroductionRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (fis []domain.FoodIntroduction, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("food_introduction").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("introduced_at").ToSql()
+
+	fis = []domain.FoodIntroduction{}
+	if err = _tx.Select(&fis, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select food introductions return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of food introduction table
+func (fr *foodIntroductionRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	fi := new(domain.FoodIntroduction)
+
+	for {
+		uuid := fi.GenerateRandomUUID()
+		_, err := fr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
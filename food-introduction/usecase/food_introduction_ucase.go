@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// foodIntroductionUsecase is used for usecase layer which implement domain.FoodIntroductionUsecase interface
+type foodIntroductionUsecase struct {
+	// foodIntroductionRepository is repository interface about domain.FoodIntroduction model
+	foodIntroductionRepository domain.FoodIntroductionRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// childrenAllergyRepository is repository interface about domain.ChildrenAllergy model
+	childrenAllergyRepository domain.ChildrenAllergyRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// FoodIntroductionUsecase return implementation of domain.FoodIntroductionUsecase
+func FoodIntroductionUsecase(
+	fr domain.FoodIntroductionRepository,
+	cr domain.ChildrenRepository,
+	car domain.ChildrenAllergyRepository,
+	th txHandler,
+) domain.FoodIntroductionUsecase {
+	return &foodIntroductionUsecase{
+		foodIntroductionRepository: fr,
+		childrenRepository:         cr,
+		childrenAllergyRepository:  car,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreateFoodIntroduction implement CreateFoodIntroduction method of domain.FoodIntroductionUsecase interface
+func (fu *foodIntroductionUsecase) CreateFoodIntroduction(
+	ctx context.Context,
+	parentUUID string,
+	fi *domain.FoodIntroduction,
+) (uuid string, err error) {
+	if fi.AllergenCategory != nil {
+		if _, ok := domain.FindAllergen(domain.StringValue(fi.AllergenCategory)); !ok {
+			err = errors.New("allergen category is not exist in allergen catalog")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			return
+		}
+	}
+
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = fu.getOwnChildren(_tx, parentUUID, domain.StringValue(fi.ChildrenUUID)); err != nil {
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = fu.foodIntroductionRepository.Store(_tx, fi); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "food introduction Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "food introduction Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(fi.UUID)
+	_ = fu.txHandler.Commit(_tx)
+	return
+}
+
+// GetFoodIntroductions implement GetFoodIntroductions method of domain.FoodIntroductionUsecase interface
+func (fu *foodIntroductionUsecase) GetFoodIntroductions(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (introductions []domain.FoodIntroduction, err error) {
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = fu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if introductions, err = fu.foodIntroductionRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "food introduction GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = fu.txHandler.Commit(_tx)
+	return
+}
+
+// GetAllergenSuggestions implement GetAllergenSuggestions method of domain.FoodIntroductionUsecase interface
+func (fu *foodIntroductionUsecase) GetAllergenSuggestions(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (suggestions []domain.AllergenSuggestion, err error) {
+	_tx, err := fu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := fu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	introductions, err := fu.foodIntroductionRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "food introduction GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	allergies, err := fu.childrenAllergyRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "children allergy GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = fu.txHandler.Rollback(_tx)
+		return
+	}
+
+	excluded := map[string]bool{}
+	for _, fi := range introductions {
+		excluded[domain.StringValue(fi.AllergenCategory)] = true
+	}
+	for _, ca := range allergies {
+		excluded[domain.StringValue(ca.Allergen)] = true
+	}
+
+	month := domain.MonthsBetween(domain.TimeValue(c.Birth), time.Now())
+	suggestions = []domain.AllergenSuggestion{}
+	for _, ad := range domain.AllergenCatalog {
+		if excluded[ad.Code] {
+			continue
+		}
+		s := domain.AllergenSuggestion{AllergenDefinition: ad, Status: domain.AllergenRecommended}
+		if month < ad.MinMonth {
+			s.Status = domain.AllergenTooEarly
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	_ = fu.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (fu *foodIntroductionUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = fu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
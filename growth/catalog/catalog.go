@@ -0,0 +1,41 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// catalogFile is name of embedded growth standard file
+// LMS parameters follow WHO Child Growth Standards, which korean national growth chart use under 36 months
+const catalogFile = "data/who-growth-standards.json"
+
+//go:embed data/*.json
+var embedded embed.FS
+
+// Load function return growth standard read from embedded catalog file
+func Load() (gs domain.GrowthStandard, err error) {
+	b, err := embedded.ReadFile(catalogFile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read growth standard catalog file")
+		return
+	}
+
+	if err = json.Unmarshal(b, &gs); err != nil {
+		err = errors.Wrap(err, "failed to unmarshal growth standard catalog")
+		return
+	}
+
+	for _, gc := range gs.Curves {
+		for i, lms := range gc.LMS {
+			if lms.M <= 0 || lms.S <= 0 || (i > 0 && lms.Month <= gc.LMS[i-1].Month) {
+				err = errors.Errorf("invalid growth curve in catalog, indicator: %q, sex: %q", gc.Indicator, gc.Sex)
+				return
+			}
+		}
+	}
+	return
+}
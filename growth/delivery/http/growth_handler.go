@@ -0,0 +1,122 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// growthHandler represent the http handler for growth
+type growthHandler struct {
+	gUsecase   domain.GrowthUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewGrowthHandler will initialize the growth resources endpoint
+func NewGrowthHandler(r *gin.Engine, gu domain.GrowthUsecase, v validator, jh jwtHandler) {
+	h := &growthHandler{
+		gUsecase:   gu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/growth-measurements", h.jwtHandler.ParseUUIDFromToken, h.CreateGrowthMeasurement)
+	r.GET("children/uuid/:children_uuid/growth-chart", h.jwtHandler.ParseUUIDFromToken, h.GetGrowthChart)
+}
+
+// CreateGrowthMeasurement deliver data to CreateGrowthMeasurement of domain.GrowthUsecase
+func (gh *growthHandler) CreateGrowthMeasurement(c *gin.Context) {
+	req := new(createGrowthMeasurementRequest)
+	if err := gh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	gm := &domain.GrowthMeasurement{
+		ChildrenUUID:      domain.String(req.ChildrenUUID),
+		Height:            req.Height,
+		Weight:            req.Weight,
+		HeadCircumference: req.HeadCircumference,
+	}
+
+	if mt, err := time.Parse(time.RFC3339, req.MeasuredAt); err != nil {
+		err = errors.Wrap(err, "failed to parse measured_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		gm.MeasuredAt = domain.Time(mt)
+	}
+
+	switch uuid, err := gh.gUsecase.CreateGrowthMeasurement(c.Request.Context(), c.GetString("uuid"), gm); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new growth measurement")
+		resp["growth_measurement_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateGrowthMeasurement return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetGrowthChart deliver data to GetGrowthChart of domain.GrowthUsecase
+func (gh *growthHandler) GetGrowthChart(c *gin.Context) {
+	req := new(getGrowthChartRequest)
+	if err := gh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	points, err := gh.gUsecase.GetGrowthChart(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get growth chart")
+		resp["points"] = points
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetGrowthChart return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (gh *growthHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := gh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createGrowthMeasurementRequest is request for growthHandler.CreateGrowthMeasurement
+// height & head_circumference is in cm and weight is in kg, and unmeasured value is omitted
+type createGrowthMeasurementRequest struct {
+	ChildrenUUID      string   `uri:"children_uuid" validate:"required,uuid=children"`
+	MeasuredAt        string   `json:"measured_at" validate:"required,max=30"`
+	Height            *float64 `json:"height" validate:"omitempty,min=30,max=130"`
+	Weight            *float64 `json:"weight" validate:"omitempty,min=0.5,max=40"`
+	HeadCircumference *float64 `json:"head_circumference" validate:"omitempty,min=20,max=60"`
+}
+
+func (r *createGrowthMeasurementRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getGrowthChartRequest is request for growthHandler.GetGrowthChart
+type getGrowthChartRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getGrowthChartRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
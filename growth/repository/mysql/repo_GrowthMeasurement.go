@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// growthMeasurementRepository is implementation of domain.GrowthMeasurementRepository using mysql
+type growthMeasurementRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// GrowthMeasurementRepository return implementation of domain.GrowthMeasurementRepository using mysql
+func GrowthMeasurementRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.GrowthMeasurementRepository {
+	repo := &growthMeasurementRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.GrowthMeasurement{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate growth measurement model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.GrowthMeasurementRepository interface
+func (gmr *growthMeasurementRepository) Store(ctx tx.Context, gm *domain.GrowthMeasurement) (err error) {
+	if domain.StringValue(gm.UUID) == "" {
+		if gm.UUID, err = gmr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = gmr.validator.ValidateStruct(gm); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.GrowthMeasurement")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("growth_measurement").
+		Columns("uuid", "children_uuid", "measured_at", "height", "weight", "head_circumference").
+		Values(gm.UUID, gm.ChildrenUUID, gm.MeasuredAt, gm.Height, gm.Weight, gm.HeadCircumference).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert growth measurement")
+			fk := gmr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert growth measurement return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert growth measurement return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.GrowthMeasurementRepository interface
+func (gmr *growthMeasurementRepository) GetByUUID(ctx tx.Context, uuid string) (gm domain.GrowthMeasurement, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("growth_measurement").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&gm, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select growth measurement")}
+	default:
+		err = errors.Wrap(err, "select growth measurement return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.GrowthMeasurementRepository interface
+func (gmr *growthMeasurementRepository) GetByChildrenUUID(
+	ctx tx.Context,
+	childrenUUID string,
+) (gms []domain.GrowthMeasurement, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("growth_measurement").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("measured_at").ToSql()
+
+	gms = []domain.GrowthMeasurement{}
+	if err = _tx.Select(&gms, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select growth measurements return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.GrowthMeasurementRepository interface
+func (gmr *growthMeasurementRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (gms []domain.GrowthMeasurement, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("growth_measurement").
+		Where("children_uuid = ?", childrenUUID).
+		Where("measured_at >= ? AND measured_at < ?", from, to).
+		OrderBy("measured_at").ToSql()
+
+	gms = []domain.GrowthMeasurement{}
+	if err = _tx.Select(&gms, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select growth measurements return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of growth_measurement table
+func (gmr *growthMeasurementRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	gm := new(domain.GrowthMeasurement)
+
+	for {
+		uuid := gm.GenerateRandomUUID()
+		_, err := gmr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
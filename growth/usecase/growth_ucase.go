@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// growthUsecase is used for usecase layer which implement domain.GrowthUsecase interface
+type growthUsecase struct {
+	// standard is growth standard used for percentile of measurements
+	standard domain.GrowthStandard
+
+	// growthMeasurementRepository is repository interface about domain.GrowthMeasurement model
+	growthMeasurementRepository domain.GrowthMeasurementRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// GrowthUsecase return implementation of domain.GrowthUsecase
+func GrowthUsecase(
+	gs domain.GrowthStandard,
+	gmr domain.GrowthMeasurementRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.GrowthUsecase {
+	return &growthUsecase{
+		standard:                    gs,
+		growthMeasurementRepository: gmr,
+		childrenRepository:          cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreateGrowthMeasurement implement CreateGrowthMeasurement method of domain.GrowthUsecase interface
+func (gu *growthUsecase) CreateGrowthMeasurement(
+	ctx context.Context,
+	parentUUID string,
+	gm *domain.GrowthMeasurement,
+) (uuid string, err error) {
+	if gm.Height == nil && gm.Weight == nil && gm.HeadCircumference == nil {
+		err = errors.New("at least one of height, weight & head circumference must be measured")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := gu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := gu.getOwnChildren(_tx, parentUUID, domain.StringValue(gm.ChildrenUUID))
+	if err != nil {
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if at := domain.TimeValue(gm.MeasuredAt); at.Before(domain.TimeValue(c.Birth)) || at.After(time.Now()) {
+		err = errors.New("measured_at must be between birth of children and now")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = gu.growthMeasurementRepository.Store(_tx, gm); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "growth measurement Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "growth measurement Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(gm.UUID)
+	_ = gu.txHandler.Commit(_tx)
+	return
+}
+
+// GetGrowthChart implement GetGrowthChart method of domain.GrowthUsecase interface
+func (gu *growthUsecase) GetGrowthChart(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (points []domain.GrowthPoint, err error) {
+	_tx, err := gu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := gu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		points = []domain.GrowthPoint{}
+		_ = gu.txHandler.Commit(_tx)
+		return
+	}
+
+	measurements, err := gu.growthMeasurementRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "growth measurement GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = gu.txHandler.Rollback(_tx)
+		return
+	}
+	_ = gu.txHandler.Commit(_tx)
+
+	points = domain.NewGrowthPoints(gu.standard, domain.TimeValue(c.Birth), domain.StringValue(c.Sex), measurements)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (gu *growthUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = gu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about health domain
+var App *healthConfig
+
+// init function initialize App global variable
+func init() {
+	App = &healthConfig{}
+}
+
+// healthConfig have config value and implement various interface about health config
+type healthConfig struct {
+	// healthDocumentS3Bucket represent aws s3 bucket for health document
+	healthDocumentS3Bucket *string
+
+	// downloadLinkDuration represent time valid duration for health document download link
+	downloadLinkDuration *time.Duration
+}
+
+// default const value about healthConfig field
+const (
+	defaultHealthDocumentS3Bucket = "first-baby-time"
+	defaultDownloadLinkDuration   = time.Hour
+)
+
+// HealthDocumentS3Bucket implement HealthDocumentS3Bucket of healthUsecaseConfig
+func (hc *healthConfig) HealthDocumentS3Bucket() string {
+	var key = "health.healthDocumentS3Bucket"
+	if hc.healthDocumentS3Bucket == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultHealthDocumentS3Bucket)
+		}
+		hc.healthDocumentS3Bucket = _string(viper.GetString(key))
+	}
+	return *hc.healthDocumentS3Bucket
+}
+
+// DownloadLinkDuration implement DownloadLinkDuration of healthUsecaseConfig
+func (hc *healthConfig) DownloadLinkDuration() time.Duration {
+	var key = "health.downloadLinkDuration"
+	if hc.downloadLinkDuration != nil {
+		return *hc.downloadLinkDuration
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultDownloadLinkDuration.String())
+		d = defaultDownloadLinkDuration
+	}
+
+	hc.downloadLinkDuration = &d
+	return *hc.downloadLinkDuration
+}
+
+func _string(s string) *string { return &s }
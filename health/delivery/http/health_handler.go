@@ -0,0 +1,344 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// healthHandler represent the http handler for health
+type healthHandler struct {
+	hUsecase   domain.HealthUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewHealthHandler will initialize the health resources endpoint
+func NewHealthHandler(r *gin.Engine, hu domain.HealthUsecase, v validator, jh jwtHandler) {
+	h := &healthHandler{
+		hUsecase:   hu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/hospital-visits", h.jwtHandler.ParseUUIDFromToken, h.CreateHospitalVisit)
+	r.GET("children/uuid/:children_uuid/hospital-visits", h.jwtHandler.ParseUUIDFromToken, h.GetHospitalVisits)
+	r.POST("children/uuid/:children_uuid/hospital-visits/uuid/:visit_uuid/documents", h.jwtHandler.ParseUUIDFromToken, h.AttachVisitDocument)
+	r.POST("children/uuid/:children_uuid/medications", h.jwtHandler.ParseUUIDFromToken, h.CreateMedication)
+	r.GET("children/uuid/:children_uuid/medications", h.jwtHandler.ParseUUIDFromToken, h.GetMedications)
+	r.POST("children/uuid/:children_uuid/medications/uuid/:medication_uuid/doses", h.jwtHandler.ParseUUIDFromToken, h.LogMedicationDose)
+	r.GET("children/uuid/:children_uuid/medications/uuid/:medication_uuid/doses", h.jwtHandler.ParseUUIDFromToken, h.GetMedicationDoses)
+	r.GET("children/uuid/:children_uuid/health-timeline", h.jwtHandler.ParseUUIDFromToken, h.GetHealthTimeline)
+}
+
+// CreateHospitalVisit deliver data to CreateHospitalVisit of domain.HealthUsecase
+func (hh *healthHandler) CreateHospitalVisit(c *gin.Context) {
+	req := new(createHospitalVisitRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	hv := &domain.HospitalVisit{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		Clinic:       domain.String(req.Clinic),
+	}
+	if req.Diagnosis != "" {
+		hv.Diagnosis = domain.String(req.Diagnosis)
+	}
+	if req.Note != "" {
+		hv.Note = domain.String(req.Note)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", req.VisitedAt, domain.ServiceLocation); err != nil {
+		err = errors.Wrap(err, "failed to parse visited_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		hv.VisitedAt = domain.Time(t)
+	}
+
+	switch uuid, err := hh.hUsecase.CreateHospitalVisit(c.Request.Context(), c.GetString("uuid"), hv); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new hospital visit")
+		resp["visit_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateHospitalVisit return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetHospitalVisits deliver data to GetHospitalVisits of domain.HealthUsecase
+func (hh *healthHandler) GetHospitalVisits(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	visits, err := hh.hUsecase.GetHospitalVisits(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get hospital visits")
+		resp["hospital_visits"] = visits
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetHospitalVisits return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// AttachVisitDocument deliver first file part of multipart body to AttachVisitDocument of domain.HealthUsecase
+func (hh *healthHandler) AttachVisitDocument(c *gin.Context) {
+	req := new(attachVisitDocumentRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		err = errors.Wrap(err, "failed to read multipart body")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, "there is no document to attach in body"))
+			return
+		} else if err != nil {
+			err = errors.Wrap(err, "failed to read next part of multipart body")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		}
+		if part.FileName() == "" {
+			_ = part.Close()
+			continue
+		}
+
+		hd := &domain.HospitalVisitDocument{
+			VisitUUID:   domain.String(req.VisitUUID),
+			FileName:    domain.String(part.FileName()),
+			ContentType: domain.String(part.Header.Get("Content-Type")),
+		}
+		uri, err := hh.hUsecase.AttachVisitDocument(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, hd, part)
+		_ = part.Close()
+
+		switch tErr := err.(type) {
+		case nil:
+			resp := defaultResp(http.StatusCreated, 0, "succeed to attach document to hospital visit")
+			resp["document_uri"] = uri
+			c.JSON(http.StatusCreated, resp)
+		case domain.UsecaseError:
+			c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+		default:
+			msg := errors.Wrap(err, "AttachVisitDocument return unexpected error").Error()
+			c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+		}
+		return
+	}
+}
+
+// CreateMedication deliver data to CreateMedication of domain.HealthUsecase
+func (hh *healthHandler) CreateMedication(c *gin.Context) {
+	req := new(createMedicationRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	m := &domain.Medication{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		Name:         domain.String(req.Name),
+		Dose:         domain.String(req.Dose),
+		Frequency:    domain.String(req.Frequency),
+	}
+	if req.VisitUUID != "" {
+		m.VisitUUID = domain.String(req.VisitUUID)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", req.StartDate, domain.ServiceLocation); err != nil {
+		err = errors.Wrap(err, "failed to parse start_date time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		m.StartDate = domain.Time(t)
+	}
+	if req.EndDate != "" {
+		if t, err := time.ParseInLocation("2006-01-02", req.EndDate, domain.ServiceLocation); err != nil {
+			err = errors.Wrap(err, "failed to parse end_date time string")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		} else {
+			m.EndDate = domain.Time(t)
+		}
+	}
+
+	switch uuid, err := hh.hUsecase.CreateMedication(c.Request.Context(), c.GetString("uuid"), m); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new medication")
+		resp["medication_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateMedication return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetMedications deliver data to GetMedications of domain.HealthUsecase
+func (hh *healthHandler) GetMedications(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	medications, err := hh.hUsecase.GetMedications(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get medications")
+		resp["medications"] = medications
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetMedications return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// LogMedicationDose deliver data to LogMedicationDose of domain.HealthUsecase
+func (hh *healthHandler) LogMedicationDose(c *gin.Context) {
+	req := new(logMedicationDoseRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	md := &domain.MedicationDose{
+		MedicationUUID: domain.String(req.MedicationUUID),
+	}
+	if req.Amount != "" {
+		md.Amount = domain.String(req.Amount)
+	}
+	if req.Note != "" {
+		md.Note = domain.String(req.Note)
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.GivenAt); err != nil {
+		err = errors.Wrap(err, "failed to parse given_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		md.GivenAt = domain.Time(t)
+	}
+
+	switch uuid, err := hh.hUsecase.LogMedicationDose(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, md); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to log medication dose")
+		resp["dose_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "LogMedicationDose return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetMedicationDoses deliver data to GetMedicationDoses of domain.HealthUsecase
+func (hh *healthHandler) GetMedicationDoses(c *gin.Context) {
+	req := new(getMedicationDosesRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	doses, err := hh.hUsecase.GetMedicationDoses(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.MedicationUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get medication doses")
+		resp["doses"] = doses
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetMedicationDoses return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetHealthTimeline deliver data to GetHealthTimeline of domain.HealthUsecase
+func (hh *healthHandler) GetHealthTimeline(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := hh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	items, err := hh.hUsecase.GetHealthTimeline(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get health timeline")
+		resp["timeline"] = items
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetHealthTimeline return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (hh *healthHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := hh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createHospitalVisitRequest is request for healthHandler.CreateHospitalVisit
+type createHospitalVisitRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	VisitedAt    string `json:"visited_at" validate:"required,len=10"`
+	Clinic       string `json:"clinic" validate:"required,max=50"`
+	Diagnosis    string `json:"diagnosis" validate:"max=100"`
+	Note         string `json:"note" validate:"max=1000"`
+}
+
+func (r *createHospitalVisitRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// attachVisitDocumentRequest is request for healthHandler.AttachVisitDocument
+// document is read from multipart body as stream, not bound in this request
+type attachVisitDocumentRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	VisitUUID    string `uri:"visit_uuid" validate:"required,uuid=hospital_visit"`
+}
+
+func (r *attachVisitDocumentRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// createMedicationRequest is request for healthHandler.CreateMedication
+type createMedicationRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	VisitUUID    string `json:"visit_uuid" validate:"omitempty,uuid=hospital_visit"`
+	Name         string `json:"name" validate:"required,max=50"`
+	Dose         string `json:"dose" validate:"required,max=30"`
+	Frequency    string `json:"frequency" validate:"required,max=30"`
+	StartDate    string `json:"start_date" validate:"required,len=10"`
+	EndDate      string `json:"end_date" validate:"omitempty,len=10"`
+}
+
+func (r *createMedicationRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// logMedicationDoseRequest is request for healthHandler.LogMedicationDose
+type logMedicationDoseRequest struct {
+	ChildrenUUID   string `uri:"children_uuid" validate:"required,uuid=children"`
+	MedicationUUID string `uri:"medication_uuid" validate:"required,uuid=medication"`
+	GivenAt        string `json:"given_at" validate:"required,max=30"`
+	Amount         string `json:"amount" validate:"max=30"`
+	Note           string `json:"note" validate:"max=200"`
+}
+
+func (r *logMedicationDoseRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getMedicationDosesRequest is request for healthHandler.GetMedicationDoses
+type getMedicationDosesRequest struct {
+	ChildrenUUID   string `uri:"children_uuid" validate:"required,uuid=children"`
+	MedicationUUID string `uri:"medication_uuid" validate:"required,uuid=medication"`
+}
+
+func (r *getMedicationDosesRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// childrenUUIDRequest is request having only children uuid in uri
+// used for healthHandler.GetHospitalVisits, GetMedications & GetHealthTimeline
+type childrenUUIDRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *childrenUUIDRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
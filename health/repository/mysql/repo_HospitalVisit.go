@@ -0,0 +1,213 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// hospitalVisitRepository is implementation of domain.HospitalVisitRepository using mysql
+type hospitalVisitRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// HospitalVisitRepository return implementation of domain.HospitalVisitRepository using mysql
+func HospitalVisitRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.HospitalVisitRepository {
+	repo := &hospitalVisitRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.HospitalVisit{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate hospital visit model").Error())
+	}
+	if err := repo.migrator.MigrateModel(repo.db, domain.HospitalVisitDocument{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate hospital visit document model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.HospitalVisitRepository interface
+func (hr *hospitalVisitRepository) Store(ctx tx.Context, hv *domain.HospitalVisit) (err error) {
+	if domain.StringValue(hv.UUID) == "" {
+		if hv.UUID, err = hr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = hr.validator.ValidateStruct(hv); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.HospitalVisit")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("hospital_visit").
+		Columns("uuid", "children_uuid", "visited_at", "clinic", "diagnosis", "note").
+		Values(hv.UUID, hv.ChildrenUUID, hv.VisitedAt, hv.Clinic, hv.Diagnosis, hv.Note).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert hospital visit")
+			fk := hr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert hospital visit return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert hospital visit return unexpected error type")
+	}
+	return
+}
+
+// StoreDocument is implement StoreDocument method of domain.HospitalVisitRepository interface
+func (hr *hospitalVisitRepository) StoreDocument(ctx tx.Context, hd *domain.HospitalVisitDocument) (err error) {
+	if err = hr.validator.ValidateStruct(hd); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.HospitalVisitDocument")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("hospital_visit_document").
+		Columns("visit_uuid", "seq", "file_name", "content_type", "document_uri").
+		Values(hd.VisitUUID, hd.Seq, hd.FileName, hd.ContentType, hd.DocumentUri).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert hospital visit document")
+			_, key := hr.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert hospital visit document")
+			fk := hr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert hospital visit document return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert hospital visit document return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.HospitalVisitRepository interface
+func (hr *hospitalVisitRepository) GetByUUID(ctx tx.Context, uuid string) (hv domain.HospitalVisit, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("hospital_visit").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&hv, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select hospital visit")}
+		return
+	default:
+		err = errors.Wrap(err, "select hospital visit return unexpected error")
+		return
+	}
+
+	hvs := []domain.HospitalVisit{hv}
+	err = hr.fillDocuments(_tx, hvs)
+	hv = hvs[0]
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.HospitalVisitRepository interface
+func (hr *hospitalVisitRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (hvs []domain.HospitalVisit, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("hospital_visit").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("visited_at").ToSql()
+
+	hvs = []domain.HospitalVisit{}
+	if err = _tx.Select(&hvs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select hospital visits return unexpected error")
+		return
+	}
+
+	err = hr.fillDocuments(_tx, hvs)
+	return
+}
+
+// fillDocuments method select documents of hospital visits & set Documents field of each visit
+func (hr *hospitalVisitRepository) fillDocuments(_tx *sqlx.Tx, hvs []domain.HospitalVisit) (err error) {
+	if len(hvs) == 0 {
+		return
+	}
+
+	uuids := make([]string, len(hvs))
+	for i, hv := range hvs {
+		uuids[i] = domain.StringValue(hv.UUID)
+	}
+
+	_sql, args, _ := squirrel.Select("*").From("hospital_visit_document").
+		Where(squirrel.Eq{"visit_uuid": uuids}).
+		OrderBy("visit_uuid", "seq").ToSql()
+
+	var docs []domain.HospitalVisitDocument
+	if err = _tx.Select(&docs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select hospital visit documents return unexpected error")
+		return
+	}
+
+	byVisit := map[string][]domain.HospitalVisitDocument{}
+	for _, d := range docs {
+		byVisit[domain.StringValue(d.VisitUUID)] = append(byVisit[domain.StringValue(d.VisitUUID)], d)
+	}
+	for i := range hvs {
+		hvs[i].Documents = byVisit[domain.StringValue(hvs[i].UUID)]
+		if hvs[i].Documents == nil {
+			hvs[i].Documents = []domain.HospitalVisitDocument{}
+		}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of hospital visit table
+func (hr *hospitalVisitRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	hv := new(domain.HospitalVisit)
+
+	for {
+		uuid := hv.GenerateRandomUUID()
+		_, err := hr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
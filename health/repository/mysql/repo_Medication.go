@@ -0,0 +1,225 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// medicationRepository is implementation of domain.MedicationRepository using mysql
+type medicationRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// MedicationRepository return implementation of domain.MedicationRepository using mysql
+// hospital visit model must be migrated before because medication refer to hospital visit
+func MedicationRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.MedicationRepository {
+	repo := &medicationRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Medication{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate medication model").Error())
+	}
+	if err := repo.migrator.MigrateModel(repo.db, domain.MedicationDose{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate medication dose model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.MedicationRepository interface
+func (mr *medicationRepository) Store(ctx tx.Context, m *domain.Medication) (err error) {
+	if domain.StringValue(m.UUID) == "" {
+		if m.UUID, err = mr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = mr.validator.ValidateStruct(m); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Medication")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("medication").
+		Columns("uuid", "children_uuid", "visit_uuid", "name", "dose", "frequency", "start_date", "end_date").
+		Values(m.UUID, m.ChildrenUUID, m.VisitUUID, m.Name, m.Dose, m.Frequency, m.StartDate, m.EndDate).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert medication")
+			fk := mr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert medication return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert medication return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.MedicationRepository interface
+func (mr *medicationRepository) GetByUUID(ctx tx.Context, uuid string) (m domain.Medication, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("medication").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&m, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select medication")}
+	default:
+		err = errors.Wrap(err, "select medication return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.MedicationRepository interface
+func (mr *medicationRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (ms []domain.Medication, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("medication").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("start_date").ToSql()
+
+	ms = []domain.Medication{}
+	if err = _tx.Select(&ms, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select medications return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of medication table
+func (mr *medicationRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	m := new(domain.Medication)
+
+	for {
+		uuid := m.GenerateRandomUUID()
+		_, err := mr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
+
+// StoreDose is implement StoreDose method of domain.MedicationRepository interface
+func (mr *medicationRepository) StoreDose(ctx tx.Context, md *domain.MedicationDose) (err error) {
+	if domain.StringValue(md.UUID) == "" {
+		if md.UUID, err = mr.GetAvailableDoseUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableDoseUUID")
+		}
+	}
+
+	if err = mr.validator.ValidateStruct(md); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.MedicationDose")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("medication_dose").
+		Columns("uuid", "medication_uuid", "given_at", "amount", "note").
+		Values(md.UUID, md.MedicationUUID, md.GivenAt, md.Amount, md.Note).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert medication dose")
+			fk := mr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert medication dose return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert medication dose return unexpected error type")
+	}
+	return
+}
+
+// GetDoseByUUID is implement GetDoseByUUID method of domain.MedicationRepository interface
+func (mr *medicationRepository) GetDoseByUUID(ctx tx.Context, uuid string) (md domain.MedicationDose, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("medication_dose").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&md, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select medication dose")}
+	default:
+		err = errors.Wrap(err, "select medication dose return unexpected error")
+	}
+	return
+}
+
+// GetDosesByMedicationUUID is implement GetDosesByMedicationUUID method of domain.MedicationRepository interface
+func (mr *medicationRepository) GetDosesByMedicationUUID(ctx tx.Context, medicationUUID string) (mds []domain.MedicationDose, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("medication_dose").
+		Where("medication_uuid = ?", medicationUUID).
+		OrderBy("given_at").ToSql()
+
+	mds = []domain.MedicationDose{}
+	if err = _tx.Select(&mds, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select medication doses return unexpected error")
+	}
+	return
+}
+
+// GetDosesByChildrenUUID is implement GetDosesByChildrenUUID method of domain.MedicationRepository interface
+func (mr *medicationRepository) GetDosesByChildrenUUID(ctx tx.Context, childrenUUID string) (mds []domain.MedicationDose, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("medication_dose.*").From("medication_dose").
+		Join("medication ON medication.uuid = medication_dose.medication_uuid").
+		Where("medication.children_uuid = ?", childrenUUID).
+		OrderBy("medication_dose.given_at").ToSql()
+
+	mds = []domain.MedicationDose{}
+	if err = _tx.Select(&mds, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select medication doses return unexpected error")
+	}
+	return
+}
+
+// GetAvailableDoseUUID method return available uuid of medication dose table
+func (mr *medicationRepository) GetAvailableDoseUUID(ctx tx.Context) (*string, error) {
+	md := new(domain.MedicationDose)
+
+	for {
+		uuid := md.GenerateRandomUUID()
+		_, err := mr.GetDoseByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetDoseByUUID")
+		}
+	}
+}
@@ -0,0 +1,560 @@
+package usecase
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxDocumentCount is max count of documents that can be attached to one hospital visit
+const maxDocumentCount = 50
+
+// healthUsecase is used for usecase layer which implement domain.HealthUsecase interface
+type healthUsecase struct {
+	// myCfg is used for get config value for health usecase
+	myCfg healthUsecaseConfig
+
+	// hospitalVisitRepository is repository interface about domain.HospitalVisit model
+	hospitalVisitRepository domain.HospitalVisitRepository
+
+	// medicationRepository is repository interface about domain.Medication model
+	medicationRepository domain.MedicationRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// s3Agency is used as agency about aws s3 API
+	s3Agency s3Agency
+}
+
+// HealthUsecase return implementation of domain.HealthUsecase
+func HealthUsecase(
+	cfg healthUsecaseConfig,
+	hr domain.HospitalVisitRepository,
+	mr domain.MedicationRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+	sa s3Agency,
+) domain.HealthUsecase {
+	return &healthUsecase{
+		myCfg:                   cfg,
+		hospitalVisitRepository: hr,
+		medicationRepository:    mr,
+		childrenRepository:      cr,
+
+		txHandler: th,
+		s3Agency:  sa,
+	}
+}
+
+// healthUsecaseConfig is interface get config value for health usecase
+type healthUsecaseConfig interface {
+	// HealthDocumentS3Bucket return aws s3 bucket name for health document
+	HealthDocumentS3Bucket() string
+
+	// DownloadLinkDuration return valid duration of health document download link
+	DownloadLinkDuration() time.Duration
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// s3Agency is agency that agent various API about aws s3
+type s3Agency interface {
+	// Upload method upload object to s3 streaming body in parts
+	Upload(input *s3manager.UploadInput) (output *s3manager.UploadOutput, err error)
+
+	// DeleteObject method delete object from s3
+	DeleteObject(input *s3.DeleteObjectInput) (output *s3.DeleteObjectOutput, err error)
+
+	// PresignGetObject method return url that anyone can download object with until expire
+	PresignGetObject(input *s3.GetObjectInput, expire time.Duration) (url string, err error)
+}
+
+// CreateHospitalVisit implement CreateHospitalVisit method of domain.HealthUsecase interface
+func (hu *healthUsecase) CreateHospitalVisit(ctx context.Context, parentUUID string, hv *domain.HospitalVisit) (uuid string, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, domain.StringValue(hv.ChildrenUUID)); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = hu.hospitalVisitRepository.Store(_tx, hv); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "hospital visit Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "hospital visit Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(hv.UUID)
+	_ = hu.txHandler.Commit(_tx)
+	return
+}
+
+// GetHospitalVisits implement GetHospitalVisits method of domain.HealthUsecase interface
+func (hu *healthUsecase) GetHospitalVisits(ctx context.Context, parentUUID, childrenUUID string) (visits []domain.HospitalVisit, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if visits, err = hu.hospitalVisitRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "hospital visit GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = hu.txHandler.Commit(_tx)
+	err = hu.setDownloadUrls(visits)
+	return
+}
+
+// setDownloadUrls method set presigned download url to each document of hospital visits
+func (hu *healthUsecase) setDownloadUrls(visits []domain.HospitalVisit) (err error) {
+	for i := range visits {
+		for j := range visits[i].Documents {
+			hd := &visits[i].Documents[j]
+			if hd.DownloadUrl, err = hu.s3Agency.PresignGetObject(&s3.GetObjectInput{
+				Bucket: aws.String(hu.myCfg.HealthDocumentS3Bucket()),
+				Key:    hd.DocumentUri,
+			}, hu.myCfg.DownloadLinkDuration()); err != nil {
+				err = errors.Wrap(err, "s3 PresignGetObject return unexpected error")
+				err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+				return
+			}
+		}
+	}
+	return
+}
+
+// AttachVisitDocument implement AttachVisitDocument method of domain.HealthUsecase interface
+// document is streamed to s3 out of transaction & its sequence is decided after upload, in its own transaction
+func (hu *healthUsecase) AttachVisitDocument(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	hd *domain.HospitalVisitDocument,
+	body io.Reader,
+) (uri string, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	hv, err := hu.getChildrenVisit(_tx, childrenUUID, domain.StringValue(hd.VisitUUID))
+	if err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+	_ = hu.txHandler.Commit(_tx)
+
+	if len(hv.Documents) >= maxDocumentCount {
+		err = errors.Errorf("hospital visit can have up to %d documents", maxDocumentCount)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+	hd.DocumentUri = domain.String(hv.GenerateDocumentUri())
+
+	if _, err = hu.s3Agency.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(hu.myCfg.HealthDocumentS3Bucket()),
+		Key:         hd.DocumentUri,
+		Body:        body,
+		ContentType: hd.ContentType,
+	}); err != nil {
+		err = errors.Wrap(err, "s3 Upload return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if err = hu.storeVisitDocument(ctx, childrenUUID, hd); err != nil {
+		_, _ = hu.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(hu.myCfg.HealthDocumentS3Bucket()),
+			Key:    hd.DocumentUri,
+		})
+		return
+	}
+
+	uri = domain.StringValue(hd.DocumentUri)
+	return
+}
+
+// storeVisitDocument method store metadata of document uploaded to s3 with next sequence of hospital visit
+func (hu *healthUsecase) storeVisitDocument(ctx context.Context, childrenUUID string, hd *domain.HospitalVisitDocument) (err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	hv, err := hu.getChildrenVisit(_tx, childrenUUID, domain.StringValue(hd.VisitUUID))
+	if err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if len(hv.Documents) >= maxDocumentCount {
+		err = errors.Errorf("hospital visit can have up to %d documents", maxDocumentCount)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+	hd.Seq = domain.Int64(int64(len(hv.Documents) + 1))
+
+	switch err = hu.hospitalVisitRepository.StoreDocument(_tx, hd); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "hospital visit StoreDocument return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrEntryDuplicate:
+		err = errors.New("other document is being attached to that hospital visit, please retry")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "hospital visit StoreDocument return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = hu.txHandler.Commit(_tx); err != nil {
+		err = errors.Wrap(err, "failed to commit transaction")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// CreateMedication implement CreateMedication method of domain.HealthUsecase interface
+func (hu *healthUsecase) CreateMedication(ctx context.Context, parentUUID string, m *domain.Medication) (uuid string, err error) {
+	if m.EndDate != nil && domain.TimeValue(m.EndDate).Before(domain.TimeValue(m.StartDate)) {
+		err = errors.New("end_date must not be before start_date")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, domain.StringValue(m.ChildrenUUID)); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if m.VisitUUID != nil {
+		if _, err = hu.getChildrenVisit(_tx, domain.StringValue(m.ChildrenUUID), domain.StringValue(m.VisitUUID)); err != nil {
+			_ = hu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	switch err = hu.medicationRepository.Store(_tx, m); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "medication Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "medication Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(m.UUID)
+	_ = hu.txHandler.Commit(_tx)
+	return
+}
+
+// GetMedications implement GetMedications method of domain.HealthUsecase interface
+func (hu *healthUsecase) GetMedications(ctx context.Context, parentUUID, childrenUUID string) (medications []domain.Medication, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if medications, err = hu.medicationRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "medication GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = hu.txHandler.Commit(_tx)
+	return
+}
+
+// LogMedicationDose implement LogMedicationDose method of domain.HealthUsecase interface
+func (hu *healthUsecase) LogMedicationDose(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	md *domain.MedicationDose,
+) (uuid string, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if _, err = hu.getChildrenMedication(_tx, childrenUUID, domain.StringValue(md.MedicationUUID)); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = hu.medicationRepository.StoreDose(_tx, md); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "medication StoreDose return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "medication StoreDose return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(md.UUID)
+	_ = hu.txHandler.Commit(_tx)
+	return
+}
+
+// GetMedicationDoses implement GetMedicationDoses method of domain.HealthUsecase interface
+func (hu *healthUsecase) GetMedicationDoses(
+	ctx context.Context,
+	parentUUID, childrenUUID, medicationUUID string,
+) (doses []domain.MedicationDose, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if _, err = hu.getChildrenMedication(_tx, childrenUUID, medicationUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if doses, err = hu.medicationRepository.GetDosesByMedicationUUID(_tx, medicationUUID); err != nil {
+		err = errors.Wrap(err, "medication GetDosesByMedicationUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = hu.txHandler.Commit(_tx)
+	return
+}
+
+// GetHealthTimeline implement GetHealthTimeline method of domain.HealthUsecase interface
+func (hu *healthUsecase) GetHealthTimeline(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (items []domain.HealthTimelineItem, err error) {
+	_tx, err := hu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = hu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	visits, err := hu.hospitalVisitRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "hospital visit GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	medications, err := hu.medicationRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "medication GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	doses, err := hu.medicationRepository.GetDosesByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "medication GetDosesByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = hu.txHandler.Rollback(_tx)
+		return
+	}
+
+	items = []domain.HealthTimelineItem{}
+	for i := range visits {
+		items = append(items, domain.HealthTimelineItem{
+			Type:          domain.HealthTimelineHospitalVisit,
+			At:            domain.TimeValue(visits[i].VisitedAt),
+			HospitalVisit: &visits[i],
+		})
+	}
+	for i := range medications {
+		items = append(items, domain.HealthTimelineItem{
+			Type:       domain.HealthTimelineMedicationStart,
+			At:         domain.TimeValue(medications[i].StartDate),
+			Medication: &medications[i],
+		})
+		if medications[i].EndDate != nil {
+			items = append(items, domain.HealthTimelineItem{
+				Type:       domain.HealthTimelineMedicationEnd,
+				At:         domain.TimeValue(medications[i].EndDate),
+				Medication: &medications[i],
+			})
+		}
+	}
+	for i := range doses {
+		items = append(items, domain.HealthTimelineItem{
+			Type:           domain.HealthTimelineMedicationDose,
+			At:             domain.TimeValue(doses[i].GivenAt),
+			MedicationDose: &doses[i],
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].At.Before(items[j].At) })
+
+	_ = hu.txHandler.Commit(_tx)
+	err = hu.setDownloadUrls(visits)
+	return
+}
+
+// getChildrenVisit method return hospital visit with uuid if that visit is of children with childrenUUID
+func (hu *healthUsecase) getChildrenVisit(_tx tx.Context, childrenUUID, visitUUID string) (hv domain.HospitalVisit, err error) {
+	switch hv, err = hu.hospitalVisitRepository.GetByUUID(_tx, visitUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("hospital visit with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "hospital visit GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(hv.ChildrenUUID) != childrenUUID {
+		err = errors.New("hospital visit with that uuid is not exist in that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+	}
+	return
+}
+
+// getChildrenMedication method return medication with uuid if that medication is of children with childrenUUID
+func (hu *healthUsecase) getChildrenMedication(_tx tx.Context, childrenUUID, medicationUUID string) (m domain.Medication, err error) {
+	switch m, err = hu.medicationRepository.GetByUUID(_tx, medicationUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("medication with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "medication GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(m.ChildrenUUID) != childrenUUID {
+		err = errors.New("medication with that uuid is not exist in that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+	}
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (hu *healthUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = hu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// catalogFile is name of embedded milestone catalog file
+// age windows follow WHO motor development study & CDC milestone checklist
+const catalogFile = "data/milestones.json"
+
+//go:embed data/*.json
+var embedded embed.FS
+
+// Load function return standard milestones read from embedded catalog file
+func Load() (catalog []domain.MilestoneDefinition, err error) {
+	b, err := embedded.ReadFile(catalogFile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read milestone catalog file")
+		return
+	}
+
+	if err = json.Unmarshal(b, &catalog); err != nil {
+		err = errors.Wrap(err, "failed to unmarshal milestone catalog")
+		return
+	}
+
+	for _, md := range catalog {
+		if md.Code == "" || md.StartMonth > md.EndMonth {
+			err = errors.Errorf("invalid milestone definition in catalog, code: %q", md.Code)
+			return
+		}
+	}
+	return
+}
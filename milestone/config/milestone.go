@@ -0,0 +1,36 @@
+package config
+
+import "github.com/spf13/viper"
+
+// App is the application config about milestone domain
+var App *milestoneConfig
+
+// init function initialize App global variable
+func init() {
+	App = &milestoneConfig{}
+}
+
+// milestoneConfig have config value and implement various interface about milestone config
+type milestoneConfig struct {
+	// milestonePhotoS3Bucket represent aws s3 bucket for milestone photo
+	milestonePhotoS3Bucket *string
+}
+
+// default const value about milestoneConfig field
+const (
+	defaultMilestonePhotoS3Bucket = "first-baby-time"
+)
+
+// MilestonePhotoS3Bucket implement MilestonePhotoS3Bucket of milestoneUsecaseConfig
+func (mc *milestoneConfig) MilestonePhotoS3Bucket() string {
+	var key = "milestone.milestonePhotoS3Bucket"
+	if mc.milestonePhotoS3Bucket == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultMilestonePhotoS3Bucket)
+		}
+		mc.milestonePhotoS3Bucket = _string(viper.GetString(key))
+	}
+	return *mc.milestonePhotoS3Bucket
+}
+
+func _string(s string) *string { return &s }
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"encoding/base64"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// milestoneHandler represent the http handler for milestone
+type milestoneHandler struct {
+	mUsecase   domain.MilestoneUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewMilestoneHandler will initialize the milestone resources endpoint
+func NewMilestoneHandler(r *gin.Engine, mu domain.MilestoneUsecase, v validator, jh jwtHandler) {
+	h := &milestoneHandler{
+		mUsecase:   mu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("milestones/catalog", h.GetMilestoneCatalog)
+	r.POST("children/uuid/:children_uuid/milestones", h.jwtHandler.ParseUUIDFromToken, h.CreateMilestoneRecord)
+	r.GET("children/uuid/:children_uuid/milestones", h.jwtHandler.ParseUUIDFromToken, h.GetMilestoneRecords)
+	r.GET("children/uuid/:children_uuid/milestones/comparison", h.jwtHandler.ParseUUIDFromToken, h.CompareMilestones)
+}
+
+// GetMilestoneCatalog deliver data to GetMilestoneCatalog of domain.MilestoneUsecase
+func (mh *milestoneHandler) GetMilestoneCatalog(c *gin.Context) {
+	resp := defaultResp(http.StatusOK, 0, "succeed to get milestone catalog")
+	resp["milestones"] = mh.mUsecase.GetMilestoneCatalog(c.Request.Context())
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateMilestoneRecord deliver data to CreateMilestoneRecord of domain.MilestoneUsecase
+func (mh *milestoneHandler) CreateMilestoneRecord(c *gin.Context) {
+	req := new(createMilestoneRecordRequest)
+	if err := mh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	mr := &domain.MilestoneRecord{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+	}
+	if req.MilestoneCode != "" {
+		mr.MilestoneCode = domain.String(req.MilestoneCode)
+	}
+	if req.Title != "" {
+		mr.Title = domain.String(req.Title)
+	}
+	if req.Note != "" {
+		mr.Note = domain.String(req.Note)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", req.AchievedAt, domain.ServiceLocation); err != nil {
+		err = errors.Wrap(err, "failed to parse achieved_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		mr.AchievedAt = domain.Time(t)
+	}
+
+	var photos [][]byte
+	for _, fh := range req.Photos {
+		file, err := fh.Open()
+		if err != nil {
+			err = errors.Wrap(err, "failed to open photo file")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		}
+		photo, err := ioutil.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			err = errors.Wrap(err, "failed to read photo file")
+			c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, err.Error()))
+			return
+		}
+		photos = append(photos, photo)
+	}
+	for _, s := range req.PhotosBase64 {
+		s = string(regexp.MustCompile("^data:image/\\w+;base64,").ReplaceAll([]byte(s), []byte("")))
+		photo, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			err = errors.Wrap(err, "failed to decode base64 string to byte array")
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+			return
+		}
+		photos = append(photos, photo)
+	}
+
+	switch uuid, err := mh.mUsecase.CreateMilestoneRecord(c.Request.Context(), c.GetString("uuid"), mr, photos); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new milestone record")
+		resp["milestone_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateMilestoneRecord return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetMilestoneRecords deliver data to GetMilestoneRecords of domain.MilestoneUsecase
+func (mh *milestoneHandler) GetMilestoneRecords(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := mh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	records, err := mh.mUsecase.GetMilestoneRecords(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get milestone records")
+		resp["milestones"] = records
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetMilestoneRecords return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// CompareMilestones deliver data to CompareMilestones of domain.MilestoneUsecase
+func (mh *milestoneHandler) CompareMilestones(c *gin.Context) {
+	req := new(childrenUUIDRequest)
+	if err := mh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	comparisons, err := mh.mUsecase.CompareMilestones(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to compare milestones")
+		resp["comparisons"] = comparisons
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CompareMilestones return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (mh *milestoneHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := mh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"mime/multipart"
+)
+
+// createMilestoneRecordRequest is request for milestoneHandler.CreateMilestoneRecord
+// MilestoneCode is empty for custom milestone with Title
+type createMilestoneRecordRequest struct {
+	ChildrenUUID  string                  `uri:"children_uuid" validate:"required,uuid=children"`
+	MilestoneCode string                  `form:"milestone_code" json:"milestone_code" validate:"max=30"`
+	Title         string                  `form:"title" json:"title" validate:"max=50"`
+	AchievedAt    string                  `form:"achieved_at" json:"achieved_at" validate:"required,len=10"`
+	Note          string                  `form:"note" json:"note" validate:"max=500"`
+	Photos        []*multipart.FileHeader `form:"photos"`
+	PhotosBase64  []string                `json:"photos_base64"`
+}
+
+func (r *createMilestoneRecordRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+
+	switch c.ContentType() {
+	case "application/json":
+		return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+	default:
+		return errors.Wrap(c.Bind(r), "failed to Bind")
+	}
+}
+
+// childrenUUIDRequest is request having only children uuid in uri
+// used for milestoneHandler.GetMilestoneRecords & CompareMilestones
+type childrenUUIDRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *childrenUUIDRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
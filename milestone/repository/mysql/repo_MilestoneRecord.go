@@ -0,0 +1,217 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// milestoneRecordRepository is implementation of domain.MilestoneRecordRepository using mysql
+type milestoneRecordRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// MilestoneRecordRepository return implementation of domain.MilestoneRecordRepository using mysql
+func MilestoneRecordRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.MilestoneRecordRepository {
+	repo := &milestoneRecordRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.MilestoneRecord{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate milestone record model").Error())
+	}
+	if err := repo.migrator.MigrateModel(repo.db, domain.MilestonePhoto{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate milestone photo model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.MilestoneRecordRepository interface
+func (mr *milestoneRecordRepository) Store(ctx tx.Context, m *domain.MilestoneRecord) (err error) {
+	if domain.StringValue(m.UUID) == "" {
+		if m.UUID, err = mr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = mr.validator.ValidateStruct(m); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.MilestoneRecord")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("milestone_record").
+		Columns("uuid", "children_uuid", "milestone_code", "title", "achieved_at", "note").
+		Values(m.UUID, m.ChildrenUUID, m.MilestoneCode, m.Title, m.AchievedAt, m.Note).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert milestone record")
+			_, key := mr.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert milestone record")
+			fk := mr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert milestone record return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert milestone record return unexpected error type")
+	}
+	return
+}
+
+// StorePhoto is implement StorePhoto method of domain.MilestoneRecordRepository interface
+func (mr *milestoneRecordRepository) StorePhoto(ctx tx.Context, p *domain.MilestonePhoto) (err error) {
+	if err = mr.validator.ValidateStruct(p); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.MilestonePhoto")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("milestone_photo").
+		Columns("milestone_uuid", "seq", "photo_uri").
+		Values(p.MilestoneUUID, p.Seq, p.PhotoUri).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert milestone photo")
+			_, key := mr.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert milestone photo")
+			fk := mr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert milestone photo return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert milestone photo return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.MilestoneRecordRepository interface
+func (mr *milestoneRecordRepository) GetByUUID(ctx tx.Context, uuid string) (m domain.MilestoneRecord, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("milestone_record").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&m, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select milestone record")}
+		return
+	default:
+		err = errors.Wrap(err, "select milestone record return unexpected error")
+		return
+	}
+
+	ms := []domain.MilestoneRecord{m}
+	err = mr.fillPhotoUris(_tx, ms)
+	m = ms[0]
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.MilestoneRecordRepository interface
+func (mr *milestoneRecordRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (ms []domain.MilestoneRecord, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("milestone_record").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("achieved_at").ToSql()
+
+	ms = []domain.MilestoneRecord{}
+	if err = _tx.Select(&ms, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select milestone records return unexpected error")
+		return
+	}
+
+	err = mr.fillPhotoUris(_tx, ms)
+	return
+}
+
+// fillPhotoUris method select photos of milestone records & set PhotoUris field of each record
+func (mr *milestoneRecordRepository) fillPhotoUris(_tx *sqlx.Tx, ms []domain.MilestoneRecord) (err error) {
+	if len(ms) == 0 {
+		return
+	}
+
+	uuids := make([]string, len(ms))
+	for i, m := range ms {
+		uuids[i] = domain.StringValue(m.UUID)
+	}
+
+	_sql, args, _ := squirrel.Select("*").From("milestone_photo").
+		Where(squirrel.Eq{"milestone_uuid": uuids}).
+		OrderBy("milestone_uuid", "seq").ToSql()
+
+	var photos []domain.MilestonePhoto
+	if err = _tx.Select(&photos, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select milestone photos return unexpected error")
+		return
+	}
+
+	uris := map[string][]string{}
+	for _, p := range photos {
+		uris[domain.StringValue(p.MilestoneUUID)] = append(uris[domain.StringValue(p.MilestoneUUID)], domain.StringValue(p.PhotoUri))
+	}
+	for i := range ms {
+		ms[i].PhotoUris = uris[domain.StringValue(ms[i].UUID)]
+		if ms[i].PhotoUris == nil {
+			ms[i].PhotoUris = []string{}
+		}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of milestone record table
+func (mr *milestoneRecordRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	m := new(domain.MilestoneRecord)
+
+	for {
+		uuid := m.GenerateRandomUUID()
+		_, err := mr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxPhotoCount is max count of photos that can be attached to one milestone record
+const maxPhotoCount = 20
+
+// milestoneUsecase is used for usecase layer which implement domain.MilestoneUsecase interface
+type milestoneUsecase struct {
+	// myCfg is used for get config value for milestone usecase
+	myCfg milestoneUsecaseConfig
+
+	// catalog is standard milestones with typical age windows
+	catalog []domain.MilestoneDefinition
+
+	// milestoneRecordRepository is repository interface about domain.MilestoneRecord model
+	milestoneRecordRepository domain.MilestoneRecordRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// s3Agency is used as agency about aws s3 API
+	s3Agency s3Agency
+}
+
+// MilestoneUsecase return implementation of domain.MilestoneUsecase
+func MilestoneUsecase(
+	cfg milestoneUsecaseConfig,
+	catalog []domain.MilestoneDefinition,
+	mr domain.MilestoneRecordRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+	sa s3Agency,
+) domain.MilestoneUsecase {
+	return &milestoneUsecase{
+		myCfg:                     cfg,
+		catalog:                   catalog,
+		milestoneRecordRepository: mr,
+		childrenRepository:        cr,
+
+		txHandler: th,
+		s3Agency:  sa,
+	}
+}
+
+// milestoneUsecaseConfig is interface get config value for milestone usecase
+type milestoneUsecaseConfig interface {
+	// MilestonePhotoS3Bucket return aws s3 bucket name for milestone photo
+	MilestonePhotoS3Bucket() string
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// s3Agency is agency that agent various API about aws s3
+type s3Agency interface {
+	// PutObject method put(insert or update) object to s3
+	PutObject(input *s3.PutObjectInput) (output *s3.PutObjectOutput, err error)
+
+	// DeleteObject method delete object from s3
+	DeleteObject(input *s3.DeleteObjectInput) (output *s3.DeleteObjectOutput, err error)
+}
+
+// GetMilestoneCatalog implement GetMilestoneCatalog method of domain.MilestoneUsecase interface
+func (mu *milestoneUsecase) GetMilestoneCatalog(ctx context.Context) (catalog []domain.MilestoneDefinition) {
+	return mu.catalog
+}
+
+// CreateMilestoneRecord implement CreateMilestoneRecord method of domain.MilestoneUsecase interface
+// title of catalog milestone is set to name in catalog
+func (mu *milestoneUsecase) CreateMilestoneRecord(
+	ctx context.Context,
+	parentUUID string,
+	mr *domain.MilestoneRecord,
+	photos [][]byte,
+) (uuid string, err error) {
+	if len(photos) > maxPhotoCount {
+		err = errors.Errorf("milestone record can have up to %d photos", maxPhotoCount)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	if code := domain.StringValue(mr.MilestoneCode); code != "" {
+		md, ok := mu.findDefinition(code)
+		if !ok {
+			err = errors.New("milestone with that code is not exist in catalog")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			return
+		}
+		mr.Title = domain.String(md.Name)
+	} else if domain.StringValue(mr.Title) == "" {
+		err = errors.New("custom milestone must have title")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := mu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = mu.getOwnChildren(_tx, parentUUID, domain.StringValue(mr.ChildrenUUID)); err != nil {
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = mu.milestoneRecordRepository.Store(_tx, mr); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "milestone record Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrEntryDuplicate:
+		err = errors.New("children already achieved that milestone")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.MilestoneAlreadyAchieved}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "milestone record Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	// every photo is stored before any is put to s3, so that only s3 put & commit can fail after upload
+	keys := make([]*string, len(photos))
+	for i := range photos {
+		seq := int64(i + 1)
+		p := &domain.MilestonePhoto{
+			MilestoneUUID: mr.UUID,
+			Seq:           domain.Int64(seq),
+			PhotoUri:      domain.String(mr.GeneratePhotoUri(seq)),
+		}
+
+		if err = mu.milestoneRecordRepository.StorePhoto(_tx, p); err != nil {
+			err = errors.Wrap(err, "milestone record StorePhoto return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = mu.txHandler.Rollback(_tx)
+			return
+		}
+		keys[i] = p.PhotoUri
+	}
+
+	for i, photo := range photos {
+		if _, err = mu.s3Agency.PutObject(&s3.PutObjectInput{
+			Bucket: aws.String(mu.myCfg.MilestonePhotoS3Bucket()),
+			Key:    keys[i],
+			Body:   bytes.NewReader(photo),
+			ACL:    aws.String("public-read"),
+		}); err != nil {
+			err = errors.Wrap(err, "s3 PutObject return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = mu.txHandler.Rollback(_tx)
+			mu.deletePhotos(keys[:i])
+			return
+		}
+	}
+
+	if err = mu.txHandler.Commit(_tx); err != nil {
+		err = errors.Wrap(err, "failed to commit transaction")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		mu.deletePhotos(keys)
+		return
+	}
+
+	uuid = domain.StringValue(mr.UUID)
+	return
+}
+
+// deletePhotos method delete photos put to s3 with keys, used when storing milestone record is failed after put
+func (mu *milestoneUsecase) deletePhotos(keys []*string) {
+	for _, key := range keys {
+		_, _ = mu.s3Agency.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(mu.myCfg.MilestonePhotoS3Bucket()),
+			Key:    key,
+		})
+	}
+}
+
+// GetMilestoneRecords implement GetMilestoneRecords method of domain.MilestoneUsecase interface
+func (mu *milestoneUsecase) GetMilestoneRecords(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (records []domain.MilestoneRecord, err error) {
+	_tx, err := mu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = mu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if records, err = mu.milestoneRecordRepository.GetByChildrenUUID(_tx, childrenUUID); err != nil {
+		err = errors.Wrap(err, "milestone record GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = mu.txHandler.Commit(_tx)
+	return
+}
+
+// CompareMilestones implement CompareMilestones method of domain.MilestoneUsecase interface
+func (mu *milestoneUsecase) CompareMilestones(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (comparisons []domain.MilestoneComparison, err error) {
+	_tx, err := mu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := mu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	records, err := mu.milestoneRecordRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "milestone record GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = mu.txHandler.Rollback(_tx)
+		return
+	}
+
+	achieved := map[string]*domain.MilestoneRecord{}
+	for i := range records {
+		if code := domain.StringValue(records[i].MilestoneCode); code != "" {
+			achieved[code] = &records[i]
+		}
+	}
+
+	now := time.Now()
+	comparisons = make([]domain.MilestoneComparison, len(mu.catalog))
+	for i, md := range mu.catalog {
+		comparisons[i] = domain.NewMilestoneComparison(md, domain.TimeValue(c.Birth), now, achieved[md.Code])
+	}
+
+	_ = mu.txHandler.Commit(_tx)
+	return
+}
+
+// findDefinition method return milestone definition with code in catalog
+func (mu *milestoneUsecase) findDefinition(code string) (md domain.MilestoneDefinition, ok bool) {
+	for _, md = range mu.catalog {
+		if md.Code == code {
+			return md, true
+		}
+	}
+	return domain.MilestoneDefinition{}, false
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (mu *milestoneUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = mu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
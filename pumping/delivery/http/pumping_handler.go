@@ -0,0 +1,209 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// pumpingHandler represent the http handler for pumping
+type pumpingHandler struct {
+	pUsecase   domain.PumpingUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewPumpingHandler will initialize the pumping resources endpoint
+func NewPumpingHandler(r *gin.Engine, pu domain.PumpingUsecase, v validator, jh jwtHandler) {
+	h := &pumpingHandler{
+		pUsecase:   pu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/pumpings", h.jwtHandler.ParseUUIDFromToken, h.CreatePumping)
+	r.GET("children/uuid/:children_uuid/pumpings", h.jwtHandler.ParseUUIDFromToken, h.GetPumpingsByDate)
+	r.GET("children/uuid/:children_uuid/milk-bags", h.jwtHandler.ParseUUIDFromToken, h.GetStoredMilkBags)
+	r.GET("children/uuid/:children_uuid/milk-bags/expiring", h.jwtHandler.ParseUUIDFromToken, h.GetExpiringMilkBags)
+	r.POST("children/uuid/:children_uuid/milk-bags/uuid/:bag_uuid/consume", h.jwtHandler.ParseUUIDFromToken, h.ConsumeMilkBag)
+}
+
+// CreatePumping deliver data to CreatePumping of domain.PumpingUsecase
+func (ph *pumpingHandler) CreatePumping(c *gin.Context) {
+	req := new(createPumpingRequest)
+	if err := ph.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	p := &domain.Pumping{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		LeftVolume:   domain.Int64(req.LeftVolume),
+		RightVolume:  domain.Int64(req.RightVolume),
+		Bags:         make([]domain.MilkBag, len(req.Bags)),
+	}
+	if req.Duration != 0 {
+		p.Duration = domain.Int64(req.Duration)
+	}
+	for i, b := range req.Bags {
+		p.Bags[i] = domain.MilkBag{
+			Volume:  domain.Int64(b.Volume),
+			Storage: domain.String(b.Storage),
+		}
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.PumpedAt); err != nil {
+		err = errors.Wrap(err, "failed to parse pumped_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		p.PumpedAt = domain.Time(t)
+	}
+
+	switch uuid, err := ph.pUsecase.CreatePumping(c.Request.Context(), c.GetString("uuid"), p); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new pumping")
+		resp["pumping_uuid"] = uuid
+		resp["bags"] = p.Bags
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreatePumping return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetPumpingsByDate deliver data to GetPumpingsByDate of domain.PumpingUsecase
+func (ph *pumpingHandler) GetPumpingsByDate(c *gin.Context) {
+	req := new(getPumpingsByDateRequest)
+	if err := ph.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	pumpings, err := ph.pUsecase.GetPumpingsByDate(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get pumpings by date")
+		resp["pumpings"] = pumpings
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetPumpingsByDate return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetStoredMilkBags deliver data to GetStoredMilkBags of domain.PumpingUsecase
+func (ph *pumpingHandler) GetStoredMilkBags(c *gin.Context) {
+	req := new(getStoredMilkBagsRequest)
+	if err := ph.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	bags, err := ph.pUsecase.GetStoredMilkBags(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get stored milk bags")
+		resp["bags"] = bags
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetStoredMilkBags return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetExpiringMilkBags deliver data to GetExpiringMilkBags of domain.PumpingUsecase
+func (ph *pumpingHandler) GetExpiringMilkBags(c *gin.Context) {
+	req := new(getExpiringMilkBagsRequest)
+	if err := ph.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	within := time.Duration(req.WithinHours) * time.Hour
+	bags, err := ph.pUsecase.GetExpiringMilkBags(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, within)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get expiring milk bags")
+		resp["bags"] = bags
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetExpiringMilkBags return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// ConsumeMilkBag deliver data to ConsumeMilkBag of domain.PumpingUsecase
+func (ph *pumpingHandler) ConsumeMilkBag(c *gin.Context) {
+	req := new(consumeMilkBagRequest)
+	if err := ph.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	consumedAt, err := time.Parse(time.RFC3339, req.ConsumedAt)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse consumed_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := ph.pUsecase.ConsumeMilkBag(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.BagUUID, req.FeedingUUID, consumedAt); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to consume milk bag"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "ConsumeMilkBag return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (ph *pumpingHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := ph.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
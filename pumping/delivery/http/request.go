@@ -0,0 +1,80 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createPumpingRequest is request for pumpingHandler.CreatePumping
+type createPumpingRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	PumpedAt     string `json:"pumped_at" validate:"required,max=30"`
+	LeftVolume   int64  `json:"left_volume" validate:"range=0~1000"`
+	RightVolume  int64  `json:"right_volume" validate:"range=0~1000"`
+	Duration     int64  `json:"duration" validate:"range=0~180"`
+	Bags         []struct {
+		Volume  int64  `json:"volume" validate:"range=1~1000"`
+		Storage string `json:"storage" validate:"required,oneof=room fridge freezer"`
+	} `json:"bags" validate:"max=10,dive"`
+}
+
+func (r *createPumpingRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getPumpingsByDateRequest is request for pumpingHandler.GetPumpingsByDate
+type getPumpingsByDateRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+}
+
+func (r *getPumpingsByDateRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// getStoredMilkBagsRequest is request for pumpingHandler.GetStoredMilkBags
+type getStoredMilkBagsRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+}
+
+func (r *getStoredMilkBagsRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// getExpiringMilkBagsRequest is request for pumpingHandler.GetExpiringMilkBags
+type getExpiringMilkBagsRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	WithinHours  int    `form:"within_hours" validate:"range=1~720"`
+}
+
+// defaultExpiringWithinHours is hours used to find expiring milk bags if within_hours is not set
+const defaultExpiringWithinHours = 24
+
+func (r *getExpiringMilkBagsRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	r.WithinHours = defaultExpiringWithinHours
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// consumeMilkBagRequest is request for pumpingHandler.ConsumeMilkBag
+type consumeMilkBagRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	BagUUID      string `uri:"bag_uuid" validate:"required,uuid=milk_bag"`
+	FeedingUUID  string `json:"feeding_uuid" validate:"uuid=feeding"`
+	ConsumedAt   string `json:"consumed_at" validate:"required,max=30"`
+}
+
+func (r *consumeMilkBagRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
@@ -0,0 +1,186 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// milkBagRepository is implementation of domain.MilkBagRepository using mysql
+type milkBagRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// MilkBagRepository return implementation of domain.MilkBagRepository using mysql
+func MilkBagRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.MilkBagRepository {
+	repo := &milkBagRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.MilkBag{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate milk bag model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.MilkBagRepository interface
+func (mbr *milkBagRepository) Store(ctx tx.Context, mb *domain.MilkBag) (err error) {
+	if domain.StringValue(mb.UUID) == "" {
+		if mb.UUID, err = mbr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = mbr.validator.ValidateStruct(mb); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.MilkBag")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("milk_bag").
+		Columns("uuid", "pumping_uuid", "children_uuid", "volume", "storage", "stored_at", "expires_at", "consumed_at", "feeding_uuid").
+		Values(mb.UUID, mb.PumpingUUID, mb.ChildrenUUID, mb.Volume, mb.Storage, mb.StoredAt, mb.ExpiresAt, mb.ConsumedAt, mb.FeedingUUID).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert milk bag")
+			fk := mbr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert milk bag return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert milk bag return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.MilkBagRepository interface
+func (mbr *milkBagRepository) GetByUUID(ctx tx.Context, uuid string) (mb domain.MilkBag, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("milk_bag").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&mb, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select milk bag")}
+	default:
+		err = errors.Wrap(err, "select milk bag return unexpected error")
+	}
+	return
+}
+
+// GetByPumpingUUIDs is implement GetByPumpingUUIDs method of domain.MilkBagRepository interface
+func (mbr *milkBagRepository) GetByPumpingUUIDs(ctx tx.Context, pumpingUUIDs []string) (mbs []domain.MilkBag, err error) {
+	mbs = []domain.MilkBag{}
+	if len(pumpingUUIDs) == 0 {
+		return
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("milk_bag").
+		Where(squirrel.Eq{"pumping_uuid": pumpingUUIDs}).
+		OrderBy("stored_at", "uuid").ToSql()
+
+	if err = _tx.Select(&mbs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select milk bags return unexpected error")
+	}
+	return
+}
+
+// GetStoredByChildrenUUID is implement GetStoredByChildrenUUID method of domain.MilkBagRepository interface
+// milk bags not consumed yet are returned, filtered by expires_at if expiresBefore is not nil
+func (mbr *milkBagRepository) GetStoredByChildrenUUID(
+	ctx tx.Context,
+	childrenUUID string,
+	expiresBefore *time.Time,
+) (mbs []domain.MilkBag, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	b := squirrel.Select("*").From("milk_bag").
+		Where("children_uuid = ? AND consumed_at IS NULL", childrenUUID)
+	if expiresBefore != nil {
+		b = b.Where("expires_at < ?", expiresBefore)
+	}
+	_sql, args, _ := b.OrderBy("expires_at").ToSql()
+
+	mbs = []domain.MilkBag{}
+	if err = _tx.Select(&mbs, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select milk bags return unexpected error")
+	}
+	return
+}
+
+// UpdateConsumed is implement UpdateConsumed method of domain.MilkBagRepository interface
+// consumed_at & feeding_uuid of milk bag are updated to value in model
+func (mbr *milkBagRepository) UpdateConsumed(ctx tx.Context, mb *domain.MilkBag) (err error) {
+	if domain.StringValue(mb.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = mbr.validator.ValidateStruct(mb); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.MilkBag")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("milk_bag").
+		Set("consumed_at", mb.ConsumedAt).
+		Set("feeding_uuid", mb.FeedingUUID).
+		Where("uuid = ?", mb.UUID).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to update milk bag")
+			fk := mbr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "update milk bag return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "update milk bag return unexpected error type")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of milk bag table
+func (mbr *milkBagRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	mb := new(domain.MilkBag)
+
+	for {
+		uuid := mb.GenerateRandomUUID()
+		_, err := mbr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
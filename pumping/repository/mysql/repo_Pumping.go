@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// pumpingRepository is implementation of domain.PumpingRepository using mysql
+type pumpingRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// PumpingRepository return implementation of domain.PumpingRepository using mysql
+func PumpingRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.PumpingRepository {
+	repo := &pumpingRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Pumping{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate pumping model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.PumpingRepository interface
+func (pr *pumpingRepository) Store(ctx tx.Context, p *domain.Pumping) (err error) {
+	if domain.StringValue(p.UUID) == "" {
+		if p.UUID, err = pr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = pr.validator.ValidateStruct(p); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Pumping")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("pumping").
+		Columns("uuid", "children_uuid", "pumped_at", "left_volume", "right_volume", "duration").
+		Values(p.UUID, p.ChildrenUUID, p.PumpedAt, p.LeftVolume, p.RightVolume, p.Duration).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert pumping")
+			fk := pr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert pumping return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert pumping return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.PumpingRepository interface
+func (pr *pumpingRepository) GetByUUID(ctx tx.Context, uuid string) (p domain.Pumping, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("pumping").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&p, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select pumping")}
+	default:
+		err = errors.Wrap(err, "select pumping return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.PumpingRepository interface
+func (pr *pumpingRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (ps []domain.Pumping, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("pumping").
+		Where("children_uuid = ?", childrenUUID).
+		Where("pumped_at >= ? AND pumped_at < ?", from, to).
+		OrderBy("pumped_at").ToSql()
+
+	ps = []domain.Pumping{}
+	if err = _tx.Select(&ps, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select pumpings return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of pumping table
+func (pr *pumpingRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	p := new(domain.Pumping)
+
+	for {
+		uuid := p.GenerateRandomUUID()
+		_, err := pr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxExpiringWithin is max duration that expiring milk bags can be requested with
+const maxExpiringWithin = 30 * 24 * time.Hour
+
+// pumpingUsecase is used for usecase layer which implement domain.PumpingUsecase interface
+type pumpingUsecase struct {
+	// pumpingRepository is repository interface about domain.Pumping model
+	pumpingRepository domain.PumpingRepository
+
+	// milkBagRepository is repository interface about domain.MilkBag model
+	milkBagRepository domain.MilkBagRepository
+
+	// feedingRepository is repository interface about domain.Feeding model
+	feedingRepository domain.FeedingRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// PumpingUsecase return implementation of domain.PumpingUsecase
+func PumpingUsecase(
+	pr domain.PumpingRepository,
+	mbr domain.MilkBagRepository,
+	fr domain.FeedingRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.PumpingUsecase {
+	return &pumpingUsecase{
+		pumpingRepository:  pr,
+		milkBagRepository:  mbr,
+		feedingRepository:  fr,
+		childrenRepository: cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreatePumping implement CreatePumping method of domain.PumpingUsecase interface
+// milk bags in p.Bags need only Volume & Storage, other fields are set from pumping session
+func (pu *pumpingUsecase) CreatePumping(ctx context.Context, parentUUID string, p *domain.Pumping) (uuid string, err error) {
+	var bagVolume int64
+	for _, mb := range p.Bags {
+		bagVolume += domain.Int64Value(mb.Volume)
+	}
+	if bagVolume > p.TotalVolume() {
+		err = errors.New("total volume of milk bags can't exceed pumped volume")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := pu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = pu.getOwnChildren(_tx, parentUUID, domain.StringValue(p.ChildrenUUID)); err != nil {
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = pu.pumpingRepository.Store(_tx, p); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "pumping Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "pumping Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	for i := range p.Bags {
+		mb := &p.Bags[i]
+		mb.PumpingUUID = p.UUID
+		mb.ChildrenUUID = p.ChildrenUUID
+		mb.StoredAt = p.PumpedAt
+		mb.ExpiresAt = domain.Time(domain.MilkExpiry(domain.StringValue(mb.Storage), domain.TimeValue(p.PumpedAt)))
+
+		switch err = pu.milkBagRepository.Store(_tx, mb); err.(type) {
+		case nil:
+			break
+		case domain.ErrInvalidModel:
+			err = errors.Wrap(err, "milk bag Store return invalid model")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			_ = pu.txHandler.Rollback(_tx)
+			return
+		default:
+			err = errors.Wrap(err, "milk bag Store return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = pu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	uuid = domain.StringValue(p.UUID)
+	_ = pu.txHandler.Commit(_tx)
+	return
+}
+
+// GetPumpingsByDate implement GetPumpingsByDate method of domain.PumpingUsecase interface
+func (pu *pumpingUsecase) GetPumpingsByDate(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (pumpings []domain.Pumping, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := pu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = pu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if pumpings, err = pu.pumpingRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to); err != nil {
+		err = errors.Wrap(err, "pumping GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuids := make([]string, len(pumpings))
+	for i, p := range pumpings {
+		uuids[i] = domain.StringValue(p.UUID)
+	}
+	bags, err := pu.milkBagRepository.GetByPumpingUUIDs(_tx, uuids)
+	if err != nil {
+		err = errors.Wrap(err, "milk bag GetByPumpingUUIDs return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	byPumping := map[string][]domain.MilkBag{}
+	for _, mb := range bags {
+		byPumping[domain.StringValue(mb.PumpingUUID)] = append(byPumping[domain.StringValue(mb.PumpingUUID)], mb)
+	}
+	for i := range pumpings {
+		if pumpings[i].Bags = byPumping[domain.StringValue(pumpings[i].UUID)]; pumpings[i].Bags == nil {
+			pumpings[i].Bags = []domain.MilkBag{}
+		}
+	}
+
+	_ = pu.txHandler.Commit(_tx)
+	return
+}
+
+// GetStoredMilkBags implement GetStoredMilkBags method of domain.PumpingUsecase interface
+func (pu *pumpingUsecase) GetStoredMilkBags(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+) (bags []domain.MilkBag, err error) {
+	return pu.getStoredMilkBags(ctx, parentUUID, childrenUUID, nil)
+}
+
+// GetExpiringMilkBags implement GetExpiringMilkBags method of domain.PumpingUsecase interface
+func (pu *pumpingUsecase) GetExpiringMilkBags(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	within time.Duration,
+) (bags []domain.MilkBag, err error) {
+	if within <= 0 || within > maxExpiringWithin {
+		err = errors.Errorf("expiring within must be 1 ~ %d hours", int(maxExpiringWithin.Hours()))
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+	return pu.getStoredMilkBags(ctx, parentUUID, childrenUUID, domain.Time(time.Now().Add(within)))
+}
+
+// getStoredMilkBags method return milk bags not consumed, expiring before expiresBefore if not nil
+func (pu *pumpingUsecase) getStoredMilkBags(
+	ctx context.Context,
+	parentUUID, childrenUUID string,
+	expiresBefore *time.Time,
+) (bags []domain.MilkBag, err error) {
+	_tx, err := pu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = pu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if bags, err = pu.milkBagRepository.GetStoredByChildrenUUID(_tx, childrenUUID, expiresBefore); err != nil {
+		err = errors.Wrap(err, "milk bag GetStoredByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = pu.txHandler.Commit(_tx)
+	return
+}
+
+// ConsumeMilkBag implement ConsumeMilkBag method of domain.PumpingUsecase interface
+func (pu *pumpingUsecase) ConsumeMilkBag(
+	ctx context.Context,
+	parentUUID, childrenUUID, bagUUID, feedingUUID string,
+	consumedAt time.Time,
+) (err error) {
+	_tx, err := pu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = pu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	mb, err := pu.milkBagRepository.GetByUUID(_tx, bagUUID)
+	switch err.(type) {
+	case nil:
+		if domain.StringValue(mb.ChildrenUUID) != childrenUUID {
+			err = errors.New("that milk bag is not stored for that children")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = pu.txHandler.Rollback(_tx)
+			return
+		}
+	case domain.ErrRowNotExist:
+		err = errors.New("milk bag with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "milk bag GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if mb.ConsumedAt != nil {
+		err = errors.New("that milk bag is already consumed")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.MilkBagAlreadyConsumed}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+	if mb.IsExpired(consumedAt) {
+		err = errors.New("that milk bag is expired before consumed time")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.MilkBagExpired}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if feedingUUID != "" {
+		switch f, fErr := pu.feedingRepository.GetByUUID(_tx, feedingUUID); fErr.(type) {
+		case nil:
+			if domain.StringValue(f.ChildrenUUID) != childrenUUID || domain.StringValue(f.FeedingType) != domain.FeedingTypeBottle {
+				err = errors.New("milk bag can be linked only to bottle feeding of same children")
+				err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+				_ = pu.txHandler.Rollback(_tx)
+				return
+			}
+		case domain.ErrRowNotExist:
+			err = errors.New("feeding with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+			_ = pu.txHandler.Rollback(_tx)
+			return
+		default:
+			err = errors.Wrap(fErr, "feeding GetByUUID return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = pu.txHandler.Rollback(_tx)
+			return
+		}
+		mb.FeedingUUID = domain.String(feedingUUID)
+	}
+
+	mb.ConsumedAt = domain.Time(consumedAt)
+	switch err = pu.milkBagRepository.UpdateConsumed(_tx, &mb); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "milk bag UpdateConsumed return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "milk bag UpdateConsumed return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = pu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = pu.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (pu *pumpingUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = pu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about recurring expenditure domain
+var App *recurringExpenditureConfig
+
+// init function initialize App global variable
+func init() {
+	App = &recurringExpenditureConfig{}
+}
+
+// recurringExpenditureConfig have config value and implement various interface about recurring expenditure config
+type recurringExpenditureConfig struct {
+	// materializeInterval represent interval that scheduler materialize due recurring expenditures
+	materializeInterval *time.Duration
+}
+
+// default const value about recurringExpenditureConfig field
+const (
+	defaultMaterializeInterval = 10 * time.Minute
+)
+
+// MaterializeInterval return interval that scheduler materialize due recurring expenditures
+func (rec *recurringExpenditureConfig) MaterializeInterval() time.Duration {
+	var key = "recurringExpenditure.materializeInterval"
+	if rec.materializeInterval != nil {
+		return *rec.materializeInterval
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil || d <= 0 {
+		viper.Set(key, defaultMaterializeInterval.String())
+		d = defaultMaterializeInterval
+	}
+
+	rec.materializeInterval = &d
+	return *rec.materializeInterval
+}
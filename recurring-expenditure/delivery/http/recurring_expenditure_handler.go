@@ -0,0 +1,263 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// recurringExpenditureHandler represent the http handler for recurring expenditure
+type recurringExpenditureHandler struct {
+	reUsecase  domain.RecurringExpenditureUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewRecurringExpenditureHandler will initialize the recurring expenditure resources endpoint
+func NewRecurringExpenditureHandler(r *gin.Engine, reu domain.RecurringExpenditureUsecase, v validator, jh jwtHandler) {
+	h := &recurringExpenditureHandler{
+		reUsecase:  reu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("recurring-expenditures", h.jwtHandler.ParseUUIDFromToken, h.CreateRecurringExpenditure)
+	r.GET("recurring-expenditures", h.jwtHandler.ParseUUIDFromToken, h.GetRecurringExpenditures)
+	r.PATCH("recurring-expenditures/uuid/:recurring_uuid", h.jwtHandler.ParseUUIDFromToken, h.UpdateRecurringExpenditure)
+	r.DELETE("recurring-expenditures/uuid/:recurring_uuid", h.jwtHandler.ParseUUIDFromToken, h.DeleteRecurringExpenditure)
+	r.POST("recurring-expenditures/uuid/:recurring_uuid/pause", h.jwtHandler.ParseUUIDFromToken, h.PauseRecurringExpenditure)
+	r.POST("recurring-expenditures/uuid/:recurring_uuid/resume", h.jwtHandler.ParseUUIDFromToken, h.ResumeRecurringExpenditure)
+	r.POST("recurring-expenditures/uuid/:recurring_uuid/skip-next", h.jwtHandler.ParseUUIDFromToken, h.SkipNextRecurringExpenditure)
+}
+
+// CreateRecurringExpenditure deliver data to CreateRecurringExpenditure of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) CreateRecurringExpenditure(c *gin.Context) {
+	req := new(createRecurringExpenditureRequest)
+	if err := reh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	startDate := req.StartDate
+	if startDate == "" {
+		startDate = time.Now().In(domain.ServiceLocation).Format("2006-01-02")
+	}
+	startAt, _, err := domain.DayRange(startDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, "invalid start_date"))
+		return
+	}
+
+	re := &domain.RecurringExpenditure{
+		ParentUUID: domain.String(c.GetString("uuid")),
+		Name:       domain.String(req.Name),
+		Amount:     domain.Int64(req.Amount),
+		Rating:     domain.Int64(req.Rating),
+		Link:       domain.String(req.Link),
+		RRule:      domain.String(req.RRule),
+		StartAt:    domain.Time(startAt),
+	}
+	if req.CategoryUUID != "" {
+		re.CategoryUUID = domain.String(req.CategoryUUID)
+	}
+	if req.EndDate != "" {
+		if re.EndAt, err = endOfDay(req.EndDate); err != nil {
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, "invalid end_date"))
+			return
+		}
+	}
+
+	uuid, err := reh.reUsecase.CreateRecurringExpenditure(c.Request.Context(), re, req.BabyUUIDs)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create recurring expenditure")
+		resp["recurring_uuid"] = uuid
+		resp["next_at"] = re.NextAt
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateRecurringExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetRecurringExpenditures deliver data to GetRecurringExpenditures of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) GetRecurringExpenditures(c *gin.Context) {
+	res, err := reh.reUsecase.GetRecurringExpenditures(c.Request.Context(), c.GetString("uuid"))
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get recurring expenditures")
+		resp["recurring_expenditures"] = res
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetRecurringExpenditures return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// UpdateRecurringExpenditure deliver data to UpdateRecurringExpenditure of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) UpdateRecurringExpenditure(c *gin.Context) {
+	req := new(updateRecurringExpenditureRequest)
+	if err := reh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	re := &domain.RecurringExpenditure{
+		UUID:         domain.String(req.RecurringUUID),
+		Name:         req.Name,
+		Amount:       req.Amount,
+		Rating:       req.Rating,
+		Link:         req.Link,
+		CategoryUUID: req.CategoryUUID,
+		RRule:        req.RRule,
+	}
+	if req.EndDate != nil {
+		var err error
+		if re.EndAt, err = endOfDay(*req.EndDate); err != nil {
+			c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, "invalid end_date"))
+			return
+		}
+	}
+
+	switch err := reh.reUsecase.UpdateRecurringExpenditure(c.Request.Context(), c.GetString("uuid"), re); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to update recurring expenditure")
+		resp["recurring_expenditure"] = re
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "UpdateRecurringExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// DeleteRecurringExpenditure deliver data to DeleteRecurringExpenditure of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) DeleteRecurringExpenditure(c *gin.Context) {
+	req := new(recurringExpenditureURIRequest)
+	if err := reh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	switch err := reh.reUsecase.DeleteRecurringExpenditure(c.Request.Context(), c.GetString("uuid"), req.RecurringUUID); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, "succeed to delete recurring expenditure"))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "DeleteRecurringExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// PauseRecurringExpenditure deliver data to PauseRecurringExpenditure of domain.RecurringExpenditureUsecase to pause
+func (reh *recurringExpenditureHandler) PauseRecurringExpenditure(c *gin.Context) {
+	reh.setPaused(c, true)
+}
+
+// ResumeRecurringExpenditure deliver data to PauseRecurringExpenditure of domain.RecurringExpenditureUsecase to resume
+func (reh *recurringExpenditureHandler) ResumeRecurringExpenditure(c *gin.Context) {
+	reh.setPaused(c, false)
+}
+
+// setPaused method deliver data to PauseRecurringExpenditure of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) setPaused(c *gin.Context, paused bool) {
+	req := new(recurringExpenditureURIRequest)
+	if err := reh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	msg := "succeed to resume recurring expenditure"
+	if paused {
+		msg = "succeed to pause recurring expenditure"
+	}
+
+	switch err := reh.reUsecase.PauseRecurringExpenditure(c.Request.Context(), c.GetString("uuid"), req.RecurringUUID, paused); tErr := err.(type) {
+	case nil:
+		c.JSON(http.StatusOK, defaultResp(http.StatusOK, 0, msg))
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "PauseRecurringExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// SkipNextRecurringExpenditure deliver data to SkipNextRecurringExpenditure of domain.RecurringExpenditureUsecase
+func (reh *recurringExpenditureHandler) SkipNextRecurringExpenditure(c *gin.Context) {
+	req := new(recurringExpenditureURIRequest)
+	if err := reh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	nextAt, err := reh.reUsecase.SkipNextRecurringExpenditure(c.Request.Context(), c.GetString("uuid"), req.RecurringUUID)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to skip next recurring expenditure")
+		resp["next_at"] = nextAt
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "SkipNextRecurringExpenditure return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (reh *recurringExpenditureHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := reh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// endOfDay function return last second of the date string (ex. 2021-05-01), so that occurrence in the date is included
+func endOfDay(date string) (*time.Time, error) {
+	_, to, err := domain.DayRange(date)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Time(to.Add(-time.Second)), nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
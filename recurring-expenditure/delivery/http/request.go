@@ -0,0 +1,54 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createRecurringExpenditureRequest is request for recurringExpenditureHandler.CreateRecurringExpenditure
+// RRule is recurrence rule (ex. FREQ=MONTHLY;BYMONTHDAY=25), occurrences are at start of the day
+// StartDate & EndDate are inclusive dates (yyyy-mm-dd), StartDate is today if not set and there is no end if EndDate is not set
+type createRecurringExpenditureRequest struct {
+	Name         string   `json:"name" validate:"required,max=20"`
+	Amount       int64    `json:"amount" validate:"required,range=0~1000000000"`
+	Rating       int64    `json:"rating" validate:"range=0~5"`
+	Link         string   `json:"link" validate:"max=100"`
+	CategoryUUID string   `json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	BabyUUIDs    []string `json:"baby_uuids" validate:"omitempty,dive,uuid=children"`
+	RRule        string   `json:"rrule" validate:"required,max=100"`
+	StartDate    string   `json:"start_date" validate:"omitempty,len=10"`
+	EndDate      string   `json:"end_date" validate:"omitempty,len=10"`
+}
+
+func (r *createRecurringExpenditureRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// updateRecurringExpenditureRequest is request for recurringExpenditureHandler.UpdateRecurringExpenditure
+// field not in body is not updated
+type updateRecurringExpenditureRequest struct {
+	RecurringUUID string  `uri:"recurring_uuid" validate:"required,uuid=recurring_expenditure"`
+	Name          *string `json:"name" validate:"omitempty,min=1,max=20"`
+	Amount        *int64  `json:"amount" validate:"omitempty,range=0~1000000000"`
+	Rating        *int64  `json:"rating" validate:"omitempty,range=0~5"`
+	Link          *string `json:"link" validate:"omitempty,max=100"`
+	CategoryUUID  *string `json:"category_uuid" validate:"omitempty,uuid=expenditure_category"`
+	RRule         *string `json:"rrule" validate:"omitempty,max=100"`
+	EndDate       *string `json:"end_date" validate:"omitempty,len=10"`
+}
+
+func (r *updateRecurringExpenditureRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindJSON(r); err != nil {
+		return errors.Wrap(err, "failed to BindJSON")
+	}
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
+
+// recurringExpenditureURIRequest is request for recurringExpenditureHandler having only uuid of template in uri
+type recurringExpenditureURIRequest struct {
+	RecurringUUID string `uri:"recurring_uuid" validate:"required,uuid=recurring_expenditure"`
+}
+
+func (r *recurringExpenditureURIRequest) BindFrom(c *gin.Context) error {
+	return errors.Wrap(c.BindUri(r), "failed to BindUri")
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// recurringExpenditureScheduler represent the scheduler materializing due recurring expenditures periodically
+type recurringExpenditureScheduler struct {
+	reUsecase domain.RecurringExpenditureUsecase
+	myCfg     recurringExpenditureSchedulerConfig
+}
+
+// recurringExpenditureSchedulerConfig is interface get config value for recurring expenditure scheduler
+type recurringExpenditureSchedulerConfig interface {
+	// MaterializeInterval return interval that scheduler materialize due recurring expenditures
+	MaterializeInterval() time.Duration
+}
+
+// NewRecurringExpenditureScheduler will start to materialize due recurring expenditures in background
+// due expenditures are materialized once at start, and then at every MaterializeInterval
+func NewRecurringExpenditureScheduler(cfg recurringExpenditureSchedulerConfig, reu domain.RecurringExpenditureUsecase) {
+	s := &recurringExpenditureScheduler{
+		reUsecase: reu,
+		myCfg:     cfg,
+	}
+
+	go s.run()
+}
+
+// run method call MaterializeDueExpenditures of domain.RecurringExpenditureUsecase at every tick
+func (s *recurringExpenditureScheduler) run() {
+	ticker := time.NewTicker(s.myCfg.MaterializeInterval())
+	defer ticker.Stop()
+
+	for {
+		s.materialize()
+		<-ticker.C
+	}
+}
+
+// materialize method deliver current time to MaterializeDueExpenditures of domain.RecurringExpenditureUsecase
+func (s *recurringExpenditureScheduler) materialize() {
+	count, err := s.reUsecase.MaterializeDueExpenditures(context.Background(), time.Now())
+	if err != nil {
+		log.Println(errors.Wrap(err, "MaterializeDueExpenditures return unexpected error").Error())
+	}
+	if count != 0 {
+		log.Printf("%d recurring expenditures are materialized\n", count)
+	}
+}
@@ -0,0 +1,256 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// recurringExpenditureRepository is implementation of domain.RecurringExpenditureRepository using mysql
+type recurringExpenditureRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// RecurringExpenditureRepository return implementation of domain.RecurringExpenditureRepository using mysql
+func RecurringExpenditureRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.RecurringExpenditureRepository {
+	repo := &recurringExpenditureRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.RecurringExpenditure{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate recurring expenditure model").Error())
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.RecurringExpenditureBabyTag{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate recurring expenditure baby tag model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.RecurringExpenditureRepository interface
+// babies in babyUUIDs are tagged in recurring expenditure together
+func (rer *recurringExpenditureRepository) Store(ctx tx.Context, re *domain.RecurringExpenditure, babyUUIDs []string) (err error) {
+	if domain.StringValue(re.UUID) == "" {
+		if re.UUID, err = rer.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = rer.validator.ValidateStruct(re); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.RecurringExpenditure")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("recurring_expenditure").
+		Columns("uuid", "parent_uuid", "name", "amount", "rating", "link", "category_uuid",
+			"rrule", "start_at", "end_at", "next_at", "paused", "created_at", "updated_at").
+		Values(re.UUID, re.ParentUUID, re.Name, re.Amount, re.Rating, re.Link, re.CategoryUUID,
+			re.RRule, re.StartAt, re.EndAt, re.NextAt, re.Paused, re.CreatedAt, re.UpdatedAt).ToSql()
+
+	_, err = _tx.Exec(_sql, args...)
+	for _, babyUUID := range babyUUIDs {
+		if err != nil {
+			break
+		}
+		_sql, args, _ = squirrel.Insert("recurring_expenditure_baby_tag").
+			Columns("recurring_uuid", "baby_uuid").
+			Values(domain.StringValue(re.UUID), babyUUID).ToSql()
+		_, err = _tx.Exec(_sql, args...)
+	}
+
+	switch tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert recurring expenditure")
+			fk := rer.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert recurring expenditure")
+			_, key := rer.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		default:
+			err = errors.Wrap(err, "insert recurring expenditure return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert recurring expenditure return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.RecurringExpenditureRepository interface
+func (rer *recurringExpenditureRepository) GetByUUID(ctx tx.Context, uuid string) (re domain.RecurringExpenditure, err error) {
+	return rer.getByUUID(ctx, uuid, "")
+}
+
+// GetByUUIDForUpdate is implement GetByUUIDForUpdate method of domain.RecurringExpenditureRepository interface
+// selected row is locked until end of transaction, so that template is not materialized twice at same time
+func (rer *recurringExpenditureRepository) GetByUUIDForUpdate(ctx tx.Context, uuid string) (re domain.RecurringExpenditure, err error) {
+	return rer.getByUUID(ctx, uuid, "FOR UPDATE")
+}
+
+// getByUUID method select recurring expenditure with uuid, with suffix of select query
+func (rer *recurringExpenditureRepository) getByUUID(ctx tx.Context, uuid, suffix string) (re domain.RecurringExpenditure, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	query := squirrel.Select("*").From("recurring_expenditure").Where("uuid = ?", uuid)
+	if suffix != "" {
+		query = query.Suffix(suffix)
+	}
+	_sql, args, _ := query.ToSql()
+
+	switch err = _tx.Get(&re, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select recurring expenditure")}
+	default:
+		err = errors.Wrap(err, "select recurring expenditure return unexpected error")
+	}
+	return
+}
+
+// GetByParentUUID is implement GetByParentUUID method of domain.RecurringExpenditureRepository interface
+func (rer *recurringExpenditureRepository) GetByParentUUID(ctx tx.Context, parentUUID string) (res []domain.RecurringExpenditure, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("recurring_expenditure").
+		Where("parent_uuid = ?", parentUUID).
+		OrderBy("created_at", "uuid").ToSql()
+
+	res = []domain.RecurringExpenditure{}
+	if err = _tx.Select(&res, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select recurring expenditures return unexpected error")
+	}
+	return
+}
+
+// GetDueUUIDs is implement GetDueUUIDs method of domain.RecurringExpenditureRepository interface
+// uuid of templates not paused & having next occurrence until now are returned in order of next occurrence
+func (rer *recurringExpenditureRepository) GetDueUUIDs(ctx tx.Context, now time.Time, limit int) (uuids []string, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("uuid").From("recurring_expenditure").
+		Where("paused = ? AND next_at <= ?", false, now).
+		OrderBy("next_at", "uuid").Limit(uint64(limit)).ToSql()
+
+	uuids = []string{}
+	if err = _tx.Select(&uuids, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select due recurring expenditures return unexpected error")
+	}
+	return
+}
+
+// GetBabyTagsByRecurringUUIDs is implement GetBabyTagsByRecurringUUIDs method of domain.RecurringExpenditureRepository interface
+func (rer *recurringExpenditureRepository) GetBabyTagsByRecurringUUIDs(
+	ctx tx.Context,
+	recurringUUIDs []string,
+) (tags []domain.RecurringExpenditureBabyTag, err error) {
+	tags = []domain.RecurringExpenditureBabyTag{}
+	if len(recurringUUIDs) == 0 {
+		return
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("recurring_expenditure_baby_tag").
+		Where(squirrel.Eq{"recurring_uuid": recurringUUIDs}).
+		OrderBy("recurring_uuid", "baby_uuid").ToSql()
+
+	if err = _tx.Select(&tags, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select recurring expenditure baby tags return unexpected error")
+	}
+	return
+}
+
+// Update is implement Update method of domain.RecurringExpenditureRepository interface
+// every field except for parent, start & created time can be updated
+func (rer *recurringExpenditureRepository) Update(ctx tx.Context, re *domain.RecurringExpenditure) (err error) {
+	if domain.StringValue(re.UUID) == "" {
+		err = errors.New("UUID(PK) value in model must be set")
+		return
+	}
+
+	if err = rer.validator.ValidateStruct(re); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.RecurringExpenditure")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Update("recurring_expenditure").
+		Set("name", re.Name).
+		Set("amount", re.Amount).
+		Set("rating", re.Rating).
+		Set("link", re.Link).
+		Set("category_uuid", re.CategoryUUID).
+		Set("rrule", re.RRule).
+		Set("end_at", re.EndAt).
+		Set("next_at", re.NextAt).
+		Set("paused", re.Paused).
+		Set("updated_at", re.UpdatedAt).
+		Where("uuid = ?", re.UUID).ToSql()
+
+	if _, err = _tx.Exec(_sql, args...); err != nil {
+		err = errors.Wrap(err, "update recurring expenditure return unexpected error")
+	}
+	return
+}
+
+// Delete is implement Delete method of domain.RecurringExpenditureRepository interface
+func (rer *recurringExpenditureRepository) Delete(ctx tx.Context, uuid string) (err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Delete("recurring_expenditure").Where("uuid = ?", uuid).ToSql()
+
+	result, err := _tx.Exec(_sql, args...)
+	if err != nil {
+		err = errors.Wrap(err, "delete recurring expenditure return unexpected error")
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = domain.ErrRowNotExist{RepoErr: errors.New("recurring expenditure with that uuid is not exist")}
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of recurring expenditure table
+func (rer *recurringExpenditureRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	re := new(domain.RecurringExpenditure)
+
+	for {
+		uuid := re.GenerateRandomUUID()
+		_, err := rer.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,591 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// recurringExpenditureUsecase is used for usecase layer which implement domain.RecurringExpenditureUsecase interface
+type recurringExpenditureUsecase struct {
+	// defaultCategories is default expenditure categories loaded from catalog
+	defaultCategories []domain.ExpenditureCategory
+
+	// recurringExpenditureRepository is repository interface about domain.RecurringExpenditure model
+	recurringExpenditureRepository domain.RecurringExpenditureRepository
+
+	// expenditureRepository is repository interface about domain.Expenditure model
+	expenditureRepository domain.ExpenditureRepository
+
+	// expenditureCategoryRepository is repository interface about domain.ExpenditureCategory model
+	expenditureCategoryRepository domain.ExpenditureCategoryRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// elasticSearch is used for indexing materialized expenditures
+	elasticSearch elasticSearch
+}
+
+// RecurringExpenditureUsecase return implementation of domain.RecurringExpenditureUsecase
+func RecurringExpenditureUsecase(
+	dc []domain.ExpenditureCategory,
+	rer domain.RecurringExpenditureRepository,
+	er domain.ExpenditureRepository,
+	ecr domain.ExpenditureCategoryRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+	es elasticSearch,
+) domain.RecurringExpenditureUsecase {
+	return &recurringExpenditureUsecase{
+		defaultCategories:              dc,
+		recurringExpenditureRepository: rer,
+		expenditureRepository:          er,
+		expenditureCategoryRepository:  ecr,
+		childrenRepository:             cr,
+
+		txHandler:     th,
+		elasticSearch: es,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// elasticSearch is interface used for indexing expenditure document
+type elasticSearch interface {
+	// Create method index document s with id in index
+	Create(ctx context.Context, index, id, s string) (err error)
+
+	// Delete method delete document with id in index
+	Delete(ctx context.Context, index, id string) (err error)
+}
+
+// dueBatchSize is count of due templates materialized in one call of MaterializeDueExpenditures
+const dueBatchSize = 100
+
+// CreateRecurringExpenditure implement CreateRecurringExpenditure method of domain.RecurringExpenditureUsecase interface
+// occurrences from StartAt until now are materialized at next run of scheduler if StartAt is in the past
+func (reu *recurringExpenditureUsecase) CreateRecurringExpenditure(
+	ctx context.Context,
+	re *domain.RecurringExpenditure,
+	babyUUIDs []string,
+) (uuid string, err error) {
+	if err = checkSchedule(re); err != nil {
+		return
+	}
+
+	now := time.Now()
+	re.Paused = domain.Bool(false)
+	re.CreatedAt = domain.Time(now)
+	re.UpdatedAt = domain.Time(now)
+	if re.NextAt = re.Occurrence(domain.TimeValue(re.StartAt), true); re.NextAt == nil {
+		err = errors.New("there is no occurrence of rrule until end date")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if err = reu.checkCategory(_tx, domain.StringValue(re.ParentUUID), re.CategoryUUID); err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+	for _, babyUUID := range babyUUIDs {
+		if err = reu.checkOwnChildren(_tx, domain.StringValue(re.ParentUUID), babyUUID); err != nil {
+			_ = reu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	switch err = reu.recurringExpenditureRepository.Store(_tx, re, babyUUIDs); tErr := err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "recurring expenditure Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrNoReferencedRow:
+		switch tErr.ForeignKey {
+		case "parent_uuid":
+			err = errors.New("parent with that uuid is not exist")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		default:
+			err = errors.Wrap(err, "recurring expenditure Store return unexpected no referenced error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrEntryDuplicate:
+		err = errors.New("same baby is tagged more than once")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "recurring expenditure Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(re.UUID)
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// GetRecurringExpenditures implement GetRecurringExpenditures method of domain.RecurringExpenditureUsecase interface
+func (reu *recurringExpenditureUsecase) GetRecurringExpenditures(
+	ctx context.Context,
+	parentUUID string,
+) (res []domain.RecurringExpenditure, err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if res, err = reu.recurringExpenditureRepository.GetByParentUUID(_tx, parentUUID); err != nil {
+		err = errors.Wrap(err, "recurring expenditure GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuids := make([]string, 0, len(res))
+	for _, re := range res {
+		uuids = append(uuids, domain.StringValue(re.UUID))
+	}
+	tags, err := reu.recurringExpenditureRepository.GetBabyTagsByRecurringUUIDs(_tx, uuids)
+	if err != nil {
+		err = errors.Wrap(err, "recurring expenditure GetBabyTagsByRecurringUUIDs return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	babyUUIDs := map[string][]string{}
+	for _, tag := range tags {
+		uuid := domain.StringValue(tag.RecurringUUID)
+		babyUUIDs[uuid] = append(babyUUIDs[uuid], domain.StringValue(tag.BabyUUID))
+	}
+	for i := range res {
+		if res[i].BabyUUIDs = babyUUIDs[domain.StringValue(res[i].UUID)]; res[i].BabyUUIDs == nil {
+			res[i].BabyUUIDs = []string{}
+		}
+	}
+
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// UpdateRecurringExpenditure implement UpdateRecurringExpenditure method of domain.RecurringExpenditureUsecase interface
+// next occurrence is recalculated from now (or from next occurrence not materialized yet) if RRule or EndAt is changed
+func (reu *recurringExpenditureUsecase) UpdateRecurringExpenditure(
+	ctx context.Context,
+	parentUUID string,
+	re *domain.RecurringExpenditure,
+) (err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	cur, err := reu.getOwnRecurringExpenditure(_tx, parentUUID, domain.StringValue(re.UUID))
+	if err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if re.Name != nil {
+		cur.Name = re.Name
+	}
+	if re.Amount != nil {
+		cur.Amount = re.Amount
+	}
+	if re.Rating != nil {
+		cur.Rating = re.Rating
+	}
+	if re.Link != nil {
+		cur.Link = re.Link
+	}
+	if re.CategoryUUID != nil {
+		if err = reu.checkCategory(_tx, parentUUID, re.CategoryUUID); err != nil {
+			_ = reu.txHandler.Rollback(_tx)
+			return
+		}
+		cur.CategoryUUID = re.CategoryUUID
+	}
+
+	now := time.Now()
+	if re.RRule != nil || re.EndAt != nil {
+		if re.RRule != nil {
+			cur.RRule = re.RRule
+		}
+		if re.EndAt != nil {
+			cur.EndAt = re.EndAt
+		}
+		if err = checkSchedule(&cur); err != nil {
+			_ = reu.txHandler.Rollback(_tx)
+			return
+		}
+
+		from := now
+		if cur.NextAt != nil && cur.NextAt.Before(now) {
+			from = *cur.NextAt
+		}
+		cur.NextAt = cur.Occurrence(from, true)
+	}
+
+	cur.UpdatedAt = domain.Time(now)
+	if err = reu.updateRecurringExpenditure(_tx, &cur); err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	*re = cur
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// PauseRecurringExpenditure implement PauseRecurringExpenditure method of domain.RecurringExpenditureUsecase interface
+// resumed template continue from first occurrence after now, unless next occurrence was skipped to the future
+func (reu *recurringExpenditureUsecase) PauseRecurringExpenditure(
+	ctx context.Context,
+	parentUUID, uuid string,
+	paused bool,
+) (err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	cur, err := reu.getOwnRecurringExpenditure(_tx, parentUUID, uuid)
+	if err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if domain.BoolValue(cur.Paused) == paused {
+		_ = reu.txHandler.Commit(_tx)
+		return
+	}
+
+	now := time.Now()
+	if !paused {
+		from := now
+		if cur.NextAt != nil && cur.NextAt.After(now) {
+			from = *cur.NextAt
+		}
+		cur.NextAt = cur.Occurrence(from, true)
+	}
+	cur.Paused = domain.Bool(paused)
+	cur.UpdatedAt = domain.Time(now)
+
+	if err = reu.updateRecurringExpenditure(_tx, &cur); err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// SkipNextRecurringExpenditure implement SkipNextRecurringExpenditure method of domain.RecurringExpenditureUsecase interface
+func (reu *recurringExpenditureUsecase) SkipNextRecurringExpenditure(
+	ctx context.Context,
+	parentUUID, uuid string,
+) (nextAt *time.Time, err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	cur, err := reu.getOwnRecurringExpenditure(_tx, parentUUID, uuid)
+	if err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if cur.NextAt == nil {
+		err = errors.New("there is no next occurrence to skip")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	cur.NextAt = cur.Occurrence(*cur.NextAt, false)
+	cur.UpdatedAt = domain.Time(time.Now())
+	if err = reu.updateRecurringExpenditure(_tx, &cur); err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	nextAt = cur.NextAt
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// DeleteRecurringExpenditure implement DeleteRecurringExpenditure method of domain.RecurringExpenditureUsecase interface
+func (reu *recurringExpenditureUsecase) DeleteRecurringExpenditure(ctx context.Context, parentUUID, uuid string) (err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = reu.getOwnRecurringExpenditure(_tx, parentUUID, uuid); err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if err = reu.recurringExpenditureRepository.Delete(_tx, uuid); err != nil {
+		err = errors.Wrap(err, "recurring expenditure Delete return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// MaterializeDueExpenditures implement MaterializeDueExpenditures method of domain.RecurringExpenditureUsecase interface
+// each template is materialized in its own transaction, so that failure of one template doesn't block others
+func (reu *recurringExpenditureUsecase) MaterializeDueExpenditures(ctx context.Context, now time.Time) (count int, err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	uuids, err := reu.recurringExpenditureRepository.GetDueUUIDs(_tx, now, dueBatchSize)
+	if err != nil {
+		err = errors.Wrap(err, "recurring expenditure GetDueUUIDs return unexpected error")
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+	_ = reu.txHandler.Commit(_tx)
+
+	for _, uuid := range uuids {
+		n, mErr := reu.materialize(ctx, uuid, now)
+		if mErr != nil {
+			log.Println(errors.Wrapf(mErr, "failed to materialize recurring expenditure %s", uuid).Error())
+			continue
+		}
+		count += n
+	}
+	return
+}
+
+// materialize method store expenditure for every occurrence of template with uuid until now & return count of them
+// template is locked while materializing, so that it is not materialized twice by several server at same time
+func (reu *recurringExpenditureUsecase) materialize(ctx context.Context, uuid string, now time.Time) (count int, err error) {
+	_tx, err := reu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	re, err := reu.recurringExpenditureRepository.GetByUUIDForUpdate(_tx, uuid)
+	if err != nil {
+		err = errors.Wrap(err, "recurring expenditure GetByUUIDForUpdate return unexpected error")
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+	if domain.BoolValue(re.Paused) || re.NextAt == nil || re.NextAt.After(now) {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	tags, err := reu.recurringExpenditureRepository.GetBabyTagsByRecurringUUIDs(_tx, []string{uuid})
+	if err != nil {
+		err = errors.Wrap(err, "recurring expenditure GetBabyTagsByRecurringUUIDs return unexpected error")
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+	babyUUIDs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		babyUUIDs = append(babyUUIDs, domain.StringValue(tag.BabyUUID))
+	}
+
+	categories, err := reu.getCategories(_tx, domain.StringValue(re.ParentUUID))
+	if err != nil {
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+	// category deleted after template was created is not copied to expenditure
+	_, categorized := categories.Find(domain.StringValue(re.CategoryUUID))
+	path := categories.Path(domain.StringValue(re.CategoryUUID))
+
+	indexed := []string{}
+	for ; re.NextAt != nil && !re.NextAt.After(now) && count < domain.MaxMaterializedOccurrences; count++ {
+		e := re.Expenditure(*re.NextAt, now)
+		if !categorized {
+			e.CategoryUUID = nil
+		}
+		if err = reu.expenditureRepository.Store(_tx, &e, babyUUIDs); err != nil {
+			err = errors.Wrap(err, "expenditure Store return unexpected error")
+			break
+		}
+
+		body, _ := domain.ExpenditureSearchDocument(&e, babyUUIDs, path)
+		if err = reu.elasticSearch.Create(ctx, domain.ExpenditureSearchIndex, domain.StringValue(e.UUID), body); err != nil {
+			err = errors.Wrap(err, "failed to index materialized expenditure")
+			break
+		}
+		indexed = append(indexed, domain.StringValue(e.UUID))
+		re.NextAt = re.Occurrence(*re.NextAt, false)
+	}
+
+	if err == nil && re.NextAt != nil && !re.NextAt.After(now) {
+		// occurrences over MaxMaterializedOccurrences are skipped
+		re.NextAt = re.Occurrence(now, false)
+	}
+	if err == nil {
+		re.UpdatedAt = domain.Time(now)
+		if err = reu.recurringExpenditureRepository.Update(_tx, &re); err != nil {
+			err = errors.Wrap(err, "recurring expenditure Update return unexpected error")
+		}
+	}
+
+	if err != nil {
+		// documents of expenditures rollbacked are removed, so that search index is not out of sync
+		for _, id := range indexed {
+			_ = reu.elasticSearch.Delete(ctx, domain.ExpenditureSearchIndex, id)
+		}
+		count = 0
+		_ = reu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = reu.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnRecurringExpenditure method return template with uuid locked for update, with usecase error if parent doesn't own it
+func (reu *recurringExpenditureUsecase) getOwnRecurringExpenditure(
+	_tx tx.Context,
+	parentUUID, uuid string,
+) (re domain.RecurringExpenditure, err error) {
+	switch re, err = reu.recurringExpenditureRepository.GetByUUIDForUpdate(_tx, uuid); err.(type) {
+	case nil:
+		if domain.StringValue(re.ParentUUID) != parentUUID {
+			err = errors.New("you can't access to that recurring expenditure")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+		}
+	case domain.ErrRowNotExist:
+		err = errors.New("recurring expenditure with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+	default:
+		err = errors.Wrap(err, "recurring expenditure GetByUUIDForUpdate return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// updateRecurringExpenditure method update template with usecase error
+func (reu *recurringExpenditureUsecase) updateRecurringExpenditure(_tx tx.Context, re *domain.RecurringExpenditure) (err error) {
+	switch err = reu.recurringExpenditureRepository.Update(_tx, re); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "recurring expenditure Update return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+	default:
+		err = errors.Wrap(err, "recurring expenditure Update return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+	}
+	return
+}
+
+// checkCategory method return usecase error if category with categoryUUID is not usable by parent
+func (reu *recurringExpenditureUsecase) checkCategory(_tx tx.Context, parentUUID string, categoryUUID *string) (err error) {
+	if categoryUUID == nil {
+		return
+	}
+
+	categories, err := reu.getCategories(_tx, parentUUID)
+	if err != nil {
+		return
+	}
+	if _, ok := categories.Find(*categoryUUID); !ok {
+		err = errors.New("category with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+	}
+	return
+}
+
+// checkOwnChildren method return usecase error if children with uuid is not exist or not owned by parent
+func (reu *recurringExpenditureUsecase) checkOwnChildren(_tx tx.Context, parentUUID, uuid string) (err error) {
+	c, err := reu.childrenRepository.GetByUUID(_tx, uuid)
+	switch err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
+
+// getCategories method return default categories with custom categories of parent
+func (reu *recurringExpenditureUsecase) getCategories(_tx tx.Context, parentUUID string) (all domain.ExpenditureCategories, err error) {
+	customs, err := reu.expenditureCategoryRepository.GetByParentUUID(_tx, parentUUID)
+	if err != nil {
+		err = errors.Wrap(err, "expenditure category GetByParentUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	all = append(all, reu.defaultCategories...)
+	all = append(all, customs...)
+	return
+}
+
+// checkSchedule function return usecase error if RRule of template is invalid or EndAt is before StartAt
+func checkSchedule(re *domain.RecurringExpenditure) (err error) {
+	if _, err = domain.ParseRRule(domain.StringValue(re.RRule)); err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "invalid rrule"), Status: http.StatusBadRequest}
+		return
+	}
+	if re.EndAt != nil && re.EndAt.Before(domain.TimeValue(re.StartAt)) {
+		err = errors.New("end date can't be before start date")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+	}
+	return
+}
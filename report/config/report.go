@@ -0,0 +1,60 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about report domain
+var App *reportConfig
+
+// init function initialize App global variable
+func init() {
+	App = &reportConfig{}
+}
+
+// reportConfig have config value and implement various interface about report config
+type reportConfig struct {
+	// reportS3Bucket represent aws s3 bucket for generated report
+	reportS3Bucket *string
+
+	// downloadLinkDuration represent time valid duration for report download link
+	downloadLinkDuration *time.Duration
+}
+
+// default const value about reportConfig field
+const (
+	defaultReportS3Bucket       = "first-baby-time"
+	defaultDownloadLinkDuration = time.Hour
+)
+
+// ReportS3Bucket implement ReportS3Bucket of reportUsecaseConfig
+func (rc *reportConfig) ReportS3Bucket() string {
+	var key = "report.reportS3Bucket"
+	if rc.reportS3Bucket == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultReportS3Bucket)
+		}
+		rc.reportS3Bucket = _string(viper.GetString(key))
+	}
+	return *rc.reportS3Bucket
+}
+
+// DownloadLinkDuration implement DownloadLinkDuration of reportUsecaseConfig
+func (rc *reportConfig) DownloadLinkDuration() time.Duration {
+	var key = "report.downloadLinkDuration"
+	if rc.downloadLinkDuration != nil {
+		return *rc.downloadLinkDuration
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultDownloadLinkDuration.String())
+		d = defaultDownloadLinkDuration
+	}
+
+	rc.downloadLinkDuration = &d
+	return *rc.downloadLinkDuration
+}
+
+func _string(s string) *string { return &s }
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// reportHandler represent the http handler for report
+type reportHandler struct {
+	rUsecase   domain.ReportUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewReportHandler will initialize the report resources endpoint
+func NewReportHandler(r *gin.Engine, ru domain.ReportUsecase, v validator, jh jwtHandler) {
+	h := &reportHandler{
+		rUsecase:   ru,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/reports/health", h.jwtHandler.ParseUUIDFromToken, h.GenerateHealthReport)
+}
+
+// GenerateHealthReport deliver data to GenerateHealthReport of domain.ReportUsecase
+func (rh *reportHandler) GenerateHealthReport(c *gin.Context) {
+	req := new(generateHealthReportRequest)
+	if err := rh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	link, expiresAt, err := rh.rUsecase.GenerateHealthReport(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.StartDate, req.EndDate)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to generate health report")
+		resp["download_link"] = link
+		resp["expires_at"] = expiresAt
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GenerateHealthReport return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (rh *reportHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := rh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// generateHealthReportRequest is request for reportHandler.GenerateHealthReport
+type generateHealthReportRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	StartDate    string `json:"start_date" validate:"required,len=10"`
+	EndDate      string `json:"end_date" validate:"required,len=10"`
+}
+
+func (r *generateHealthReportRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
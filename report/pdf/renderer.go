@@ -0,0 +1,285 @@
+package pdf
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// family name that embedded fonts are registered with in PDF
+// NanumBarunGothic has only hangul glyphs, so digit, latin & symbol are written with Fira Sans
+const (
+	hangulFamily = "nanum-barun-gothic"
+	latinFamily  = "fira-sans"
+)
+
+// hangulFont is NanumBarunGothic font (SIL Open Font License) having glyphs of hangul syllables & jamo
+//
+//go:embed fonts/NanumBarunGothic.ttf
+var hangulFont []byte
+
+// latinFont is Fira Sans Regular font (SIL Open Font License) having glyphs of latin, digit & symbol
+//
+//go:embed fonts/FiraSans-Regular.ttf
+var latinFont []byte
+
+// renderer is struct that render domain.HealthReport to PDF with embedded fonts
+// subset of fonts is embedded into every PDF, so no font file or network is needed
+type renderer struct{}
+
+// New return renderer using embedded fonts
+func New() *renderer {
+	return &renderer{}
+}
+
+// RenderHealthReport method write health report rendered as PDF to w
+func (r *renderer) RenderHealthReport(w io.Writer, hr domain.HealthReport) (err error) {
+	doc := gofpdf.New("P", "mm", "A4", "")
+	doc.AddUTF8FontFromBytes(hangulFamily, "", hangulFont)
+	doc.AddUTF8FontFromBytes(latinFamily, "", latinFont)
+	doc.SetFont(latinFamily, "", 10)
+	doc.SetTitle(fmt.Sprintf("%s 건강 리포트", domain.StringValue(hr.Children.Name)), true)
+	doc.AliasNbPages("")
+	doc.SetFooterFunc(func() {
+		doc.SetY(-15)
+		doc.SetFontSize(8)
+		cell(doc, 0, 10, fmt.Sprintf("%s 생성 · %d/{nb}", formatDateTime(hr.GeneratedAt), doc.PageNo()), "", 0, "C")
+	})
+	doc.AddPage()
+
+	doc.SetFontSize(18)
+	cell(doc, 0, 12, fmt.Sprintf("%s 건강 리포트", domain.StringValue(hr.Children.Name)), "", 1, "L")
+	doc.SetFontSize(10)
+	cell(doc, 0, 6, fmt.Sprintf("기간 %s ~ %s", formatDate(hr.From), formatDate(hr.To.Add(-1))), "", 1, "L")
+
+	section(doc, "프로필")
+	row(doc, "이름", domain.StringValue(hr.Children.Name))
+	if hr.Children.IsBorn() {
+		row(doc, "생년월일", formatDate(domain.TimeValue(hr.Children.Birth)))
+		row(doc, "성별", sexNames[domain.StringValue(hr.Children.Sex)])
+	} else {
+		row(doc, "출산 예정일", formatDate(domain.TimeValue(hr.Children.ExpectedBirth)))
+	}
+
+	section(doc, "성장")
+	if len(hr.Growth) == 0 {
+		text(doc, "기간 내 측정 기록 없음")
+	} else {
+		growthTable(doc, hr.Growth)
+		doc.SetFontSize(8)
+		text(doc, fmt.Sprintf("괄호 안은 백분위 (P), %s 기준", hr.GrowthSource))
+		doc.SetFontSize(10)
+	}
+
+	section(doc, "예방접종")
+	if len(hr.Vaccinations) == 0 {
+		text(doc, "기간 내 접종 기록 없음")
+	}
+	for _, v := range hr.Vaccinations {
+		line := fmt.Sprintf("%s  %s %d차", formatDate(v.AdministeredAt), v.VaccineName, v.DoseNumber)
+		if v.Hospital != "" {
+			line += fmt.Sprintf(" (%s)", v.Hospital)
+		}
+		text(doc, line)
+	}
+
+	section(doc, "복용 약")
+	if len(hr.Medications) == 0 {
+		text(doc, "기간 내 복용 약 없음")
+	}
+	for _, m := range hr.Medications {
+		period := formatDate(domain.TimeValue(m.StartDate)) + " ~ "
+		if m.EndDate != nil {
+			period += formatDate(*m.EndDate)
+		}
+		text(doc, fmt.Sprintf("%s  %s %s, %s", period, domain.StringValue(m.Name), domain.StringValue(m.Dose), domain.StringValue(m.Frequency)))
+	}
+
+	section(doc, "수유 (하루 평균)")
+	row(doc, "수유 횟수", fmt.Sprintf("%.1f회", hr.Feeding.CountPerDay))
+	row(doc, "모유 수유 시간", fmt.Sprintf("%.0f분", hr.Feeding.BreastMinutesPerDay))
+	row(doc, "분유/유축 수유량", fmt.Sprintf("%.0fml", hr.Feeding.BottleVolumePerDay))
+	row(doc, "이유식 횟수", fmt.Sprintf("%.1f회", hr.Feeding.SolidCountPerDay))
+
+	section(doc, "수면 (하루 평균)")
+	row(doc, "총 수면 시간", fmt.Sprintf("%.1f시간", hr.Sleep.HoursPerDay))
+	row(doc, "밤잠 시간", fmt.Sprintf("%.1f시간", hr.Sleep.NightHoursPerDay))
+	row(doc, "낮잠 횟수", fmt.Sprintf("%.1f회", hr.Sleep.NapCountPerDay))
+
+	if err = doc.Output(w); err != nil {
+		err = errors.Wrap(err, "failed to output PDF")
+	}
+	return
+}
+
+// sexNames is korean name of children sex
+var sexNames = map[string]string{
+	"male":   "남아",
+	"female": "여아",
+}
+
+// section function write section title to doc
+func section(doc *gofpdf.Fpdf, title string) {
+	doc.Ln(4)
+	doc.SetFontSize(13)
+	cell(doc, 0, 8, title, "B", 1, "L")
+	doc.SetFontSize(10)
+	doc.Ln(1)
+}
+
+// row function write label & value in one line to doc
+func row(doc *gofpdf.Fpdf, label, value string) {
+	cell(doc, 45, 6, label, "", 0, "L")
+	cell(doc, 0, 6, value, "", 1, "L")
+}
+
+// growthColumnWidths is width of date, age, height, weight & head circumference column in growth table
+var growthColumnWidths = []float64{30, 22, 46, 46, 46}
+
+// growthTable function write growth points as table to doc, with percentile next to each measured value
+func growthTable(doc *gofpdf.Fpdf, points []domain.GrowthPoint) {
+	cells := func(values []string, border string) {
+		for i, v := range values {
+			ln := 0
+			if i == len(values)-1 {
+				ln = 1
+			}
+			cell(doc, growthColumnWidths[i], 6, v, border, ln, "L")
+		}
+	}
+
+	cells([]string{"측정일", "월령", "키 (cm)", "몸무게 (kg)", "머리둘레 (cm)"}, "B")
+	for _, gp := range points {
+		cells([]string{
+			formatDate(domain.TimeValue(gp.MeasuredAt)),
+			fmt.Sprintf("%.1f개월", gp.AgeMonths),
+			formatMeasurement(gp.Height, gp.HeightPercentile, "%.1f"),
+			formatMeasurement(gp.Weight, gp.WeightPercentile, "%.2f"),
+			formatMeasurement(gp.HeadCircumference, gp.HeadCircumferencePercentile, "%.1f"),
+		}, "")
+	}
+}
+
+// formatMeasurement function return measured value in format with percentile, or "-" if value is not measured
+func formatMeasurement(value, percentile *float64, format string) string {
+	if value == nil {
+		return "-"
+	}
+	s := fmt.Sprintf(format, *value)
+	if percentile != nil {
+		s += fmt.Sprintf(" (P%.1f)", *percentile)
+	}
+	return s
+}
+
+// text function write text wrapped in page width to doc
+func text(doc *gofpdf.Fpdf, s string) {
+	pageW, _ := doc.GetPageSize()
+	left, _, right, _ := doc.GetMargins()
+	for _, line := range wrap(doc, s, pageW-left-right-2*doc.GetCellMargin()) {
+		cell(doc, 0, 6, line, "", 1, "L")
+	}
+}
+
+// run is part of text written with one font family
+type run struct {
+	family string
+	text   string
+}
+
+// splitRuns function split s into runs, hangul is written with hangulFamily & others with latinFamily
+func splitRuns(s string) (runs []run) {
+	for _, r := range s {
+		family := latinFamily
+		if isHangul(r) {
+			family = hangulFamily
+		}
+		if n := len(runs); n > 0 && runs[n-1].family == family {
+			runs[n-1].text += string(r)
+			continue
+		}
+		runs = append(runs, run{family: family, text: string(r)})
+	}
+	return
+}
+
+// isHangul function return true if r is hangul syllable or compatibility jamo, which hangulFont has glyph of
+func isHangul(r rune) bool {
+	return (r >= 0xAC00 && r <= 0xD7A3) || (r >= 0x3131 && r <= 0x318E)
+}
+
+// stringWidth function return width of s written in current font size of doc
+func stringWidth(doc *gofpdf.Fpdf, s string) (w float64) {
+	size, _ := doc.GetFontSize()
+	for _, r := range splitRuns(s) {
+		doc.SetFont(r.family, "", size)
+		w += doc.GetStringWidth(r.text)
+	}
+	return
+}
+
+// cell function write s in cell like CellFormat of gofpdf, switching font family for each run of s
+// align is "L" or "C", and w of 0 means that cell extends up to right margin
+func cell(doc *gofpdf.Fpdf, w, h float64, s, border string, ln int, align string) {
+	x := doc.GetX()
+	if w == 0 {
+		pageW, _ := doc.GetPageSize()
+		_, _, right, _ := doc.GetMargins()
+		w = pageW - right - x
+	}
+
+	// empty cell draws border & breaks page if needed, then text is written at position cell is placed
+	doc.CellFormat(w, h, "", border, ln, "", false, 0, "")
+	y := doc.GetY()
+	if ln != 0 {
+		y -= h
+	}
+
+	tx := x + doc.GetCellMargin()
+	if align == "C" {
+		tx = x + (w-stringWidth(doc, s))/2
+	}
+	size, unitSize := doc.GetFontSize()
+	for _, r := range splitRuns(s) {
+		doc.SetFont(r.family, "", size)
+		doc.Text(tx, y+h/2+0.3*unitSize, r.text)
+		tx += doc.GetStringWidth(r.text)
+	}
+}
+
+// wrap function split s into lines not wider than w, breaking at space if possible
+func wrap(doc *gofpdf.Fpdf, s string, w float64) (lines []string) {
+	rs := []rune(s)
+	for len(rs) > 0 {
+		end, space := len(rs), -1
+		for i := range rs {
+			if rs[i] == ' ' {
+				space = i
+			}
+			if i > 0 && stringWidth(doc, string(rs[:i+1])) > w {
+				if end = i; space > 0 {
+					end = space
+				}
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(rs[:end]), " "))
+		rs = []rune(strings.TrimLeft(string(rs[end:]), " "))
+	}
+	return
+}
+
+func formatDate(t time.Time) string {
+	return t.In(domain.ServiceLocation).Format("2006-01-02")
+}
+
+func formatDateTime(t time.Time) string {
+	return t.In(domain.ServiceLocation).Format("2006-01-02 15:04")
+}
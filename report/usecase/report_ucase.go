@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxReportDays is max count of days that report can be generated for at once
+const maxReportDays = 366
+
+// reportUsecase is used for usecase layer which implement domain.ReportUsecase interface
+type reportUsecase struct {
+	// myCfg is used for get config value for report usecase
+	myCfg reportUsecaseConfig
+
+	// schedule is national immunization table used for vaccine name
+	schedule domain.VaccinationSchedule
+
+	// standard is growth standard used for percentile of growth measurements
+	standard domain.GrowthStandard
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// growthMeasurementRepository is repository interface about domain.GrowthMeasurement model
+	growthMeasurementRepository domain.GrowthMeasurementRepository
+
+	// vaccinationRecordRepository is repository interface about domain.VaccinationRecord model
+	vaccinationRecordRepository domain.VaccinationRecordRepository
+
+	// medicationRepository is repository interface about domain.Medication model
+	medicationRepository domain.MedicationRepository
+
+	// feedingRepository is repository interface about domain.Feeding model
+	feedingRepository domain.FeedingRepository
+
+	// sleepRepository is repository interface about domain.Sleep model
+	sleepRepository domain.SleepRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// s3Agency is used as agency about aws s3 API
+	s3Agency s3Agency
+
+	// pdfRenderer is used for rendering report as PDF
+	pdfRenderer pdfRenderer
+}
+
+// ReportUsecase return implementation of domain.ReportUsecase
+func ReportUsecase(
+	cfg reportUsecaseConfig,
+	vs domain.VaccinationSchedule,
+	gs domain.GrowthStandard,
+	cr domain.ChildrenRepository,
+	gmr domain.GrowthMeasurementRepository,
+	vrr domain.VaccinationRecordRepository,
+	mr domain.MedicationRepository,
+	fr domain.FeedingRepository,
+	sr domain.SleepRepository,
+	th txHandler,
+	sa s3Agency,
+	pr pdfRenderer,
+) domain.ReportUsecase {
+	return &reportUsecase{
+		myCfg:                       cfg,
+		schedule:                    vs,
+		standard:                    gs,
+		childrenRepository:          cr,
+		growthMeasurementRepository: gmr,
+		vaccinationRecordRepository: vrr,
+		medicationRepository:        mr,
+		feedingRepository:           fr,
+		sleepRepository:             sr,
+
+		txHandler:   th,
+		s3Agency:    sa,
+		pdfRenderer: pr,
+	}
+}
+
+// reportUsecaseConfig is interface get config value for report usecase
+type reportUsecaseConfig interface {
+	// ReportS3Bucket method returns s3 bucket name to store report
+	ReportS3Bucket() string
+
+	// DownloadLinkDuration method returns valid duration of report download link
+	DownloadLinkDuration() time.Duration
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// s3Agency is agency that agent various API about aws s3
+type s3Agency interface {
+	// PutObject method put(insert or update) object to s3
+	PutObject(input *s3.PutObjectInput) (output *s3.PutObjectOutput, err error)
+
+	// PresignGetObject method return url that anyone can download object with until expire
+	PresignGetObject(input *s3.GetObjectInput, expire time.Duration) (url string, err error)
+}
+
+// pdfRenderer is renderer that render report as PDF
+type pdfRenderer interface {
+	// RenderHealthReport method write health report rendered as PDF to w
+	RenderHealthReport(w io.Writer, hr domain.HealthReport) (err error)
+}
+
+// GenerateHealthReport implement GenerateHealthReport method of domain.ReportUsecase interface
+func (ru *reportUsecase) GenerateHealthReport(
+	ctx context.Context,
+	parentUUID, childrenUUID, startDate, endDate string,
+) (link string, expiresAt time.Time, err error) {
+	from, _, err := domain.DayRange(startDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse start date"), Status: http.StatusBadRequest}
+		return
+	}
+	_, to, err := domain.DayRange(endDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse end date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	now := time.Now()
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 || days > maxReportDays {
+		err = errors.Errorf("report range must be 1 ~ %d days", maxReportDays)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+	if from.After(now) {
+		err = errors.New("report range must start before today")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := ru.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := ru.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = ru.txHandler.Rollback(_tx)
+		return
+	}
+
+	hr, err := ru.buildHealthReport(_tx, c, from, to, now)
+	if err != nil {
+		_ = ru.txHandler.Rollback(_tx)
+		return
+	}
+	_ = ru.txHandler.Commit(_tx)
+
+	buf := new(bytes.Buffer)
+	if err = ru.pdfRenderer.RenderHealthReport(buf, hr); err != nil {
+		err = errors.Wrap(err, "failed to render health report")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	key := domain.GenerateReportUri(childrenUUID, now)
+	if _, err = ru.s3Agency.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(ru.myCfg.ReportS3Bucket()),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(buf.Bytes()),
+		ContentType: aws.String("application/pdf"),
+	}); err != nil {
+		err = errors.Wrap(err, "s3 PutObject return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	expiresAt = now.Add(ru.myCfg.DownloadLinkDuration())
+	if link, err = ru.s3Agency.PresignGetObject(&s3.GetObjectInput{
+		Bucket: aws.String(ru.myCfg.ReportS3Bucket()),
+		Key:    aws.String(key),
+	}, ru.myCfg.DownloadLinkDuration()); err != nil {
+		err = errors.Wrap(err, "s3 PresignGetObject return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	return
+}
+
+// buildHealthReport method return health report of children with records in from ~ to
+// averages are divided by days until now if range is not ended yet
+func (ru *reportUsecase) buildHealthReport(_tx tx.Context, c domain.Children, from, to, now time.Time) (hr domain.HealthReport, err error) {
+	childrenUUID := domain.StringValue(c.UUID)
+	hr = domain.HealthReport{
+		Children:     c,
+		From:         from,
+		To:           to,
+		Growth:       []domain.GrowthPoint{},
+		GrowthSource: ru.standard.Source,
+		Vaccinations: []domain.HealthReportVaccination{},
+		Medications:  []domain.Medication{},
+		GeneratedAt:  now,
+	}
+
+	if c.IsBorn() {
+		measurements, gErr := ru.growthMeasurementRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+		if gErr != nil {
+			err = errors.Wrap(gErr, "growth measurement GetByChildrenUUIDInRange return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			return
+		}
+		hr.Growth = domain.NewGrowthPoints(ru.standard, domain.TimeValue(c.Birth), domain.StringValue(c.Sex), measurements)
+	}
+
+	records, err := ru.vaccinationRecordRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "vaccination record GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	for _, vr := range records {
+		at := domain.TimeValue(vr.AdministeredAt)
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+		name := domain.StringValue(vr.VaccineCode)
+		if v, ok := ru.schedule.GetVaccine(name); ok {
+			name = v.Name
+		}
+		hr.Vaccinations = append(hr.Vaccinations, domain.HealthReportVaccination{
+			VaccineName:    name,
+			DoseNumber:     domain.Int64Value(vr.DoseNumber),
+			AdministeredAt: at,
+			Hospital:       domain.StringValue(vr.Hospital),
+		})
+	}
+	sort.SliceStable(hr.Vaccinations, func(i, j int) bool {
+		return hr.Vaccinations[i].AdministeredAt.Before(hr.Vaccinations[j].AdministeredAt)
+	})
+
+	medications, err := ru.medicationRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "medication GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	for _, m := range medications {
+		if !domain.TimeValue(m.StartDate).Before(to) || (m.EndDate != nil && m.EndDate.Before(from)) {
+			continue
+		}
+		hr.Medications = append(hr.Medications, m)
+	}
+
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+	days := int(math.Ceil(end.Sub(from).Hours() / 24))
+
+	feedings, err := ru.feedingRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, end)
+	if err != nil {
+		err = errors.Wrap(err, "feeding GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	hr.Feeding = domain.NewFeedingAverage(feedings, days)
+
+	sleeps, err := ru.sleepRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, end)
+	if err != nil {
+		err = errors.Wrap(err, "sleep GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+	hr.Sleep = domain.NewSleepAverage(sleeps, from, end, days)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (ru *reportUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = ru.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -3,6 +3,8 @@ package s3
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"time"
 )
 
 // s3Agent is struct that agent API about aws s3 including put object, delete object, etc ...
@@ -20,3 +22,19 @@ func New(ses *session.Session) *s3Agent {
 func (sa *s3Agent) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
 	return s3.New(sa.session).PutObject(input)
 }
+
+// Upload method upload object to s3 streaming body in parts without reading whole body into memory
+func (sa *s3Agent) Upload(input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+	return s3manager.NewUploader(sa.session).Upload(input)
+}
+
+// DeleteObject method delete object from s3
+func (sa *s3Agent) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	return s3.New(sa.session).DeleteObject(input)
+}
+
+// PresignGetObject method return url that anyone can download object with until expire
+func (sa *s3Agent) PresignGetObject(input *s3.GetObjectInput, expire time.Duration) (string, error) {
+	req, _ := s3.New(sa.session).GetObjectRequest(input)
+	return req.Presign(expire)
+}
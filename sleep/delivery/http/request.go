@@ -0,0 +1,48 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createSleepRequest is request for sleepHandler.CreateSleep
+type createSleepRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	SleepType    string `json:"sleep_type" validate:"required,oneof=nap night"`
+	StartedAt    string `json:"started_at" validate:"required,max=30"`
+	EndedAt      string `json:"ended_at" validate:"required,max=30"`
+	Location     string `json:"location" validate:"max=20"`
+}
+
+func (r *createSleepRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getSleepsByDateRequest is request for sleepHandler.GetSleepsByDate & sleepHandler.GetDailySleepSummary
+type getSleepsByDateRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+}
+
+func (r *getSleepsByDateRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// getWeeklySleepSummaryRequest is request for sleepHandler.GetWeeklySleepSummary
+type getWeeklySleepSummaryRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	StartDate    string `form:"start_date" validate:"required,len=10"`
+}
+
+func (r *getWeeklySleepSummaryRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
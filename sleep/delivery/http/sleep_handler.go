@@ -0,0 +1,179 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// sleepHandler represent the http handler for sleep
+type sleepHandler struct {
+	sUsecase   domain.SleepUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewSleepHandler will initialize the sleep resources endpoint
+func NewSleepHandler(r *gin.Engine, su domain.SleepUsecase, v validator, jh jwtHandler) {
+	h := &sleepHandler{
+		sUsecase:   su,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/sleeps", h.jwtHandler.ParseUUIDFromToken, h.CreateSleep)
+	r.GET("children/uuid/:children_uuid/sleeps", h.jwtHandler.ParseUUIDFromToken, h.GetSleepsByDate)
+	r.GET("children/uuid/:children_uuid/sleeps/daily-summary", h.jwtHandler.ParseUUIDFromToken, h.GetDailySleepSummary)
+	r.GET("children/uuid/:children_uuid/sleeps/weekly-summary", h.jwtHandler.ParseUUIDFromToken, h.GetWeeklySleepSummary)
+}
+
+// CreateSleep deliver data to CreateSleep of domain.SleepUsecase
+func (sh *sleepHandler) CreateSleep(c *gin.Context) {
+	req := new(createSleepRequest)
+	if err := sh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	s := &domain.Sleep{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		SleepType:    domain.String(req.SleepType),
+	}
+	if req.Location != "" {
+		s.Location = domain.String(req.Location)
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.StartedAt); err != nil {
+		err = errors.Wrap(err, "failed to parse started_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		s.StartedAt = domain.Time(t)
+	}
+
+	if t, err := time.Parse(time.RFC3339, req.EndedAt); err != nil {
+		err = errors.Wrap(err, "failed to parse ended_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		s.EndedAt = domain.Time(t)
+	}
+
+	switch uuid, err := sh.sUsecase.CreateSleep(c.Request.Context(), c.GetString("uuid"), s); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new sleep")
+		resp["sleep_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateSleep return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetSleepsByDate deliver data to GetSleepsByDate of domain.SleepUsecase
+func (sh *sleepHandler) GetSleepsByDate(c *gin.Context) {
+	req := new(getSleepsByDateRequest)
+	if err := sh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	sleeps, err := sh.sUsecase.GetSleepsByDate(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get sleeps by date")
+		resp["sleeps"] = sleeps
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetSleepsByDate return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetDailySleepSummary deliver data to GetDailySleepSummary of domain.SleepUsecase
+func (sh *sleepHandler) GetDailySleepSummary(c *gin.Context) {
+	req := new(getSleepsByDateRequest)
+	if err := sh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	summary, err := sh.sUsecase.GetDailySleepSummary(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get daily sleep summary")
+		resp["summary"] = summary
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetDailySleepSummary return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetWeeklySleepSummary deliver data to GetWeeklySleepSummary of domain.SleepUsecase
+func (sh *sleepHandler) GetWeeklySleepSummary(c *gin.Context) {
+	req := new(getWeeklySleepSummaryRequest)
+	if err := sh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	summary, err := sh.sUsecase.GetWeeklySleepSummary(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.StartDate)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get weekly sleep summary")
+		resp["summary"] = summary
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetWeeklySleepSummary return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (sh *sleepHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := sh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
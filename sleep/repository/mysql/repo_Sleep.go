@@ -0,0 +1,141 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// sleepRepository is implementation of domain.SleepRepository using mysql
+type sleepRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// SleepRepository return implementation of domain.SleepRepository using mysql
+func SleepRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.SleepRepository {
+	repo := &sleepRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Sleep{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate sleep model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.SleepRepository interface
+func (sr *sleepRepository) Store(ctx tx.Context, s *domain.Sleep) (err error) {
+	if domain.StringValue(s.UUID) == "" {
+		if s.UUID, err = sr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = sr.validator.ValidateStruct(s); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Sleep")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("sleep").
+		Columns("uuid", "children_uuid", "sleep_type", "started_at", "ended_at", "location").
+		Values(s.UUID, s.ChildrenUUID, s.SleepType, s.StartedAt, s.EndedAt, s.Location).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert sleep")
+			fk := sr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert sleep return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert sleep return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.SleepRepository interface
+func (sr *sleepRepository) GetByUUID(ctx tx.Context, uuid string) (s domain.Sleep, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("sleep").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&s, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select sleep")}
+	default:
+		err = errors.Wrap(err, "select sleep return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.SleepRepository interface
+// sleep sessions overlapped with from ~ to are returned
+func (sr *sleepRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (sleeps []domain.Sleep, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("sleep").
+		Where("children_uuid = ?", childrenUUID).
+		Where("started_at < ? AND ended_at > ?", to, from).
+		OrderBy("started_at").ToSql()
+
+	sleeps = []domain.Sleep{}
+	if err = _tx.Select(&sleeps, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select sleeps return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of sleep table
+func (sr *sleepRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	s := new(domain.Sleep)
+
+	for {
+		uuid := s.GenerateRandomUUID()
+		_, err := sr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
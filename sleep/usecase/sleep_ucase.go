@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// sleepUsecase is used for usecase layer which implement domain.SleepUsecase interface
+type sleepUsecase struct {
+	// sleepRepository is repository interface about domain.Sleep model
+	sleepRepository domain.SleepRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// SleepUsecase return implementation of domain.SleepUsecase
+func SleepUsecase(
+	sr domain.SleepRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.SleepUsecase {
+	return &sleepUsecase{
+		sleepRepository:    sr,
+		childrenRepository: cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// CreateSleep implement CreateSleep method of domain.SleepUsecase interface
+func (su *sleepUsecase) CreateSleep(ctx context.Context, parentUUID string, s *domain.Sleep) (uuid string, err error) {
+	if !domain.TimeValue(s.EndedAt).After(domain.TimeValue(s.StartedAt)) {
+		err = errors.New("ended_at must be after started_at")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := su.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = su.getOwnChildren(_tx, parentUUID, domain.StringValue(s.ChildrenUUID)); err != nil {
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	// children is locked until commit, so that sessions stored at same time are checked for overlap one by one
+	if _, err = su.childrenRepository.GetByUUIDForUpdate(_tx, domain.StringValue(s.ChildrenUUID)); err != nil {
+		err = errors.Wrap(err, "children GetByUUIDForUpdate return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	overlapped, err := su.sleepRepository.GetByChildrenUUIDInRange(_tx, domain.StringValue(s.ChildrenUUID), domain.TimeValue(s.StartedAt), domain.TimeValue(s.EndedAt))
+	if err != nil {
+		err = errors.Wrap(err, "sleep GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+	if len(overlapped) != 0 {
+		err = errors.New("sleep session is overlapped with other session of that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.SleepSessionOverlapped}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	switch err = su.sleepRepository.Store(_tx, s); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "sleep Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "sleep Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(s.UUID)
+	_ = su.txHandler.Commit(_tx)
+	return
+}
+
+// GetSleepsByDate implement GetSleepsByDate method of domain.SleepUsecase interface
+func (su *sleepUsecase) GetSleepsByDate(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (sleeps []domain.Sleep, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := su.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = su.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	if sleeps, err = su.sleepRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to); err != nil {
+		err = errors.Wrap(err, "sleep GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = su.txHandler.Commit(_tx)
+	return
+}
+
+// GetDailySleepSummary implement GetDailySleepSummary method of domain.SleepUsecase interface
+func (su *sleepUsecase) GetDailySleepSummary(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (summary domain.SleepDailySummary, err error) {
+	days, err := su.getDailySummaries(ctx, parentUUID, childrenUUID, date, 1)
+	if err != nil {
+		return
+	}
+	return days[0], nil
+}
+
+// GetWeeklySleepSummary implement GetWeeklySleepSummary method of domain.SleepUsecase interface
+func (su *sleepUsecase) GetWeeklySleepSummary(
+	ctx context.Context,
+	parentUUID, childrenUUID, startDate string,
+) (summary domain.SleepWeeklySummary, err error) {
+	days, err := su.getDailySummaries(ctx, parentUUID, childrenUUID, startDate, 7)
+	if err != nil {
+		return
+	}
+	return domain.NewSleepWeeklySummary(startDate, days), nil
+}
+
+// getDailySummaries method return daily summaries of n days from start date
+func (su *sleepUsecase) getDailySummaries(
+	ctx context.Context,
+	parentUUID, childrenUUID, startDate string,
+	n int,
+) (days []domain.SleepDailySummary, err error) {
+	from, _, err := domain.DayRange(startDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+	to := from.AddDate(0, 0, n)
+
+	_tx, err := su.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = su.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	sleeps, err := su.sleepRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "sleep GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = su.txHandler.Rollback(_tx)
+		return
+	}
+
+	days = make([]domain.SleepDailySummary, n)
+	for i := range days {
+		dayFrom := from.AddDate(0, 0, i)
+		days[i] = domain.NewSleepDailySummary(dayFrom.Format("2006-01-02"), dayFrom, dayFrom.AddDate(0, 0, 1), sleeps)
+	}
+
+	_ = su.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (su *sleepUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = su.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// createTemperatureRequest is request for temperatureHandler.CreateTemperature
+type createTemperatureRequest struct {
+	ChildrenUUID    string  `uri:"children_uuid" validate:"required,uuid=children"`
+	Value           float64 `json:"value" validate:"required,min=30,max=45"`
+	Method          string  `json:"method" validate:"required,oneof=rectal oral axillary ear forehead"`
+	MeasuredAt      string  `json:"measured_at" validate:"required,max=30"`
+	AntipyreticName string  `json:"antipyretic_name" validate:"max=30"`
+	AntipyreticDose string  `json:"antipyretic_dose" validate:"max=20"`
+}
+
+func (r *createTemperatureRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
+
+// getTemperaturesByDateRequest is request for temperatureHandler.GetTemperaturesByDate
+type getTemperaturesByDateRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+}
+
+func (r *getTemperaturesByDateRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// getFeverEpisodesRequest is request for temperatureHandler.GetFeverEpisodes
+type getFeverEpisodesRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	StartDate    string `form:"start_date" validate:"required,len=10"`
+	EndDate      string `form:"end_date" validate:"required,len=10"`
+}
+
+func (r *getFeverEpisodesRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
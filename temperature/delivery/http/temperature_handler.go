@@ -0,0 +1,152 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// temperatureHandler represent the http handler for temperature
+type temperatureHandler struct {
+	tUsecase   domain.TemperatureUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewTemperatureHandler will initialize the temperature resources endpoint
+func NewTemperatureHandler(r *gin.Engine, tu domain.TemperatureUsecase, v validator, jh jwtHandler) {
+	h := &temperatureHandler{
+		tUsecase:   tu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.POST("children/uuid/:children_uuid/temperatures", h.jwtHandler.ParseUUIDFromToken, h.CreateTemperature)
+	r.GET("children/uuid/:children_uuid/temperatures", h.jwtHandler.ParseUUIDFromToken, h.GetTemperaturesByDate)
+	r.GET("children/uuid/:children_uuid/temperatures/fever-episodes", h.jwtHandler.ParseUUIDFromToken, h.GetFeverEpisodes)
+}
+
+// CreateTemperature deliver data to CreateTemperature of domain.TemperatureUsecase
+func (th *temperatureHandler) CreateTemperature(c *gin.Context) {
+	req := new(createTemperatureRequest)
+	if err := th.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	t := &domain.Temperature{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		Value:        domain.Float64(req.Value),
+		Method:       domain.String(req.Method),
+	}
+	if req.AntipyreticName != "" {
+		t.AntipyreticName = domain.String(req.AntipyreticName)
+	}
+	if req.AntipyreticDose != "" {
+		t.AntipyreticDose = domain.String(req.AntipyreticDose)
+	}
+
+	if mt, err := time.Parse(time.RFC3339, req.MeasuredAt); err != nil {
+		err = errors.Wrap(err, "failed to parse measured_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		t.MeasuredAt = domain.Time(mt)
+	}
+
+	switch uuid, fever, err := th.tUsecase.CreateTemperature(c.Request.Context(), c.GetString("uuid"), t); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to create new temperature")
+		resp["temperature_uuid"] = uuid
+		resp["fever"] = fever
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "CreateTemperature return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetTemperaturesByDate deliver data to GetTemperaturesByDate of domain.TemperatureUsecase
+func (th *temperatureHandler) GetTemperaturesByDate(c *gin.Context) {
+	req := new(getTemperaturesByDateRequest)
+	if err := th.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	temperatures, err := th.tUsecase.GetTemperaturesByDate(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get temperatures by date")
+		resp["temperatures"] = temperatures
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetTemperaturesByDate return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// GetFeverEpisodes deliver data to GetFeverEpisodes of domain.TemperatureUsecase
+func (th *temperatureHandler) GetFeverEpisodes(c *gin.Context) {
+	req := new(getFeverEpisodesRequest)
+	if err := th.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	episodes, err := th.tUsecase.GetFeverEpisodes(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.StartDate, req.EndDate)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get fever episodes")
+		resp["episodes"] = episodes
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetFeverEpisodes return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (th *temperatureHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := th.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
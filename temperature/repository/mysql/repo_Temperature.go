@@ -0,0 +1,162 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// temperatureRepository is implementation of domain.TemperatureRepository using mysql
+type temperatureRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// TemperatureRepository return implementation of domain.TemperatureRepository using mysql
+func TemperatureRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.TemperatureRepository {
+	repo := &temperatureRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.Temperature{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate temperature model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.TemperatureRepository interface
+func (tr *temperatureRepository) Store(ctx tx.Context, t *domain.Temperature) (err error) {
+	if domain.StringValue(t.UUID) == "" {
+		if t.UUID, err = tr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = tr.validator.ValidateStruct(t); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.Temperature")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("temperature").
+		Columns("uuid", "children_uuid", "value", "method", "measured_at", "antipyretic_name", "antipyretic_dose").
+		Values(t.UUID, t.ChildrenUUID, t.Value, t.Method, t.MeasuredAt, t.AntipyreticName, t.AntipyreticDose).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert temperature")
+			fk := tr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert temperature return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert temperature return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.TemperatureRepository interface
+func (tr *temperatureRepository) GetByUUID(ctx tx.Context, uuid string) (t domain.Temperature, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("temperature").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&t, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select temperature")}
+	default:
+		err = errors.Wrap(err, "select temperature return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUIDInRange is implement GetByChildrenUUIDInRange method of domain.TemperatureRepository interface
+func (tr *temperatureRepository) GetByChildrenUUIDInRange(
+	ctx tx.Context,
+	childrenUUID string,
+	from, to time.Time,
+) (ts []domain.Temperature, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("temperature").
+		Where("children_uuid = ?", childrenUUID).
+		Where("measured_at >= ? AND measured_at < ?", from, to).
+		OrderBy("measured_at").ToSql()
+
+	ts = []domain.Temperature{}
+	if err = _tx.Select(&ts, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select temperatures return unexpected error")
+	}
+	return
+}
+
+// GetLastByChildrenUUIDBefore is implement GetLastByChildrenUUIDBefore method of domain.TemperatureRepository interface
+func (tr *temperatureRepository) GetLastByChildrenUUIDBefore(
+	ctx tx.Context,
+	childrenUUID string,
+	before time.Time,
+) (t domain.Temperature, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("temperature").
+		Where("children_uuid = ? AND measured_at < ?", childrenUUID, before).
+		OrderBy("measured_at DESC").Limit(1).ToSql()
+
+	switch err = _tx.Get(&t, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select last temperature")}
+	default:
+		err = errors.Wrap(err, "select last temperature return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of temperature table
+func (tr *temperatureRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	t := new(domain.Temperature)
+
+	for {
+		uuid := t.GenerateRandomUUID()
+		_, err := tr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
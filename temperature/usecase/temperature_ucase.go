@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// maxEpisodeDays is max count of days that fever episodes can be requested at once
+const maxEpisodeDays = 90
+
+// temperatureUsecase is used for usecase layer which implement domain.TemperatureUsecase interface
+type temperatureUsecase struct {
+	// temperatureRepository is repository interface about domain.Temperature model
+	temperatureRepository domain.TemperatureRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// parentAuthRepository is repository interface about domain.ParentAuth model
+	parentAuthRepository domain.ParentAuthRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+
+	// messageAgency is used as agency about message API
+	messageAgency messageAgency
+}
+
+// TemperatureUsecase return implementation of domain.TemperatureUsecase
+func TemperatureUsecase(
+	tr domain.TemperatureRepository,
+	cr domain.ChildrenRepository,
+	par domain.ParentAuthRepository,
+	th txHandler,
+	ma messageAgency,
+) domain.TemperatureUsecase {
+	return &temperatureUsecase{
+		temperatureRepository: tr,
+		childrenRepository:    cr,
+		parentAuthRepository:  par,
+
+		txHandler:     th,
+		messageAgency: ma,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// messageAgency is agency that agent various API about message
+type messageAgency interface {
+	// SendSMSToOne method send SMS message to one receiver
+	SendSMSToOne(receiver, content string) (err error)
+}
+
+// CreateTemperature implement CreateTemperature method of domain.TemperatureUsecase interface
+func (tu *temperatureUsecase) CreateTemperature(
+	ctx context.Context,
+	parentUUID string,
+	t *domain.Temperature,
+) (uuid string, fever bool, err error) {
+	_tx, err := tu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := tu.getOwnChildren(_tx, parentUUID, domain.StringValue(t.ChildrenUUID))
+	if err != nil {
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	birth := domain.TimeValue(c.Birth)
+
+	// alert only when reading cross the threshold, not for every reading during fever
+	alert := false
+	if fever = t.IsFever(birth); fever {
+		switch last, lErr := tu.temperatureRepository.GetLastByChildrenUUIDBefore(_tx, domain.StringValue(t.ChildrenUUID), domain.TimeValue(t.MeasuredAt)); lErr.(type) {
+		case nil:
+			alert = !last.IsFever(birth)
+		case domain.ErrRowNotExist:
+			alert = true
+		default:
+			err = errors.Wrap(lErr, "temperature GetLastByChildrenUUIDBefore return unexpected error")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = tu.txHandler.Rollback(_tx)
+			return
+		}
+	}
+
+	switch err = tu.temperatureRepository.Store(_tx, t); err.(type) {
+	case nil:
+		break
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "temperature Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "temperature Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	var phoneNumber string
+	if alert {
+		// receiver is parent owning children, failure to find receiver don't fail storing reading
+		if p, pErr := tu.parentAuthRepository.GetByUUID(_tx, domain.StringValue(c.ParentUUID)); pErr != nil {
+			log.Println(errors.Wrap(pErr, "parent auth GetByUUID return unexpected error").Error())
+		} else {
+			phoneNumber = domain.StringValue(p.PhoneNumber)
+		}
+	}
+
+	uuid = domain.StringValue(t.UUID)
+	_ = tu.txHandler.Commit(_tx)
+
+	if phoneNumber != "" {
+		content := fmt.Sprintf("[육아는 처음이지 발열 알림]\n%s 체온 %.1f°C (%s)",
+			domain.StringValue(c.Name), domain.Float64Value(t.Value), methodNames[domain.StringValue(t.Method)])
+		if t.IsUrgentFever(birth) {
+			content += "\n즉시 병원 진료가 필요할 수 있어요."
+		}
+		if sErr := tu.messageAgency.SendSMSToOne(phoneNumber, content); sErr != nil {
+			log.Println(errors.Wrap(sErr, "SendSMSToOne return unexpected error").Error())
+		}
+	}
+	return
+}
+
+// methodNames is korean name of temperature measurement method using in fever alert message
+var methodNames = map[string]string{
+	domain.TemperatureMethodRectal:   "직장",
+	domain.TemperatureMethodOral:     "구강",
+	domain.TemperatureMethodAxillary: "겨드랑이",
+	domain.TemperatureMethodEar:      "귀",
+	domain.TemperatureMethodForehead: "이마",
+}
+
+// GetTemperaturesByDate implement GetTemperaturesByDate method of domain.TemperatureUsecase interface
+func (tu *temperatureUsecase) GetTemperaturesByDate(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+) (temperatures []domain.Temperature, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := tu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = tu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if temperatures, err = tu.temperatureRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to); err != nil {
+		err = errors.Wrap(err, "temperature GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	_ = tu.txHandler.Commit(_tx)
+	return
+}
+
+// GetFeverEpisodes implement GetFeverEpisodes method of domain.TemperatureUsecase interface
+func (tu *temperatureUsecase) GetFeverEpisodes(
+	ctx context.Context,
+	parentUUID, childrenUUID, startDate, endDate string,
+) (episodes []domain.FeverEpisode, err error) {
+	from, _, err := domain.DayRange(startDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse start date"), Status: http.StatusBadRequest}
+		return
+	}
+	_, to, err := domain.DayRange(endDate)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse end date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	if n := int(to.Sub(from).Hours() / 24); n <= 0 || n > maxEpisodeDays {
+		err = errors.Errorf("episode range must be 1 ~ %d days", maxEpisodeDays)
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		return
+	}
+
+	_tx, err := tu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := tu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	temperatures, err := tu.temperatureRepository.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "temperature GetByChildrenUUIDInRange return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	episodes = domain.NewFeverEpisodes(domain.TimeValue(c.Birth), temperatures)
+	_ = tu.txHandler.Commit(_tx)
+	return
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (tu *temperatureUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = tu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
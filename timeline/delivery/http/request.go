@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// getDailyTimelineRequest is request for timelineHandler.GetDailyTimeline
+// Types is comma separated event types (ex. feeding,sleep)
+type getDailyTimelineRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Date         string `form:"date" validate:"required,len=10"`
+	Types        string `form:"types" validate:"max=100"`
+	Cursor       string `form:"cursor" validate:"max=11"`
+	Limit        int    `form:"limit" validate:"range=1~100"`
+}
+
+// defaultTimelineLimit is count of events returned at once if limit is not set
+const defaultTimelineLimit = 50
+
+func (r *getDailyTimelineRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	r.Limit = defaultTimelineLimit
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// typeList method return event types split from Types
+func (r *getDailyTimelineRequest) typeList() (types []string) {
+	for _, t := range strings.Split(r.Types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return
+}
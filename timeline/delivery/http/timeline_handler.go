@@ -0,0 +1,84 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// timelineHandler represent the http handler for timeline
+type timelineHandler struct {
+	tUsecase   domain.TimelineUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewTimelineHandler will initialize the timeline resources endpoint
+func NewTimelineHandler(r *gin.Engine, tu domain.TimelineUsecase, v validator, jh jwtHandler) {
+	h := &timelineHandler{
+		tUsecase:   tu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("children/uuid/:children_uuid/timeline", h.jwtHandler.ParseUUIDFromToken, h.GetDailyTimeline)
+}
+
+// GetDailyTimeline deliver data to GetDailyTimeline of domain.TimelineUsecase
+func (th *timelineHandler) GetDailyTimeline(c *gin.Context) {
+	req := new(getDailyTimelineRequest)
+	if err := th.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	events, next, err := th.tUsecase.GetDailyTimeline(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Date, req.typeList(), req.Cursor, req.Limit)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get daily timeline")
+		resp["events"] = events
+		resp["next_cursor"] = next
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetDailyTimeline return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (th *timelineHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := th.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// timelineUsecase is used for usecase layer which implement domain.TimelineUsecase interface
+type timelineUsecase struct {
+	// sources is fetcher of timeline events in range for each event type
+	sources map[string]eventSource
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// eventSource is function fetching timeline events of children owned by parent from one log in range
+type eventSource func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) ([]domain.TimelineEvent, error)
+
+// TimelineUsecase return implementation of domain.TimelineUsecase
+func TimelineUsecase(
+	fr domain.FeedingRepository,
+	sr domain.SleepRepository,
+	dr domain.DiaperRepository,
+	tr domain.TemperatureRepository,
+	mr domain.MilestoneRecordRepository,
+	er domain.ExpenditureRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.TimelineUsecase {
+	return &timelineUsecase{
+		sources: map[string]eventSource{
+			domain.TimelineEventFeeding:     feedingSource(fr),
+			domain.TimelineEventSleep:       sleepSource(sr),
+			domain.TimelineEventDiaper:      diaperSource(dr),
+			domain.TimelineEventTemperature: temperatureSource(tr),
+			domain.TimelineEventMilestone:   milestoneSource(mr),
+			domain.TimelineEventExpenditure: expenditureSource(er),
+		},
+		childrenRepository: cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// GetDailyTimeline implement GetDailyTimeline method of domain.TimelineUsecase interface
+func (tu *timelineUsecase) GetDailyTimeline(
+	ctx context.Context,
+	parentUUID, childrenUUID, date string,
+	types []string,
+	cursor string,
+	limit int,
+) (events []domain.TimelineEvent, nextCursor string, err error) {
+	from, to, err := domain.DayRange(date)
+	if err != nil {
+		err = domain.UsecaseError{UsecaseErr: errors.Wrap(err, "failed to parse date"), Status: http.StatusBadRequest}
+		return
+	}
+
+	if len(types) == 0 {
+		for _type := range tu.sources {
+			types = append(types, _type)
+		}
+	}
+	for _, _type := range types {
+		if _, ok := tu.sources[_type]; !ok {
+			err = errors.Errorf("%s is not supported timeline event type", _type)
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			return
+		}
+	}
+
+	_tx, err := tu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = tu.getOwnChildren(_tx, parentUUID, childrenUUID); err != nil {
+		_ = tu.txHandler.Rollback(_tx)
+		return
+	}
+
+	all := []domain.TimelineEvent{}
+	for _, _type := range types {
+		es, sErr := tu.sources[_type](_tx, parentUUID, childrenUUID, from, to)
+		if sErr != nil {
+			err = errors.Wrapf(sErr, "%s timeline source return unexpected error", _type)
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+			_ = tu.txHandler.Rollback(_tx)
+			return
+		}
+		all = append(all, es...)
+	}
+	_ = tu.txHandler.Commit(_tx)
+	domain.SortTimelineEvents(all)
+
+	if cursor != "" {
+		start := -1
+		for i, e := range all {
+			if e.UUID == cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start == -1 {
+			err = errors.New("event of cursor is not exist in that timeline")
+			err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+			return
+		}
+		all = all[start:]
+	}
+
+	events = all
+	if len(events) > limit {
+		events = events[:limit]
+		nextCursor = events[limit-1].UUID
+	}
+	return
+}
+
+// feedingSource function return eventSource fetching feedings
+func feedingSource(fr domain.FeedingRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		feedings, err := fr.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+		for _, f := range feedings {
+			events = append(events, domain.NewFeedingTimelineEvent(f))
+		}
+		return
+	}
+}
+
+// sleepSource function return eventSource fetching sleeps started in range
+func sleepSource(sr domain.SleepRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		sleeps, err := sr.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+		for _, s := range sleeps {
+			events = append(events, domain.NewSleepTimelineEvent(s))
+		}
+		return
+	}
+}
+
+// diaperSource function return eventSource fetching diapers
+func diaperSource(dr domain.DiaperRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		diapers, err := dr.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+		for _, d := range diapers {
+			events = append(events, domain.NewDiaperTimelineEvent(d))
+		}
+		return
+	}
+}
+
+// temperatureSource function return eventSource fetching temperatures
+func temperatureSource(tr domain.TemperatureRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		temperatures, err := tr.GetByChildrenUUIDInRange(_tx, childrenUUID, from, to)
+		for _, t := range temperatures {
+			events = append(events, domain.NewTemperatureTimelineEvent(t))
+		}
+		return
+	}
+}
+
+// milestoneSource function return eventSource fetching milestones achieved in range
+// milestones of children are few, so they are filtered here instead of query
+func milestoneSource(mr domain.MilestoneRecordRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		records, err := mr.GetByChildrenUUID(_tx, childrenUUID)
+		for _, r := range records {
+			if at := domain.TimeValue(r.AchievedAt); !at.Before(from) && at.Before(to) {
+				events = append(events, domain.NewMilestoneTimelineEvent(r))
+			}
+		}
+		return
+	}
+}
+
+// expenditureSource function return eventSource fetching expenditures of parent tagged with children
+func expenditureSource(er domain.ExpenditureRepository) eventSource {
+	return func(_tx tx.Context, parentUUID, childrenUUID string, from, to time.Time) (events []domain.TimelineEvent, err error) {
+		expenditures, err := er.GetByBabyUUIDInRange(_tx, parentUUID, childrenUUID, from, to)
+		if err != nil || len(expenditures) == 0 {
+			return
+		}
+
+		uuids := make([]string, 0, len(expenditures))
+		for _, e := range expenditures {
+			uuids = append(uuids, domain.StringValue(e.UUID))
+		}
+		tags, err := er.GetBabyTagsByExpenditureUUIDs(_tx, uuids)
+		if err != nil {
+			return
+		}
+		babies := map[string][]string{}
+		for _, tag := range tags {
+			expenditureUUID := domain.StringValue(tag.ExpenditureUUID)
+			babies[expenditureUUID] = append(babies[expenditureUUID], domain.StringValue(tag.BabyUUID))
+		}
+
+		for _, e := range expenditures {
+			e.BabyUUIDs = babies[domain.StringValue(e.UUID)]
+			events = append(events, domain.NewExpenditureTimelineEvent(e, childrenUUID))
+		}
+		return
+	}
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (tu *timelineUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = tu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
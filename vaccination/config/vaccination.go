@@ -0,0 +1,36 @@
+package config
+
+import "github.com/spf13/viper"
+
+// App is the application config about vaccination domain
+var App *vaccinationConfig
+
+// init function initialize App global variable
+func init() {
+	App = &vaccinationConfig{}
+}
+
+// vaccinationConfig have config value and implement various interface about vaccination config
+type vaccinationConfig struct {
+	// scheduleFile represent path of vaccination schedule file (use embedded schedule if empty)
+	scheduleFile *string
+}
+
+// default const value about vaccinationConfig field
+const (
+	defaultScheduleFile = ""
+)
+
+// ScheduleFile return path of vaccination schedule file
+func (vc *vaccinationConfig) ScheduleFile() string {
+	var key = "vaccination.scheduleFile"
+	if vc.scheduleFile == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultScheduleFile)
+		}
+		vc.scheduleFile = _string(viper.GetString(key))
+	}
+	return *vc.scheduleFile
+}
+
+func _string(s string) *string { return &s }
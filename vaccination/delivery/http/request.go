@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// getVaccinationScheduleRequest is request for vaccinationHandler.GetVaccinationSchedule
+type getVaccinationScheduleRequest struct {
+	ChildrenUUID string `uri:"children_uuid" validate:"required,uuid=children"`
+	Status       string `form:"status" validate:"omitempty,oneof=upcoming overdue completed"`
+}
+
+func (r *getVaccinationScheduleRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindQuery(r), "failed to BindQuery")
+}
+
+// markDoseAdministeredRequest is request for vaccinationHandler.MarkDoseAdministered
+type markDoseAdministeredRequest struct {
+	ChildrenUUID   string `uri:"children_uuid" validate:"required,uuid=children"`
+	VaccineCode    string `json:"vaccine_code" validate:"required,max=20"`
+	DoseNumber     int64  `json:"dose_number" validate:"required,range=1~20"`
+	AdministeredAt string `json:"administered_at" validate:"required,max=20"`
+	Hospital       string `json:"hospital" validate:"max=50"`
+	LotNumber      string `json:"lot_number" validate:"max=30"`
+}
+
+func (r *markDoseAdministeredRequest) BindFrom(c *gin.Context) error {
+	if err := c.BindUri(r); err != nil {
+		return errors.Wrap(err, "failed to BindUri")
+	}
+	return errors.Wrap(c.BindJSON(r), "failed to BindJSON")
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// vaccinationHandler represent the http handler for vaccination
+type vaccinationHandler struct {
+	vUsecase   domain.VaccinationUsecase
+	validator  validator
+	jwtHandler jwtHandler
+}
+
+// jwtHandler is interface of jwt handler
+type jwtHandler interface {
+	// ParseUUIDFromToken parse token & return token payload and type
+	ParseUUIDFromToken(c *gin.Context)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// NewVaccinationHandler will initialize the vaccination resources endpoint
+func NewVaccinationHandler(r *gin.Engine, vu domain.VaccinationUsecase, v validator, jh jwtHandler) {
+	h := &vaccinationHandler{
+		vUsecase:   vu,
+		validator:  v,
+		jwtHandler: jh,
+	}
+
+	r.GET("children/uuid/:children_uuid/vaccinations", h.jwtHandler.ParseUUIDFromToken, h.GetVaccinationSchedule)
+	r.POST("children/uuid/:children_uuid/vaccinations", h.jwtHandler.ParseUUIDFromToken, h.MarkDoseAdministered)
+}
+
+// GetVaccinationSchedule deliver data to GetVaccinationSchedule of domain.VaccinationUsecase
+func (vh *vaccinationHandler) GetVaccinationSchedule(c *gin.Context) {
+	req := new(getVaccinationScheduleRequest)
+	if err := vh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	doses, err := vh.vUsecase.GetVaccinationSchedule(c.Request.Context(), c.GetString("uuid"), req.ChildrenUUID, req.Status)
+	switch tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusOK, 0, "succeed to get vaccination schedule")
+		resp["doses"] = doses
+		c.JSON(http.StatusOK, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "GetVaccinationSchedule return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// MarkDoseAdministered deliver data to MarkDoseAdministered of domain.VaccinationUsecase
+func (vh *vaccinationHandler) MarkDoseAdministered(c *gin.Context) {
+	req := new(markDoseAdministeredRequest)
+	if err := vh.bindRequest(req, c); err != nil {
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	}
+
+	vr := &domain.VaccinationRecord{
+		ChildrenUUID: domain.String(req.ChildrenUUID),
+		VaccineCode:  domain.String(req.VaccineCode),
+		DoseNumber:   domain.Int64(req.DoseNumber),
+	}
+	if req.Hospital != "" {
+		vr.Hospital = domain.String(req.Hospital)
+	}
+	if req.LotNumber != "" {
+		vr.LotNumber = domain.String(req.LotNumber)
+	}
+
+	if t, err := time.Parse("2006-01-02", req.AdministeredAt); err != nil {
+		err = errors.Wrap(err, "failed to parse administered_at time string")
+		c.JSON(http.StatusBadRequest, defaultResp(http.StatusBadRequest, 0, err.Error()))
+		return
+	} else {
+		vr.AdministeredAt = domain.Time(t)
+	}
+
+	switch uuid, err := vh.vUsecase.MarkDoseAdministered(c.Request.Context(), c.GetString("uuid"), vr); tErr := err.(type) {
+	case nil:
+		resp := defaultResp(http.StatusCreated, 0, "succeed to mark dose administered")
+		resp["vaccination_uuid"] = uuid
+		c.JSON(http.StatusCreated, resp)
+	case domain.UsecaseError:
+		c.JSON(tErr.Status, defaultResp(tErr.Status, tErr.Code, tErr.Error()))
+	default:
+		msg := errors.Wrap(err, "MarkDoseAdministered return unexpected error").Error()
+		c.JSON(http.StatusInternalServerError, defaultResp(http.StatusInternalServerError, 0, msg))
+	}
+	return
+}
+
+// bindRequest method bind *gin.Context to request having BindFrom method
+func (vh *vaccinationHandler) bindRequest(req interface {
+	BindFrom(ctx *gin.Context) error
+}, c *gin.Context) error {
+	if err := req.BindFrom(c); err != nil {
+		return errors.Wrap(err, "failed to bind req")
+	}
+	if err := vh.validator.ValidateStruct(req); err != nil {
+		return errors.Wrap(err, "invalid request")
+	}
+	return nil
+}
+
+// defaultResp return response have status, code, message inform
+func defaultResp(status, code int, msg string) (resp gin.H) {
+	resp = gin.H{}
+	resp["status"] = status
+	resp["code"] = code
+	resp["message"] = msg
+	return
+}
@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"log"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// vaccinationRecordRepository is implementation of domain.VaccinationRecordRepository using mysql
+type vaccinationRecordRepository struct {
+	db           *sqlx.DB
+	migrator     migrator
+	sqlMsgParser sqlMsgParser
+	validator    validator
+}
+
+// sqlMsgParser is interface used for parse sql result message
+type sqlMsgParser interface {
+	EntryDuplicate(msg string) (entry, key string)
+	NoReferencedRow(msg string) (fk string)
+}
+
+// validator is interface used for validating struct value
+type validator interface {
+	ValidateStruct(s interface{}) (err error)
+}
+
+// VaccinationRecordRepository return implementation of domain.VaccinationRecordRepository using mysql
+func VaccinationRecordRepository(
+	db *sqlx.DB,
+	sp sqlMsgParser,
+	v validator,
+) domain.VaccinationRecordRepository {
+	repo := &vaccinationRecordRepository{
+		db:           db,
+		sqlMsgParser: sp,
+		validator:    v,
+	}
+
+	if err := repo.migrator.MigrateModel(repo.db, domain.VaccinationRecord{}); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to migrate vaccination record model").Error())
+	}
+	return repo
+}
+
+// Store is implement Store method of domain.VaccinationRecordRepository interface
+func (vr *vaccinationRecordRepository) Store(ctx tx.Context, r *domain.VaccinationRecord) (err error) {
+	if domain.StringValue(r.UUID) == "" {
+		if r.UUID, err = vr.GetAvailableUUID(ctx); err != nil {
+			return errors.Wrap(err, "failed to GetAvailableUUID")
+		}
+	}
+
+	if err = vr.validator.ValidateStruct(r); err != nil {
+		return domain.ErrInvalidModel{RepoErr: errors.Wrap(err, "failed to validate domain.VaccinationRecord")}
+	}
+
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Insert("vaccination_record").
+		Columns("uuid", "children_uuid", "vaccine_code", "dose_number", "schedule_version", "administered_at", "hospital", "lot_number").
+		Values(r.UUID, r.ChildrenUUID, r.VaccineCode, r.DoseNumber, r.ScheduleVersion, r.AdministeredAt, r.Hospital, r.LotNumber).ToSql()
+
+	switch _, err = _tx.Exec(_sql, args...); tErr := err.(type) {
+	case nil:
+		break
+	case *mysql.MySQLError:
+		switch tErr.Number {
+		case mysqlerr.ER_DUP_ENTRY:
+			err = errors.Wrap(err, "failed to insert vaccination record")
+			_, key := vr.sqlMsgParser.EntryDuplicate(tErr.Message)
+			err = domain.ErrEntryDuplicate{RepoErr: err, DuplicateKey: key}
+		case mysqlerr.ER_NO_REFERENCED_ROW_2:
+			err = errors.Wrap(err, "failed to insert vaccination record")
+			fk := vr.sqlMsgParser.NoReferencedRow(tErr.Message)
+			err = domain.ErrNoReferencedRow{RepoErr: err, ForeignKey: fk}
+		default:
+			err = errors.Wrap(err, "insert vaccination record return unexpected code return")
+		}
+	default:
+		err = errors.Wrap(err, "insert vaccination record return unexpected error type")
+	}
+	return
+}
+
+// GetByUUID is implement GetByUUID method of domain.VaccinationRecordRepository interface
+func (vr *vaccinationRecordRepository) GetByUUID(ctx tx.Context, uuid string) (r domain.VaccinationRecord, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("vaccination_record").Where("uuid = ?", uuid).ToSql()
+
+	switch err = _tx.Get(&r, _sql, args...); err {
+	case nil:
+		break
+	case sql.ErrNoRows:
+		err = domain.ErrRowNotExist{RepoErr: errors.Wrap(err, "failed to select vaccination record")}
+	default:
+		err = errors.Wrap(err, "select vaccination record return unexpected error")
+	}
+	return
+}
+
+// GetByChildrenUUID is implement GetByChildrenUUID method of domain.VaccinationRecordRepository interface
+func (vr *vaccinationRecordRepository) GetByChildrenUUID(ctx tx.Context, childrenUUID string) (records []domain.VaccinationRecord, err error) {
+	_tx, _ := ctx.Tx().(*sqlx.Tx)
+	_sql, args, _ := squirrel.Select("*").From("vaccination_record").
+		Where("children_uuid = ?", childrenUUID).
+		OrderBy("administered_at").ToSql()
+
+	records = []domain.VaccinationRecord{}
+	if err = _tx.Select(&records, _sql, args...); err != nil {
+		err = errors.Wrap(err, "select vaccination records return unexpected error")
+	}
+	return
+}
+
+// GetAvailableUUID method return available uuid of vaccination record table
+func (vr *vaccinationRecordRepository) GetAvailableUUID(ctx tx.Context) (*string, error) {
+	r := new(domain.VaccinationRecord)
+
+	for {
+		uuid := r.GenerateRandomUUID()
+		_, err := vr.GetByUUID(ctx, uuid)
+
+		if err == nil {
+			continue
+		} else if _, ok := err.(domain.ErrRowNotExist); ok {
+			return &uuid, nil
+		} else {
+			return nil, errors.Wrap(err, "failed to GetByUUID")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package schedule
+
+import (
+	"embed"
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+)
+
+// defaultScheduleFile is name of embedded schedule file used when schedule file is not set
+const defaultScheduleFile = "data/kr-nip-2021.json"
+
+//go:embed data/*.json
+var embedded embed.FS
+
+// Load function return domain.VaccinationSchedule read from file in path (embedded default if path is empty)
+func Load(path string) (vs domain.VaccinationSchedule, err error) {
+	var b []byte
+	if path == "" {
+		b, err = embedded.ReadFile(defaultScheduleFile)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		err = errors.Wrap(err, "failed to read vaccination schedule file")
+		return
+	}
+
+	if err = json.Unmarshal(b, &vs); err != nil {
+		err = errors.Wrap(err, "failed to unmarshal vaccination schedule")
+		return
+	}
+
+	if vs.Version == "" {
+		err = errors.New("vaccination schedule must have version")
+		return
+	}
+	for _, v := range vs.Vaccines {
+		for _, d := range v.Doses {
+			if _, err = domain.AddScheduleAge(time.Time{}, d.Start); err != nil {
+				return
+			}
+			if _, err = domain.AddScheduleAge(time.Time{}, d.End); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
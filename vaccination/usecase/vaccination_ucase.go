@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/MyFirstBabyTime/Server/domain"
+	"github.com/MyFirstBabyTime/Server/tx"
+)
+
+// vaccinationUsecase is used for usecase layer which implement domain.VaccinationUsecase interface
+type vaccinationUsecase struct {
+	// schedule is national immunization table that children schedule is built from
+	schedule domain.VaccinationSchedule
+
+	// vaccinationRecordRepository is repository interface about domain.VaccinationRecord model
+	vaccinationRecordRepository domain.VaccinationRecordRepository
+
+	// childrenRepository is repository interface about domain.Children model
+	childrenRepository domain.ChildrenRepository
+
+	// txHandler is used for handling transaction to begin & commit or rollback
+	txHandler txHandler
+}
+
+// VaccinationUsecase return implementation of domain.VaccinationUsecase
+func VaccinationUsecase(
+	vs domain.VaccinationSchedule,
+	vrr domain.VaccinationRecordRepository,
+	cr domain.ChildrenRepository,
+	th txHandler,
+) domain.VaccinationUsecase {
+	return &vaccinationUsecase{
+		schedule: vs,
+
+		vaccinationRecordRepository: vrr,
+		childrenRepository:          cr,
+
+		txHandler: th,
+	}
+}
+
+// txHandler is used for handling transaction to begin & commit or rollback
+type txHandler interface {
+	// BeginTx method start transaction (get option from ctx)
+	BeginTx(ctx context.Context, opts interface{}) (tx tx.Context, err error)
+
+	// Commit method commit transaction
+	Commit(tx tx.Context) (err error)
+
+	// Rollback method rollback transaction
+	Rollback(tx tx.Context) (err error)
+}
+
+// GetVaccinationSchedule implement GetVaccinationSchedule method of domain.VaccinationUsecase interface
+func (vu *vaccinationUsecase) GetVaccinationSchedule(
+	ctx context.Context,
+	parentUUID, childrenUUID, status string,
+) (doses []domain.VaccinationDose, err error) {
+	_tx, err := vu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	c, err := vu.getOwnChildren(_tx, parentUUID, childrenUUID)
+	if err != nil {
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if !c.IsBorn() {
+		err = errors.New("that children is not born yet")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict, Code: domain.ChildrenNotBornYet}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+
+	records, err := vu.vaccinationRecordRepository.GetByChildrenUUID(_tx, childrenUUID)
+	if err != nil {
+		err = errors.Wrap(err, "vaccination record GetByChildrenUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+
+	doses = []domain.VaccinationDose{}
+	for _, dose := range vu.buildSchedule(c, records, time.Now()) {
+		if status == "" || dose.Status == status {
+			doses = append(doses, dose)
+		}
+	}
+
+	_ = vu.txHandler.Commit(_tx)
+	return
+}
+
+// MarkDoseAdministered implement MarkDoseAdministered method of domain.VaccinationUsecase interface
+func (vu *vaccinationUsecase) MarkDoseAdministered(
+	ctx context.Context,
+	parentUUID string,
+	vr *domain.VaccinationRecord,
+) (uuid string, err error) {
+	_tx, err := vu.txHandler.BeginTx(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to begin transaction")
+		return
+	}
+
+	if _, err = vu.getOwnChildren(_tx, parentUUID, domain.StringValue(vr.ChildrenUUID)); err != nil {
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+
+	if v, ok := vu.schedule.GetVaccine(domain.StringValue(vr.VaccineCode)); !ok || !v.HasDose(domain.Int64Value(vr.DoseNumber)) {
+		err = errors.New("that dose is not exist in vaccination schedule")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+	vr.ScheduleVersion = domain.String(vu.schedule.Version)
+
+	switch err = vu.vaccinationRecordRepository.Store(_tx, vr); err.(type) {
+	case nil:
+		break
+	case domain.ErrEntryDuplicate:
+		err = errors.New("that dose is already administered")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusConflict}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	case domain.ErrInvalidModel:
+		err = errors.Wrap(err, "vaccination record Store return invalid model")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusBadRequest}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	default:
+		err = errors.Wrap(err, "vaccination record Store return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		_ = vu.txHandler.Rollback(_tx)
+		return
+	}
+
+	uuid = domain.StringValue(vr.UUID)
+	_ = vu.txHandler.Commit(_tx)
+	return
+}
+
+// buildSchedule method build doses of children's schedule with administered records
+func (vu *vaccinationUsecase) buildSchedule(c domain.Children, records []domain.VaccinationRecord, now time.Time) []domain.VaccinationDose {
+	administered := map[string]map[int64]domain.VaccinationRecord{}
+	for _, r := range records {
+		code := domain.StringValue(r.VaccineCode)
+		if _, ok := administered[code]; !ok {
+			administered[code] = map[int64]domain.VaccinationRecord{}
+		}
+		administered[code][domain.Int64Value(r.DoseNumber)] = r
+	}
+
+	birth := domain.TimeValue(c.Birth)
+	doses := make([]domain.VaccinationDose, 0)
+	for _, v := range vu.schedule.Vaccines {
+		if v.Sex != "" && v.Sex != domain.StringValue(c.Sex) {
+			continue
+		}
+
+		for _, d := range v.Doses {
+			// age string is checked while loading schedule
+			start, _ := domain.AddScheduleAge(birth, d.Start)
+			end, _ := domain.AddScheduleAge(birth, d.End)
+			dose := domain.VaccinationDose{
+				VaccineCode: v.Code,
+				VaccineName: v.Name,
+				Disease:     v.Disease,
+				DoseNumber:  d.Number,
+				StartDate:   start,
+				EndDate:     end,
+			}
+
+			if r, ok := administered[v.Code][d.Number]; ok {
+				dose.Status = domain.VaccinationDoseCompleted
+				dose.Record = &r
+			} else if now.After(end) {
+				dose.Status = domain.VaccinationDoseOverdue
+			} else {
+				dose.Status = domain.VaccinationDoseUpcoming
+			}
+			doses = append(doses, dose)
+		}
+	}
+
+	sort.SliceStable(doses, func(i, j int) bool {
+		return doses[i].StartDate.Before(doses[j].StartDate)
+	})
+	return doses
+}
+
+// getOwnChildren method return children with uuid if parent with parentUUID own that children
+func (vu *vaccinationUsecase) getOwnChildren(_tx tx.Context, parentUUID, childrenUUID string) (c domain.Children, err error) {
+	switch c, err = vu.childrenRepository.GetByUUID(_tx, childrenUUID); err.(type) {
+	case nil:
+		break
+	case domain.ErrRowNotExist:
+		err = errors.New("children with that uuid is not exist")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusNotFound}
+		return
+	default:
+		err = errors.Wrap(err, "children GetByUUID return unexpected error")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusInternalServerError}
+		return
+	}
+
+	if domain.StringValue(c.ParentUUID) != parentUUID {
+		err = errors.New("you can't access to that children")
+		err = domain.UsecaseError{UsecaseErr: err, Status: http.StatusForbidden}
+	}
+	return
+}
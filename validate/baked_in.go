@@ -21,6 +21,48 @@ func isValidateUUID(fl validator.FieldLevel) bool {
 		return itemUUIDRegex.MatchString(fl.Field().String())
 	case "children":
 		return childrenRegex.MatchString(fl.Field().String())
+	case "vaccination":
+		return vaccinationUUIDRegex.MatchString(fl.Field().String())
+	case "feeding":
+		return feedingUUIDRegex.MatchString(fl.Field().String())
+	case "sleep":
+		return sleepUUIDRegex.MatchString(fl.Field().String())
+	case "diaper":
+		return diaperUUIDRegex.MatchString(fl.Field().String())
+	case "milestone":
+		return milestoneUUIDRegex.MatchString(fl.Field().String())
+	case "album":
+		return albumUUIDRegex.MatchString(fl.Field().String())
+	case "hospital_visit":
+		return hospitalVisitUUIDRegex.MatchString(fl.Field().String())
+	case "medication":
+		return medicationUUIDRegex.MatchString(fl.Field().String())
+	case "medication_dose":
+		return medicationDoseUUIDRegex.MatchString(fl.Field().String())
+	case "food_introduction":
+		return foodIntroductionUUIDRegex.MatchString(fl.Field().String())
+	case "temperature":
+		return temperatureUUIDRegex.MatchString(fl.Field().String())
+	case "prenatal":
+		return prenatalUUIDRegex.MatchString(fl.Field().String())
+	case "pumping":
+		return pumpingUUIDRegex.MatchString(fl.Field().String())
+	case "milk_bag":
+		return milkBagUUIDRegex.MatchString(fl.Field().String())
+	case "active_session":
+		return activeSessionUUIDRegex.MatchString(fl.Field().String())
+	case "emergency_contact":
+		return emergencyContactUUIDRegex.MatchString(fl.Field().String())
+	case "expenditure_category":
+		return expenditureCategoryUUIDRegex.MatchString(fl.Field().String())
+	case "expenditure_budget":
+		return expenditureBudgetUUIDRegex.MatchString(fl.Field().String())
+	case "recurring_expenditure":
+		return recurringExpenditureUUIDRegex.MatchString(fl.Field().String())
+	case "expenditure_receipt":
+		return expenditureReceiptUUIDRegex.MatchString(fl.Field().String())
+	case "growth_measurement":
+		return growthMeasurementUUIDRegex.MatchString(fl.Field().String())
 	}
 	return false
 }
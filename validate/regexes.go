@@ -3,13 +3,55 @@ package validate
 import "regexp"
 
 const (
-	parentUUIDRegexString = "^p\\d{10}$"
-	itemUUIDRegexString   = "^e\\d{10}$"
-	childrenRegexString   = "^c\\d{10}$"
+	parentUUIDRegexString               = "^p\\d{10}$"
+	itemUUIDRegexString                 = "^e\\d{10}$"
+	childrenRegexString                 = "^c\\d{10}$"
+	vaccinationUUIDRegexString          = "^v\\d{10}$"
+	feedingUUIDRegexString              = "^f\\d{10}$"
+	sleepUUIDRegexString                = "^s\\d{10}$"
+	diaperUUIDRegexString               = "^d\\d{10}$"
+	milestoneUUIDRegexString            = "^m\\d{10}$"
+	albumUUIDRegexString                = "^a\\d{10}$"
+	hospitalVisitUUIDRegexString        = "^h\\d{10}$"
+	medicationUUIDRegexString           = "^r\\d{10}$"
+	medicationDoseUUIDRegexString       = "^g\\d{10}$"
+	foodIntroductionUUIDRegexString     = "^i\\d{10}$"
+	temperatureUUIDRegexString          = "^t\\d{10}$"
+	prenatalUUIDRegexString             = "^n\\d{10}$"
+	pumpingUUIDRegexString              = "^k\\d{10}$"
+	milkBagUUIDRegexString              = "^b\\d{10}$"
+	activeSessionUUIDRegexString        = "^l\\d{10}$"
+	emergencyContactUUIDRegexString     = "^o\\d{10}$"
+	expenditureCategoryUUIDRegexString  = "^x\\d{10}$"
+	expenditureBudgetUUIDRegexString    = "^u\\d{10}$"
+	recurringExpenditureUUIDRegexString = "^q\\d{10}$"
+	expenditureReceiptUUIDRegexString   = "^w\\d{10}$"
+	growthMeasurementUUIDRegexString    = "^j\\d{10}$"
 )
 
 var (
-	parentUUIDRegex = regexp.MustCompile(parentUUIDRegexString)
-	itemUUIDRegex   = regexp.MustCompile(itemUUIDRegexString)
-	childrenRegex   = regexp.MustCompile(childrenRegexString)
+	parentUUIDRegex               = regexp.MustCompile(parentUUIDRegexString)
+	itemUUIDRegex                 = regexp.MustCompile(itemUUIDRegexString)
+	childrenRegex                 = regexp.MustCompile(childrenRegexString)
+	vaccinationUUIDRegex          = regexp.MustCompile(vaccinationUUIDRegexString)
+	feedingUUIDRegex              = regexp.MustCompile(feedingUUIDRegexString)
+	sleepUUIDRegex                = regexp.MustCompile(sleepUUIDRegexString)
+	diaperUUIDRegex               = regexp.MustCompile(diaperUUIDRegexString)
+	milestoneUUIDRegex            = regexp.MustCompile(milestoneUUIDRegexString)
+	albumUUIDRegex                = regexp.MustCompile(albumUUIDRegexString)
+	hospitalVisitUUIDRegex        = regexp.MustCompile(hospitalVisitUUIDRegexString)
+	medicationUUIDRegex           = regexp.MustCompile(medicationUUIDRegexString)
+	medicationDoseUUIDRegex       = regexp.MustCompile(medicationDoseUUIDRegexString)
+	foodIntroductionUUIDRegex     = regexp.MustCompile(foodIntroductionUUIDRegexString)
+	temperatureUUIDRegex          = regexp.MustCompile(temperatureUUIDRegexString)
+	prenatalUUIDRegex             = regexp.MustCompile(prenatalUUIDRegexString)
+	pumpingUUIDRegex              = regexp.MustCompile(pumpingUUIDRegexString)
+	milkBagUUIDRegex              = regexp.MustCompile(milkBagUUIDRegexString)
+	activeSessionUUIDRegex        = regexp.MustCompile(activeSessionUUIDRegexString)
+	emergencyContactUUIDRegex     = regexp.MustCompile(emergencyContactUUIDRegexString)
+	expenditureCategoryUUIDRegex  = regexp.MustCompile(expenditureCategoryUUIDRegexString)
+	expenditureBudgetUUIDRegex    = regexp.MustCompile(expenditureBudgetUUIDRegexString)
+	recurringExpenditureUUIDRegex = regexp.MustCompile(recurringExpenditureUUIDRegexString)
+	expenditureReceiptUUIDRegex   = regexp.MustCompile(expenditureReceiptUUIDRegexString)
+	growthMeasurementUUIDRegex    = regexp.MustCompile(growthMeasurementUUIDRegexString)
 )